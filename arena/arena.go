@@ -0,0 +1,258 @@
+// Package arena plays games between computer players, without anyone
+// watching, and tells how well each of them did.
+package arena
+
+import (
+	"el_poblador/game"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Kinds are the computer players the arena knows by name. Each makes a new
+// bot for every game, from a seed when it makes random choices. More can be
+// added.
+var Kinds = map[string]func(seed uint64) game.Bot{
+	"random": func(seed uint64) game.Bot { return game.NewRandomBot(seed) },
+	"greedy": func(uint64) game.Bot { return game.NewSimpleBot() },
+	"easy":   func(seed uint64) game.Bot { return game.NewHeuristicBotSeeded(game.Easy, seed) },
+	"normal": func(seed uint64) game.Bot { return game.NewHeuristicBotSeeded(game.Normal, seed) },
+	"hard":   func(seed uint64) game.Bot { return game.NewHeuristicBotSeeded(game.Hard, seed) },
+	// a fixed number of playouts rather than a time budget, so results don't
+	// depend on the machine
+	"mcts": func(seed uint64) game.Bot { return game.NewMCTSBotSeeded(0, seed) },
+}
+
+// Entrant is one of the computer players taking part
+type Entrant struct {
+	Name string
+	New  func(seed uint64) (game.Bot, error)
+}
+
+// ParseEntrant reads one of the Kinds, or "engine:<program>" for a program
+// speaking the engine protocol, started anew for every game
+func ParseEntrant(spec string) (Entrant, error) {
+	if program, ok := strings.CutPrefix(spec, "engine:"); ok {
+		return Entrant{Name: spec, New: func(uint64) (game.Bot, error) {
+			return game.NewEngineBot(program)
+		}}, nil
+	}
+	kind, ok := Kinds[spec]
+	if !ok {
+		names := make([]string, 0, len(Kinds))
+		for name := range Kinds {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return Entrant{}, fmt.Errorf("unknown player %q, expected engine:<program> or one of %s", spec, strings.Join(names, ", "))
+	}
+	return Entrant{Name: spec, New: func(seed uint64) (game.Bot, error) { return kind(seed), nil }}, nil
+}
+
+// Config says who plays and how many games
+type Config struct {
+	// Entrants are seated in every order in turn, 3 or 4 of them
+	Entrants []Entrant
+	Games    int
+	// Seed deals the first board; every rotation of the seats plays the same
+	// board, cards and dice before moving on to the next seed
+	Seed uint64
+	// MaxTurns ends games that go on for longer without a winner, 0 for
+	// defaultMaxTurns
+	MaxTurns int
+	// Progress, if set, is called after every game
+	Progress func(played int)
+}
+
+const defaultMaxTurns = 500
+
+// Result is how the entrants did
+type Result struct {
+	Games int
+	// Unfinished counts the games stopped at MaxTurns
+	Unfinished int
+	// Turns adds up the length of every game
+	Turns   int
+	Players []Standing
+}
+
+// Standing is how one entrant did, in the same order as Config.Entrants
+type Standing struct {
+	Name string
+	Wins int
+	// Points are the victory points the entrant ended each game with
+	Points []int
+}
+
+// Run plays the games, one after another
+func Run(config Config) (Result, error) {
+	n := len(config.Entrants)
+	if n < 3 || n > 4 {
+		return Result{}, fmt.Errorf("the arena needs 3 or 4 players, got %d", n)
+	}
+	maxTurns := config.MaxTurns
+	if maxTurns == 0 {
+		maxTurns = defaultMaxTurns
+	}
+	result := Result{Players: make([]Standing, n)}
+	entrants := slices.Clone(config.Entrants)
+	for i := range entrants {
+		// tell apart players of the same kind
+		name := entrants[i].Name
+		for k := 2; slices.ContainsFunc(entrants[:i], func(e Entrant) bool { return e.Name == name }); k++ {
+			name = fmt.Sprintf("%s %d", entrants[i].Name, k)
+		}
+		entrants[i].Name = name
+		result.Players[i].Name = name
+	}
+	for i := 0; i < config.Games; i++ {
+		seed := config.Seed + uint64(i/n)
+		rotation := i % n
+		if err := result.play(entrants, seed, rotation, maxTurns); err != nil {
+			return result, err
+		}
+		if config.Progress != nil {
+			config.Progress(i + 1)
+		}
+	}
+	return result, nil
+}
+
+// play plays one game, the entrant at index (seat + rotation) % n in each seat
+func (r *Result) play(entrants []Entrant, seed uint64, rotation, maxTurns int) error {
+	n := len(entrants)
+	seats := make([]game.Seat, n)
+	entrantAt := make([]int, n)
+	bots := make([]game.Bot, n)
+	defer func() {
+		for _, bot := range bots {
+			if closer, ok := bot.(io.Closer); ok {
+				closer.Close()
+			}
+		}
+	}()
+	for seat := range seats {
+		entrantAt[seat] = (seat + rotation) % n
+		entrant := entrants[entrantAt[seat]]
+		// no colors, so nothing is styled for a terminal
+		seats[seat] = game.Seat{Name: entrant.Name}
+		bot, err := entrant.New(seed*uint64(n) + uint64(entrantAt[seat]))
+		if err != nil {
+			return fmt.Errorf("starting %s: %w", entrant.Name, err)
+		}
+		bots[seat] = bot
+	}
+
+	g := &game.Game{}
+	g.Seed(seed)
+	g.StartSeated(seats)
+	for seat, bot := range bots {
+		g.SetBot(seat, bot)
+	}
+	// every call plays at least a step, so this ends even if no turn does
+	for calls := 0; g.CheckGameEnd() == nil && g.TurnsPlayed < maxTurns && calls < maxTurns; calls++ {
+		g.PlayBots()
+	}
+
+	r.Games++
+	r.Turns += g.TurnsPlayed
+	winner := g.CheckGameEnd()
+	if winner == nil {
+		r.Unfinished++
+	}
+	for seat := range g.Players {
+		standing := &r.Players[entrantAt[seat]]
+		if winner == &g.Players[seat] {
+			standing.Wins++
+		}
+		standing.Points = append(standing.Points, g.Players[seat].VictoryPoints(g))
+	}
+	return nil
+}
+
+// WinRate is the share of games won, with a 95% Wilson confidence interval
+func (s Standing) WinRate() (rate, low, high float64) {
+	games := float64(len(s.Points))
+	if games == 0 {
+		return 0, 0, 1
+	}
+	const z = 1.96
+	rate = float64(s.Wins) / games
+	center := (rate + z*z/(2*games)) / (1 + z*z/games)
+	spread := z / (1 + z*z/games) * math.Sqrt(rate*(1-rate)/games+z*z/(4*games*games))
+	return rate, max(center-spread, 0), min(center+spread, 1)
+}
+
+// MeanPoints returns the average victory points and their standard deviation
+func (s Standing) MeanPoints() (mean, deviation float64) {
+	if len(s.Points) == 0 {
+		return 0, 0
+	}
+	for _, points := range s.Points {
+		mean += float64(points)
+	}
+	mean /= float64(len(s.Points))
+	for _, points := range s.Points {
+		deviation += (float64(points) - mean) * (float64(points) - mean)
+	}
+	return mean, math.Sqrt(deviation / float64(len(s.Points)))
+}
+
+// AverageTurns is how many turns a game lasted on average
+func (r Result) AverageTurns() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.Turns) / float64(r.Games)
+}
+
+// Report writes the result as plain text tables
+func (r Result) Report(w io.Writer) {
+	fmt.Fprintf(w, "%d games, %.1f turns on average", r.Games, r.AverageTurns())
+	if r.Unfinished > 0 {
+		fmt.Fprintf(w, ", %d stopped without a winner", r.Unfinished)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "player\twins\twin rate\t95% CI\tVP\tVP sd\t")
+	for _, s := range r.Players {
+		rate, low, high := s.WinRate()
+		mean, deviation := s.MeanPoints()
+		fmt.Fprintf(table, "%s\t%d\t%.1f%%\t%.1f-%.1f%%\t%.2f\t%.2f\t\n",
+			s.Name, s.Wins, rate*100, low*100, high*100, mean, deviation)
+	}
+	table.Flush()
+	fmt.Fprintln(w)
+
+	// how many games each player ended with each number of points
+	most := 0
+	for _, s := range r.Players {
+		for _, points := range s.Points {
+			most = max(most, points)
+		}
+	}
+	fmt.Fprintln(w, "Final victory points:")
+	table = tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.AlignRight)
+	fmt.Fprint(table, "player\t")
+	for points := 0; points <= most; points++ {
+		fmt.Fprintf(table, "%d\t", points)
+	}
+	fmt.Fprintln(table)
+	for _, s := range r.Players {
+		counts := make([]int, most+1)
+		for _, points := range s.Points {
+			counts[points]++
+		}
+		fmt.Fprintf(table, "%s\t", s.Name)
+		for _, count := range counts {
+			fmt.Fprintf(table, "%d\t", count)
+		}
+		fmt.Fprintln(table)
+	}
+	table.Flush()
+}
@@ -0,0 +1,106 @@
+package arena
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func entrants(t *testing.T, specs ...string) []Entrant {
+	var entrants []Entrant
+	for _, spec := range specs {
+		entrant, err := ParseEntrant(spec)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", spec, err)
+		}
+		entrants = append(entrants, entrant)
+	}
+	return entrants
+}
+
+func TestRunPlaysEveryEntrantInEveryGame(t *testing.T) {
+	result, err := Run(Config{Entrants: entrants(t, "random", "greedy", "hard"), Games: 6, Seed: 1})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Games != 6 {
+		t.Fatalf("Expected 6 games, got %d", result.Games)
+	}
+	wins := result.Unfinished
+	for _, s := range result.Players {
+		if len(s.Points) != 6 {
+			t.Errorf("Expected %s to play 6 games, got %d", s.Name, len(s.Points))
+		}
+		wins += s.Wins
+	}
+	if wins != 6 {
+		t.Errorf("Expected every game won or unfinished, got %d", wins)
+	}
+	if result.AverageTurns() <= 0 {
+		t.Errorf("Expected the games to last some turns")
+	}
+}
+
+func TestRunIsRepeatable(t *testing.T) {
+	for _, config := range []Config{
+		{Entrants: entrants(t, "random", "greedy", "normal"), Games: 3, Seed: 7},
+		// the search is slow, a few rounds show it repeats itself
+		{Entrants: entrants(t, "easy", "mcts", "hard"), Games: 1, Seed: 7, MaxTurns: 12},
+	} {
+		play := func() Result {
+			result, err := Run(config)
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			return result
+		}
+		first, second := play(), play()
+		for i := range first.Players {
+			if !slices.Equal(first.Players[i].Points, second.Players[i].Points) {
+				t.Errorf("Expected the same seed to play the same games, %s got %v and %v",
+					first.Players[i].Name, first.Players[i].Points, second.Players[i].Points)
+			}
+		}
+	}
+}
+
+func TestRunNamesPlayersOfTheSameKind(t *testing.T) {
+	result, err := Run(Config{Entrants: entrants(t, "greedy", "greedy", "random"), Games: 1})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Players[0].Name != "greedy" || result.Players[1].Name != "greedy 2" {
+		t.Errorf("Expected greedy and greedy 2, got %s and %s", result.Players[0].Name, result.Players[1].Name)
+	}
+}
+
+func TestParseEntrantRejectsUnknownPlayers(t *testing.T) {
+	if _, err := ParseEntrant("grandmaster"); err == nil {
+		t.Errorf("Expected an unknown player to be refused")
+	}
+}
+
+func TestWinRateInterval(t *testing.T) {
+	s := Standing{Wins: 50, Points: make([]int, 100)}
+	rate, low, high := s.WinRate()
+	if rate != 0.5 || low < 0.40 || low > 0.41 || high < 0.59 || high > 0.60 {
+		t.Errorf("Expected 50%% within about 40-60%%, got %.3f in %.3f-%.3f", rate, low, high)
+	}
+}
+
+func TestReportIsPlainText(t *testing.T) {
+	result := Result{Games: 2, Turns: 100, Players: []Standing{
+		{Name: "a", Wins: 2, Points: []int{10, 10}},
+		{Name: "b", Points: []int{4, 6}},
+		{Name: "c", Points: []int{3, 2}},
+	}}
+	var out bytes.Buffer
+	result.Report(&out)
+	if strings.Contains(out.String(), "\x1b") {
+		t.Errorf("Expected no terminal styling, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "50.0 turns on average") || !strings.Contains(out.String(), "100.0%") {
+		t.Errorf("Expected the game length and win rate, got:\n%s", out.String())
+	}
+}
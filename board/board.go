@@ -1,6 +1,7 @@
 package board
 
 import (
+	"maps"
 	"slices"
 
 	"github.com/charmbracelet/lipgloss"
@@ -17,6 +18,18 @@ type Board struct {
 	Robber       TileCoord
 }
 
+// Clone returns a deep copy of the board that can change independently
+func (b *Board) Clone() *Board {
+	return &Board{
+		Tiles:        maps.Clone(b.Tiles),
+		Roads:        maps.Clone(b.Roads),
+		Settlements:  maps.Clone(b.Settlements),
+		CityUpgrades: maps.Clone(b.CityUpgrades),
+		PlayerColors: maps.Clone(b.PlayerColors),
+		Robber:       b.Robber,
+	}
+}
+
 // GetRobber returns the current robber position
 func (b *Board) GetRobber() TileCoord {
 	return b.Robber
@@ -26,12 +39,20 @@ func (b *Board) GetRobber() TileCoord {
 // also returns the player ids of the players that can be stolen from
 func (b *Board) PlaceRobber(coord TileCoord) []int {
 	b.Robber = coord
+	return b.PlayersAround(coord)
+}
+
+// PlayersAround returns the player ids of the settlements around a tile,
+// once per settlement
+func (b *Board) PlayersAround(coord TileCoord) []int {
 	playerIds := make([]int, 0)
 	for settlement, playerId := range b.Settlements {
 		if slices.Contains(settlement.adjacentTileCoords(), coord) {
 			playerIds = append(playerIds, playerId)
 		}
 	}
+	// map order is random, keep the result stable
+	slices.Sort(playerIds)
 	return playerIds
 }
 
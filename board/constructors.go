@@ -61,6 +61,12 @@ func NewChaoticBoard() *Board {
 }
 
 func NewLegalBoard(playerColors map[int]lipgloss.AdaptiveColor) *Board {
+	return NewLegalBoardWith(rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())), playerColors)
+}
+
+// NewLegalBoardWith lays out the tiles and numbers with the given random
+// numbers, so the same seed makes the same board
+func NewLegalBoardWith(rng *rand.Rand, playerColors map[int]lipgloss.AdaptiveColor) *Board {
 	diceNumbers := []int{2, 3, 3, 4, 4, 5, 5, 6, 6, 8, 8, 9, 9, 10, 10, 11, 11, 12}
 	terrains := []TerrainType{
 		TerrainWood, TerrainWood, TerrainWood, TerrainWood,
@@ -70,10 +76,10 @@ func NewLegalBoard(playerColors map[int]lipgloss.AdaptiveColor) *Board {
 		TerrainSheep, TerrainSheep, TerrainSheep, TerrainSheep,
 		TerrainDesert,
 	}
-	rand.Shuffle(len(diceNumbers), func(i, j int) {
+	rng.Shuffle(len(diceNumbers), func(i, j int) {
 		diceNumbers[i], diceNumbers[j] = diceNumbers[j], diceNumbers[i]
 	})
-	rand.Shuffle(len(terrains), func(i, j int) {
+	rng.Shuffle(len(terrains), func(i, j int) {
 		terrains[i], terrains[j] = terrains[j], terrains[i]
 	})
 	board := &Board{
@@ -0,0 +1,132 @@
+package board
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Overlay is extra information drawn over the board to help compare spots
+type Overlay int
+
+const (
+	OverlayNone Overlay = iota
+	// OverlayPips puts a dot under each number for every way to roll it
+	OverlayPips
+	// OverlayProduction shades every crossing open to settle by the pips
+	// around it
+	OverlayProduction
+	// OverlayNetworks marks the tile the robber blocks and where each
+	// player's roads reach
+	OverlayNetworks
+	overlayCount
+)
+
+func (o Overlay) String() string {
+	switch o {
+	case OverlayPips:
+		return "pips"
+	case OverlayProduction:
+		return "production"
+	case OverlayNetworks:
+		return "networks"
+	default:
+		return "none"
+	}
+}
+
+// Next returns the overlay that follows, cycling back to none after the last
+func (o Overlay) Next() Overlay {
+	return (o + 1) % overlayCount
+}
+
+// Pips counts the ways two dice roll the number, out of 36. The robber's 7
+// produces nothing and counts none.
+func Pips(number int) int {
+	if number < 2 || number > 12 || number == 7 {
+		return 0
+	}
+	return 6 - max(7-number, number-7)
+}
+
+// CrossPips adds up the pips of the tiles around a crossing
+func (b *Board) CrossPips(coord CrossCoord) int {
+	total := 0
+	for _, tile := range b.AdjacentTiles(coord) {
+		total += Pips(tile.DiceNumber)
+	}
+	return total
+}
+
+// RoadNetworks groups the player's roads into the networks they form, joined
+// at crossings no other player has settled, the longest first
+func (b *Board) RoadNetworks(player int) [][]PathCoord {
+	seen := make(map[PathCoord]bool)
+	var networks [][]PathCoord
+	for road, owner := range b.Roads {
+		if owner != player || seen[road] {
+			continue
+		}
+		seen[road] = true
+		network := []PathCoord{road}
+		for i := 0; i < len(network); i++ {
+			for _, end := range []CrossCoord{network[i].From, network[i].To} {
+				if owner, settled := b.Settlements[end]; settled && owner != player {
+					continue
+				}
+				for _, next := range end.Neighbors() {
+					path := NewPathCoord(end, next)
+					if owner, ok := b.Roads[path]; ok && owner == player && !seen[path] {
+						seen[path] = true
+						network = append(network, path)
+					}
+				}
+			}
+		}
+		networks = append(networks, network)
+	}
+	slices.SortFunc(networks, func(a, b []PathCoord) int { return len(b) - len(a) })
+	return networks
+}
+
+var (
+	blockedStyle = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#C62828", Dark: "#FF5252"}).Bold(true)
+	fadedStyle   = lipgloss.NewStyle().Faint(true)
+	// heat shades production from a poor crossing to a rich one
+	heat = []lipgloss.AdaptiveColor{
+		{Light: "#1565C0", Dark: "#64B5F6"},
+		{Light: "#2E7D32", Dark: "#81C784"},
+		{Light: "#F9A825", Dark: "#FFF176"},
+		{Light: "#EF6C00", Dark: "#FFB74D"},
+		{Light: "#C62828", Dark: "#FF5252"},
+	}
+)
+
+// overlayCrossing draws a free crossing under the overlay, three columns wide
+func (b *Board) overlayCrossing(coord CrossCoord, c *canvas) string {
+	switch c.Overlay {
+	case OverlayProduction:
+		pips := b.CrossPips(coord)
+		if pips == 0 || !b.CanPlaceSettlement(coord) {
+			break
+		}
+		// 13 pips and over are as good as crossings get
+		shade := heat[min(pips*len(heat)/13, len(heat)-1)]
+		return lipgloss.NewStyle().Foreground(shade).Bold(true).Render(padCenter(strconv.Itoa(pips), 3))
+	case OverlayNetworks:
+		for _, next := range coord.Neighbors() {
+			if owner, ok := b.Roads[NewPathCoord(coord, next)]; ok {
+				return c.owned(b, owner, " • ", 1)
+			}
+		}
+	}
+	return "   "
+}
+
+// padCenter centers text in a field, leaning left when it can't be exact
+func padCenter(s string, width int) string {
+	left := max(width-lipgloss.Width(s), 0) / 2
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", max(width-left-lipgloss.Width(s), 0))
+}
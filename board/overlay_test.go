@@ -0,0 +1,58 @@
+package board
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestOverlaysKeepTheBoardInPlace(t *testing.T) {
+	b := NewDesertBoard()
+	b.Tiles[TileCoord{X: 2, Y: 3}] = Tile{Terrain: TerrainWheat, DiceNumber: 6}
+	b.SetRoad(NewPathCoord(CrossCoord{X: 2, Y: 4}, CrossCoord{X: 3, Y: 4}), 0)
+	b.Robber = TileCoord{X: 2, Y: 3}
+
+	plain := b.Print(nil, PrintOptions{})
+	for o := OverlayPips; o != OverlayNone; o = o.Next() {
+		lines := b.Print(nil, PrintOptions{Overlay: o})
+		for i := range plain {
+			if lipgloss.Width(lines[i]) != lipgloss.Width(plain[i]) {
+				t.Errorf("%s: expected line %d to keep its width %d, got %d", o, i, lipgloss.Width(plain[i]), lipgloss.Width(lines[i]))
+			}
+		}
+	}
+	if pips := strings.Join(b.Print(nil, PrintOptions{Overlay: OverlayPips}), "\n"); !strings.Contains(pips, "•••••") || !strings.Contains(pips, "ROB  6") {
+		t.Errorf("Expected five dots under the 6 and the robber next to it, got\n%s", pips)
+	}
+}
+
+func TestPips(t *testing.T) {
+	for number, pips := range map[int]int{0: 0, 2: 1, 6: 5, 7: 0, 8: 5, 12: 1} {
+		if got := Pips(number); got != pips {
+			t.Errorf("Expected %d pips for %d, got %d", pips, number, got)
+		}
+	}
+}
+
+func TestRoadNetworks(t *testing.T) {
+	b := NewDesertBoard()
+	a, _ := NewCrossCoord(2, 4)
+	right, _ := a.Right()
+	down, _ := right.Down()
+	b.SetRoad(NewPathCoord(a, right), 0)
+	b.SetRoad(NewPathCoord(right, down), 0)
+	far, _ := NewCrossCoord(0, 3)
+	b.SetRoad(NewPathCoord(far, far.Neighbors()[0]), 0)
+	b.SetRoad(NewPathCoord(far.Neighbors()[0], far.Neighbors()[0].Neighbors()[0]), 1)
+
+	networks := b.RoadNetworks(0)
+	if len(networks) != 2 || len(networks[0]) != 2 || len(networks[1]) != 1 {
+		t.Fatalf("Expected networks of 2 and 1 roads, got %v", networks)
+	}
+	// another player's settlement cuts a network in two
+	b.Settlements[right] = 1
+	if networks := b.RoadNetworks(0); len(networks) != 3 {
+		t.Errorf("Expected the settlement to split the network, got %v", networks)
+	}
+}
@@ -0,0 +1,91 @@
+package board
+
+import "sync"
+
+// The board is drawn the same way whatever is on it, so where every
+// crossing, path and tile lands in Print's output is worked out once.
+var (
+	spotsOnce sync.Once
+	spots     [][]any
+	// centers are the line and column in the middle of each crossing and tile,
+	// in the order they are drawn
+	crossCenters []center[CrossCoord]
+	tileCenters  []center[TileCoord]
+)
+
+type center[T any] struct {
+	coord        T
+	line, column int
+}
+
+func layout() {
+	spotsOnce.Do(func() {
+		NewDesertBoard().draw(nil, PrintOptions{}, &spots)
+		seen := make(map[any]bool)
+		for line, cells := range spots {
+			for column, spot := range cells {
+				switch s := spot.(type) {
+				case CrossCoord:
+					// one line, three columns
+					if !seen[s] {
+						seen[s] = true
+						crossCenters = append(crossCenters, center[CrossCoord]{s, line, column + 1})
+					}
+				case TileCoord:
+					// the middle line of the five is ten columns wide
+					if !seen[s] && line == s.Y*3 {
+						seen[s] = true
+						tileCenters = append(tileCenters, center[TileCoord]{s, line, column + 5})
+					}
+				}
+			}
+		}
+	})
+}
+
+// SpotAt returns the crossing, path or tile drawn at a line and column of
+// Print's output, or nil outside of the board
+func SpotAt(line, column int) any {
+	layout()
+	if line < 0 || line >= len(spots) || column < 0 || column >= len(spots[line]) {
+		return nil
+	}
+	return spots[line][column]
+}
+
+// CrossAt returns the crossing closest to a line and column of Print's
+// output, for pointing at a crossing anywhere around it
+func CrossAt(line, column int) (CrossCoord, bool) {
+	if SpotAt(line, column) == nil {
+		return CrossCoord{}, false
+	}
+	return closest(crossCenters, line, column), true
+}
+
+// TileAt returns the tile closest to a line and column of Print's output
+func TileAt(line, column int) (TileCoord, bool) {
+	if SpotAt(line, column) == nil {
+		return TileCoord{}, false
+	}
+	return closest(tileCenters, line, column), true
+}
+
+// PathAt returns the path drawn at a line and column of Print's output
+func PathAt(line, column int) (PathCoord, bool) {
+	path, ok := SpotAt(line, column).(PathCoord)
+	return path, ok
+}
+
+// closest picks the center nearest to the cell; a line is about twice as tall
+// as a column is wide
+func closest[T any](centers []center[T], line, column int) T {
+	var best T
+	bestDistance := -1
+	for _, c := range centers {
+		dl, dc := 2*(c.line-line), c.column-column
+		if distance := dl*dl + dc*dc; bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = c.coord, distance
+		}
+	}
+	return best
+}
@@ -1,38 +1,111 @@
 package board
 
 import (
+	"el_poblador/i18n"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Highlights is a set of crossings and paths to mark on the board, like the
+// places a player could build on. Paths are keyed as made by NewPathCoord.
+type Highlights map[any]bool
+
+// PrintOptions are what to draw besides the board itself
+type PrintOptions struct {
+	// Highlights marks crossings and paths
+	Highlights Highlights
+	// RoadStarts marks the highlighted crossings as where a road can leave
+	// from, rather than as places to build on
+	RoadStarts bool
+	// Overlay is drawn over the board. Overlays don't move anything: every
+	// crossing, path and tile is drawn where it always is.
+	Overlay Overlay
+	// Theme is the palette to draw in, nil for the default
+	Theme *Theme
+	// Locale labels the tiles, English if empty
+	Locale i18n.Locale
+}
+
 // PrintBoard prints the game board made of ASCII hexagons
-func (b *Board) Print(cursor interface{}) []string {
+func (b *Board) Print(cursor interface{}, opts PrintOptions) []string {
+	return b.draw(cursor, opts, nil)
+}
+
+// draw renders the board; if spots is given, it also records what every cell
+// shows, line by line
+func (b *Board) draw(cursor interface{}, opts PrintOptions, spots *[][]any) []string {
+	if opts.Theme == nil {
+		opts.Theme = DefaultTheme
+	}
 	// there will be 31 lines (5 * 5 + 6 for the roads)
-	lines := make([]strings.Builder, 31)
-	sidePadding(lines)
+	c := &canvas{lines: make([]strings.Builder, 31), PrintOptions: opts}
+	if spots != nil {
+		c.spots = make([][]any, len(c.lines))
+	}
+	sidePadding(c)
 
 	for x := 0; x <= 5; x++ {
 		for y := 0; y <= 10; y++ {
 			coord, valid := NewCrossCoord(x, y)
 			if valid {
-				renderCrossing(b, lines, coord, cursor)
+				renderCrossing(b, c, coord, cursor)
 			}
 		}
 	}
 
-	sidePadding(lines)
+	sidePadding(c)
 
 	renderedLines := []string{}
-	for i := range lines {
-		renderedLines = append(renderedLines, lines[i].String())
+	for i := range c.lines {
+		renderedLines = append(renderedLines, c.lines[i].String())
+	}
+	if spots != nil {
+		*spots = c.spots
 	}
 	return renderedLines
 }
 
+// canvas is the board being drawn, line by line
+type canvas struct {
+	lines []strings.Builder
+	// spots, when recording, holds the crossing, path or tile each cell
+	// belongs to, nil for the padding
+	spots [][]any
+	PrintOptions
+}
+
+var highlightStyle = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#2E7D32", Dark: "#81C784"}).Bold(true)
+
+// owned draws a player's piece in their color, or for monochrome themes with
+// their letter in place of the rune at mark, if any
+func (c *canvas) owned(b *Board, owner int, glyph string, mark int) string {
+	if c.Theme.Monochrome {
+		if mark < 0 {
+			return glyph
+		}
+		runes := []rune(glyph)
+		return string(runes[:mark]) + OwnerLetter(owner) + string(runes[mark+1:])
+	}
+	color, ok := b.PlayerColors[owner]
+	if !ok {
+		return glyph
+	}
+	return lipgloss.NewStyle().Foreground(c.Theme.PlayerColor(color)).Render(glyph)
+}
+
+func (c *canvas) write(line int, s string, spot any) {
+	c.lines[line].WriteString(s)
+	if c.spots != nil {
+		for i := lipgloss.Width(s); i > 0; i-- {
+			c.spots[line] = append(c.spots[line], spot)
+		}
+	}
+}
+
 // takes responsibility for the crossing and whatever is to its right
 // right-up and right-down paths
-func renderCrossing(board *Board, lines []strings.Builder, coord CrossCoord, cursor interface{}) {
+func renderCrossing(board *Board, lines *canvas, coord CrossCoord, cursor interface{}) {
 	// print crossing
 	midLine := coord.Y * 3
 	settlementOwner, hasSettlement := board.Settlements[coord]
@@ -43,16 +116,32 @@ func renderCrossing(board *Board, lines []strings.Builder, coord CrossCoord, cur
 	if hasCursor {
 		cursorColor := lipgloss.AdaptiveColor{Light: "#0277BD", Dark: "#4FC3F7"}
 		style := lipgloss.NewStyle().Foreground(cursorColor).Blink(true)
-		lines[midLine].WriteString(style.Render(" ○ "))
+		lines.write(midLine, style.Render(" ○ "), coord)
 	} else if hasSettlement {
 		_, isCity := board.CityUpgrades[coord]
 		if isCity {
-			lines[midLine].WriteString(renderPlayerContent(board.PlayerColors, settlementOwner, "███"))
+			lines.write(midLine, lines.owned(board, settlementOwner, "███", 1), coord)
+		} else if lines.Highlights[coord] && lines.RoadStarts {
+			// a settlement a road can leave from
+			lines.write(midLine, highlightStyle.Render("·")+
+				lines.owned(board, settlementOwner, "▲", 0)+highlightStyle.Render("·"), coord)
+		} else if lines.Highlights[coord] {
+			// a settlement that can become a city
+			middle := "▲"
+			if lines.Theme.Monochrome {
+				middle = OwnerLetter(settlementOwner)
+			}
+			lines.write(midLine, lines.owned(board, settlementOwner, "▲", -1)+
+				highlightStyle.Render(middle)+lines.owned(board, settlementOwner, "▲", -1), coord)
 		} else {
-			lines[midLine].WriteString(renderPlayerContent(board.PlayerColors, settlementOwner, "▲▲▲"))
+			lines.write(midLine, lines.owned(board, settlementOwner, "▲▲▲", 1), coord)
 		}
+	} else if lines.Highlights[coord] && lines.RoadStarts {
+		lines.write(midLine, highlightStyle.Render("·•·"), coord)
+	} else if lines.Highlights[coord] {
+		lines.write(midLine, highlightStyle.Render(" · "), coord)
 	} else {
-		lines[midLine].WriteString("   ")
+		lines.write(midLine, board.overlayCrossing(coord, lines), coord)
 	}
 
 	// print right side
@@ -62,11 +151,14 @@ func renderCrossing(board *Board, lines []strings.Builder, coord CrossCoord, cur
 			path := NewPathCoord(coord, up)
 			roadOwner, hasRoad := board.Roads[path]
 			if hasRoad {
-				lines[midLine-2].WriteString(renderPlayerContent(board.PlayerColors, roadOwner, "//"))
-				lines[midLine-1].WriteString(renderPlayerContent(board.PlayerColors, roadOwner, "//"))
+				lines.write(midLine-2, lines.owned(board, roadOwner, "//", 0), path)
+				lines.write(midLine-1, lines.owned(board, roadOwner, "//", -1), path)
+			} else if lines.Highlights[path] {
+				lines.write(midLine-2, highlightStyle.Render(" ·"), path)
+				lines.write(midLine-1, highlightStyle.Render("· "), path)
 			} else {
-				lines[midLine-2].WriteString("  ")
-				lines[midLine-1].WriteString("  ")
+				lines.write(midLine-2, "  ", path)
+				lines.write(midLine-1, "  ", path)
 			}
 		}
 		down, valid := coord.Down()
@@ -74,11 +166,14 @@ func renderCrossing(board *Board, lines []strings.Builder, coord CrossCoord, cur
 			path := NewPathCoord(coord, down)
 			roadOwner, hasRoad := board.Roads[path]
 			if hasRoad {
-				lines[midLine+1].WriteString(renderPlayerContent(board.PlayerColors, roadOwner, "\\\\"))
-				lines[midLine+2].WriteString(renderPlayerContent(board.PlayerColors, roadOwner, "\\\\"))
+				lines.write(midLine+1, lines.owned(board, roadOwner, "\\\\", 1), path)
+				lines.write(midLine+2, lines.owned(board, roadOwner, "\\\\", -1), path)
+			} else if lines.Highlights[path] {
+				lines.write(midLine+1, highlightStyle.Render("· "), path)
+				lines.write(midLine+2, highlightStyle.Render(" ·"), path)
 			} else {
-				lines[midLine+1].WriteString("  ")
-				lines[midLine+2].WriteString("  ")
+				lines.write(midLine+1, "  ", path)
+				lines.write(midLine+2, "  ", path)
 			}
 		}
 		tileCoord, valid := NewTileCoord(coord.X, coord.Y)
@@ -89,12 +184,10 @@ func renderCrossing(board *Board, lines []strings.Builder, coord CrossCoord, cur
 			}
 			tile := board.Tiles[tileCoord]
 			hasRobber := board.Robber == tileCoord
-			renderedTile := tile.RenderTile(hasCursor, hasRobber)
-			lines[midLine-2].WriteString(renderedTile[0])
-			lines[midLine-1].WriteString(renderedTile[1])
-			lines[midLine].WriteString(renderedTile[2])
-			lines[midLine+1].WriteString(renderedTile[3])
-			lines[midLine+2].WriteString(renderedTile[4])
+			renderedTile := tile.renderTile(hasCursor, hasRobber, lines.PrintOptions)
+			for i, line := range renderedTile {
+				lines.write(midLine-2+i, line, tileCoord)
+			}
 		}
 	} else {
 		right, valid := coord.Right()
@@ -104,36 +197,29 @@ func renderCrossing(board *Board, lines []strings.Builder, coord CrossCoord, cur
 		pathCoord := NewPathCoord(coord, right)
 		roadOwner, hasRoad := board.Roads[pathCoord]
 		if hasRoad {
-			lines[midLine].WriteString(renderPlayerContent(board.PlayerColors, roadOwner, " ==== "))
+			lines.write(midLine, lines.owned(board, roadOwner, " ==== ", 2), pathCoord)
+		} else if lines.Highlights[pathCoord] {
+			lines.write(midLine, highlightStyle.Render(" ···· "), pathCoord)
 		} else {
-			lines[midLine].WriteString("      ")
+			lines.write(midLine, "      ", pathCoord)
 		}
 	}
 }
 
-// renderPlayerContent applies player color to content string
-func renderPlayerContent(playerColors map[int]lipgloss.AdaptiveColor, playerId int, content string) string {
-	if color, ok := playerColors[playerId]; ok {
-		style := lipgloss.NewStyle().Foreground(color)
-		return style.Render(content)
-	}
-	return content
-}
-
-func sidePadding(lines []strings.Builder) {
+func sidePadding(lines *canvas) {
 	// fake paths, tiles and crossings spaces
 	top := []int{3 + 6 + 3 + 10, 2 + 8 + 2 + 10, 2 + 10 + 2 + 8, 3 + 10, 2 + 10, 2 + 8}
 	// left padding with virtual tiles would go
 	// 2, 2, 1, 0, 1, 2, repeat
 	pattern := []int{2, 2, 1, 0, 1, 2}
-	for i := range lines {
+	for i := range lines.lines {
 		base := pattern[i%len(pattern)]
 		if i < len(top) {
 			base += top[i]
 		}
-		if len(lines)-i-1 < len(top) {
-			base += top[len(lines)-i-1]
+		if len(lines.lines)-i-1 < len(top) {
+			base += top[len(lines.lines)-i-1]
 		}
-		lines[i].WriteString(strings.Repeat(" ", base))
+		lines.write(i, strings.Repeat(" ", base), nil)
 	}
 }
@@ -1,7 +1,9 @@
 package board
 
 import (
+	"el_poblador/i18n"
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -55,77 +57,87 @@ type Tile struct {
 //
 // where B is the terrain abbreviation, 2 is the dice number, and R is the robber indicator
 func (tile *Tile) RenderTile(isCursor bool, hasRobber bool) [5]string {
-	terrainAbbrev := tile.getTerrainAbbrev()
+	return tile.renderTile(isCursor, hasRobber, PrintOptions{Theme: DefaultTheme})
+}
+
+// renderTile draws the tile in the theme and locale of the options, under
+// their overlay: the pips overlay puts the dots on the robber's line, moving
+// the robber next to the number, and the networks overlay fades every tile but
+// the blocked one
+func (tile *Tile) renderTile(isCursor bool, hasRobber bool, opts PrintOptions) [5]string {
+	overlay, theme := opts.Overlay, opts.Theme
+	terrainAbbrev := tile.getTerrainAbbrev(opts.Locale)
+	// the robber's label is three letters wide in every locale
+	robber := opts.Locale.T("board.robber")
 
 	diceStr := ""
 	if tile.DiceNumber > 0 {
 		diceStr = fmt.Sprintf("%d", tile.DiceNumber)
 	}
 
-	style := tile.getTerrainStyle()
-	if isCursor {
+	style := tile.getTerrainStyle(theme)
+	if overlay == OverlayNetworks {
+		style = fadedStyle
+		if hasRobber {
+			style = blockedStyle
+		}
+	}
+	if isCursor && theme.Monochrome {
+		style = style.Reverse(true)
+	} else if isCursor {
 		cursorBg := lipgloss.AdaptiveColor{Light: "#E0E0E0", Dark: "#424242"}
 		style = style.Background(cursorBg)
 	}
 
+	diceLine := fmt.Sprintf("    %2s    ", diceStr)
 	infoLine := "\\        /"
 	if hasRobber {
-		infoLine = "\\  ROB   /"
+		infoLine = "\\  " + robber + "   /"
+	}
+	if overlay == OverlayPips && tile.DiceNumber > 0 {
+		infoLine = "\\" + padCenter(strings.Repeat("•", Pips(tile.DiceNumber)), 8) + "/"
+		if hasRobber {
+			diceLine = fmt.Sprintf("%s %2s    ", robber, diceStr)
+		}
 	}
 
 	lines := [5]string{
 		style.Render("/‾‾‾‾‾‾\\"),
 		style.Render(fmt.Sprintf("/  %s  \\", terrainAbbrev)),
-		style.Render(fmt.Sprintf("    %2s    ", diceStr)),
+		style.Render(diceLine),
 		style.Render(infoLine),
 		style.Render("\\______/"),
 	}
 	return lines
 }
 
-// getTerrainAbbrev returns a short abbreviation for the terrain type
-func (tile *Tile) getTerrainAbbrev() string {
+// getTerrainAbbrev returns a four letter abbreviation for the terrain type
+func (tile *Tile) getTerrainAbbrev(l i18n.Locale) string {
 	switch tile.Terrain {
 	case TerrainWood:
-		return "WOOD"
+		return l.T("board.wood")
 	case TerrainBrick:
-		return "CLAY"
+		return l.T("board.brick")
 	case TerrainOre:
-		return "MNTN"
+		return l.T("board.ore")
 	case TerrainWheat:
-		return "WHEA"
+		return l.T("board.wheat")
 	case TerrainSheep:
-		return "PAST"
+		return l.T("board.sheep")
 	case TerrainDesert:
-		return "DESE"
+		return l.T("board.desert")
 	default:
 		return "????"
 	}
 }
 
-// getTerrainStyle returns the lipgloss style for the terrain
-func (tile *Tile) getTerrainStyle() lipgloss.Style {
-	var color lipgloss.AdaptiveColor
-	switch tile.Terrain {
-	case TerrainWood:
-		// Dark green for dark backgrounds, forest green for light
-		color = lipgloss.AdaptiveColor{Light: "#2D5016", Dark: "#4CAF50"}
-	case TerrainBrick:
-		// Brick red
-		color = lipgloss.AdaptiveColor{Light: "#B71C1C", Dark: "#EF5350"}
-	case TerrainOre:
-		// Gray stone
-		color = lipgloss.AdaptiveColor{Light: "#424242", Dark: "#9E9E9E"}
-	case TerrainWheat:
-		// Golden yellow
-		color = lipgloss.AdaptiveColor{Light: "#F57F17", Dark: "#FFEB3B"}
-	case TerrainSheep:
-		// Bright green pasture
-		color = lipgloss.AdaptiveColor{Light: "#388E3C", Dark: "#8BC34A"}
-	case TerrainDesert:
-		// Sandy brown
-		color = lipgloss.AdaptiveColor{Light: "#795548", Dark: "#BCAAA4"}
-	default:
+// getTerrainStyle returns the lipgloss style for the terrain in the theme
+func (tile *Tile) getTerrainStyle(theme *Theme) lipgloss.Style {
+	if theme.Monochrome {
+		return lipgloss.NewStyle()
+	}
+	color, ok := theme.Terrain[tile.Terrain]
+	if !ok {
 		color = lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"}
 	}
 	return lipgloss.NewStyle().Foreground(color)
@@ -144,6 +156,25 @@ const (
 
 var RESOURCE_TYPES = []ResourceType{ResourceOre, ResourceWood, ResourceSheep, ResourceWheat, ResourceBrick}
 
+// Localize names the resource in the locale, for players to read. String
+// names it for programs.
+func (r ResourceType) Localize(l i18n.Locale) string {
+	switch r {
+	case ResourceOre:
+		return l.T("resource.ore")
+	case ResourceWood:
+		return l.T("resource.wood")
+	case ResourceSheep:
+		return l.T("resource.sheep")
+	case ResourceWheat:
+		return l.T("resource.wheat")
+	case ResourceBrick:
+		return l.T("resource.brick")
+	default:
+		return l.T("resource.unknown")
+	}
+}
+
 func (r ResourceType) String() string {
 	switch r {
 	case ResourceOre:
@@ -0,0 +1,120 @@
+package board
+
+import "github.com/charmbracelet/lipgloss"
+
+// Theme is the palette the board and the players are drawn in. Players pick
+// their colors from the default theme's; other themes draw each of those
+// colors in their own.
+type Theme struct {
+	Name string
+	// Players are the colors drawn for the default theme's player colors, in
+	// the same order
+	Players []lipgloss.AdaptiveColor
+	Terrain map[TerrainType]lipgloss.AdaptiveColor
+	// Monochrome themes draw no colors at all: pieces carry their owner's
+	// letter instead
+	Monochrome bool
+}
+
+// Themes lists the themes to choose from, the default first. The
+// color-blind-safe ones are built on the Okabe-Ito palette.
+var Themes = []*Theme{
+	{
+		Name: "default",
+		Players: []lipgloss.AdaptiveColor{
+			{Light: "#1565C0", Dark: "#42A5F5"}, // blue
+			{Light: "#C62828", Dark: "#EF5350"}, // red
+			{Light: "#F57C00", Dark: "#FFB74D"}, // orange
+			{Light: "#6A1B9A", Dark: "#AB47BC"}, // purple
+		},
+		Terrain: map[TerrainType]lipgloss.AdaptiveColor{
+			// Dark green for dark backgrounds, forest green for light
+			TerrainWood: {Light: "#2D5016", Dark: "#4CAF50"},
+			// Brick red
+			TerrainBrick: {Light: "#B71C1C", Dark: "#EF5350"},
+			// Gray stone
+			TerrainOre: {Light: "#424242", Dark: "#9E9E9E"},
+			// Golden yellow
+			TerrainWheat: {Light: "#F57F17", Dark: "#FFEB3B"},
+			// Bright green pasture
+			TerrainSheep: {Light: "#388E3C", Dark: "#8BC34A"},
+			// Sandy brown
+			TerrainDesert: {Light: "#795548", Dark: "#BCAAA4"},
+		},
+	},
+	{
+		// red and green look alike: blue, yellow and vermilion stay apart
+		Name: "deuteranopia",
+		Players: []lipgloss.AdaptiveColor{
+			{Light: "#0072B2", Dark: "#56B4E9"}, // blue
+			{Light: "#D55E00", Dark: "#D55E00"}, // vermilion
+			{Light: "#8F7F00", Dark: "#F0E442"}, // yellow
+			{Light: "#000000", Dark: "#FFFFFF"}, // black or white
+		},
+		Terrain: okabeItoTerrain,
+	},
+	{
+		// reds look dark and dim: orange and yellow stand in for them
+		Name: "protanopia",
+		Players: []lipgloss.AdaptiveColor{
+			{Light: "#0072B2", Dark: "#56B4E9"}, // blue
+			{Light: "#B26B00", Dark: "#E69F00"}, // orange
+			{Light: "#000000", Dark: "#FFFFFF"}, // black or white
+			{Light: "#A2507F", Dark: "#CC79A7"}, // reddish purple
+		},
+		Terrain: okabeItoTerrain,
+	},
+	{Name: "monochrome", Monochrome: true},
+}
+
+var okabeItoTerrain = map[TerrainType]lipgloss.AdaptiveColor{
+	TerrainWood:   {Light: "#00785A", Dark: "#009E73"}, // bluish green
+	TerrainBrick:  {Light: "#B34E00", Dark: "#D55E00"}, // vermilion
+	TerrainOre:    {Light: "#424242", Dark: "#9E9E9E"}, // gray
+	TerrainWheat:  {Light: "#8F7F00", Dark: "#F0E442"}, // yellow
+	TerrainSheep:  {Light: "#2C7FB8", Dark: "#56B4E9"}, // sky blue
+	TerrainDesert: {Light: "#795548", Dark: "#BCAAA4"}, // sand
+}
+
+// DefaultTheme is the theme players pick their colors from
+var DefaultTheme = Themes[0]
+
+// ThemeNamed finds a theme by name
+func ThemeNamed(name string) (*Theme, bool) {
+	for _, theme := range Themes {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+	return nil, false
+}
+
+// Next returns the theme that follows in Themes, cycling back to the default
+func (t *Theme) Next() *Theme {
+	for i, theme := range Themes {
+		if theme == t {
+			return Themes[(i+1)%len(Themes)]
+		}
+	}
+	return DefaultTheme
+}
+
+// PlayerColor returns how the theme draws a color picked from the default
+// theme's. Colors from elsewhere are drawn as they are, and monochrome themes
+// draw none.
+func (t *Theme) PlayerColor(color lipgloss.AdaptiveColor) lipgloss.AdaptiveColor {
+	if t.Monochrome {
+		return lipgloss.AdaptiveColor{}
+	}
+	for i, c := range DefaultTheme.Players {
+		if c == color && i < len(t.Players) {
+			return t.Players[i]
+		}
+	}
+	return color
+}
+
+// OwnerLetter is the letter monochrome themes mark a player's pieces with
+func OwnerLetter(player int) string {
+	return string(rune('A' + player))
+}
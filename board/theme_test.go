@@ -0,0 +1,40 @@
+package board
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestMonochromeMarksOwnersWithLetters(t *testing.T) {
+	b := NewDesertBoard()
+	b.SetSettlement(CrossCoord{X: 2, Y: 4}, 1)
+	b.SetRoad(NewPathCoord(CrossCoord{X: 2, Y: 4}, CrossCoord{X: 3, Y: 4}), 1)
+	monochrome, _ := ThemeNamed("monochrome")
+
+	plain := b.Print(nil, PrintOptions{})
+	lines := b.Print(nil, PrintOptions{Theme: monochrome})
+	for i := range plain {
+		if lipgloss.Width(lines[i]) != lipgloss.Width(plain[i]) {
+			t.Errorf("Expected line %d to keep its width %d, got %d", i, lipgloss.Width(plain[i]), lipgloss.Width(lines[i]))
+		}
+	}
+	if view := strings.Join(lines, "\n"); !strings.Contains(view, "▲B▲") || !strings.Contains(view, "=B==") {
+		t.Errorf("Expected B on the second player's settlement and road, got\n%s", view)
+	}
+}
+
+func TestPlayerColorFollowsTheTheme(t *testing.T) {
+	deuteranopia, _ := ThemeNamed("deuteranopia")
+	if got := deuteranopia.PlayerColor(DefaultTheme.Players[1]); got != deuteranopia.Players[1] {
+		t.Errorf("Expected the second player's color to be drawn as %v, got %v", deuteranopia.Players[1], got)
+	}
+	other := lipgloss.AdaptiveColor{Light: "#123456", Dark: "#654321"}
+	if got := deuteranopia.PlayerColor(other); got != other {
+		t.Errorf("Expected a color from outside the default theme to stay %v, got %v", other, got)
+	}
+	if got := DefaultTheme.Next().Next().Next().Next(); got != DefaultTheme {
+		t.Errorf("Expected the themes to cycle back to the default, got %s", got.Name)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// chatInput is the line the user is typing into the game chat, or a command
+type chatInput struct {
+	focused bool
+	draft   string
+	err     string
+	// options are the completions tab offered for a command, until the next key
+	options []string
+}
+
+// update handles a key while the chat is focused and returns the message to
+// send once the user presses enter.
+func (c *chatInput) update(msg tea.KeyMsg) (string, bool) {
+	c.options = nil
+	switch msg.Type {
+	case tea.KeyEnter:
+		text := c.draft
+		c.draft = ""
+		c.focused = false
+		return text, text != ""
+	case tea.KeyEsc:
+		c.draft = ""
+		c.focused = false
+	case tea.KeyBackspace:
+		if runes := []rune(c.draft); len(runes) > 0 {
+			c.draft = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		c.draft += " "
+	case tea.KeyRunes:
+		c.draft += string(msg.Runes)
+	}
+	return "", false
+}
+
+func (c *chatInput) focus() {
+	c.focused = true
+	c.err = ""
+}
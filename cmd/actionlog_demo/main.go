@@ -3,6 +3,7 @@ package main
 import (
 	"el_poblador/board"
 	"el_poblador/game"
+	"el_poblador/i18n"
 	"fmt"
 )
 
@@ -22,30 +23,33 @@ func main() {
 	alice.AddResource(board.ResourceSheep)
 
 	// Log a development card purchase
-	g.LogAction(fmt.Sprintf("%s bought a development card", alice.RenderName()))
+	g.LogAction("log.bought_card", alice.RenderName())
 
 	// 2. Log some building actions
 	bob := &g.Players[1]
-	g.LogAction(fmt.Sprintf("%s built a road", bob.RenderName()))
+	g.LogAction("log.road", bob.RenderName())
 
 	charlie := &g.Players[2]
-	g.LogAction(fmt.Sprintf("%s built a settlement", charlie.RenderName()))
+	g.LogAction("log.settlement", charlie.RenderName())
 
 	// 3. Log some more complex actions
-	g.LogAction(fmt.Sprintf("%s played Knight", alice.RenderName()))
-	g.LogAction(fmt.Sprintf("%s moved the robber", alice.RenderName()))
-	g.LogAction(fmt.Sprintf("%s stole a card from %s", alice.RenderName(), bob.RenderName()))
+	g.LogAction("log.played", alice.RenderName(), game.DevCardKnight)
+	g.LogAction("log.robber", alice.RenderName())
+	g.LogAction("log.steal", alice.RenderName(), bob.RenderName())
 
 	// 4. Log resource generation
-	g.LogAction(fmt.Sprintf("%s gained 2 Wood, 1 Brick from dice roll (8)", bob.RenderName()))
+	gained := i18n.List{i18n.M("amount", 2, board.ResourceWood), i18n.M("amount", 1, board.ResourceBrick)}
+	g.LogAction("log.rolled", bob.RenderName(), gained, 8)
 
 	// 5. Log turn change
-	g.LogAction(fmt.Sprintf("Turn passed to %s", charlie.RenderName()))
-
-	// Display the action log
-	fmt.Println("\nAction Log:")
-	fmt.Println("----------")
-	for i, action := range g.ActionLog {
-		fmt.Printf("%2d. %s\n", i+1, action)
+	g.LogAction("log.turn", charlie.RenderName())
+
+	// Display the action log, in every language
+	for _, locale := range i18n.Locales {
+		fmt.Printf("\nAction Log (%s):\n", locale)
+		fmt.Println("----------")
+		for i, action := range g.LogLines(locale) {
+			fmt.Printf("%2d. %s\n", i+1, action)
+		}
 	}
 }
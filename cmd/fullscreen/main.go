@@ -1,20 +1,38 @@
 package main
 
 import (
+	"el_poblador/board"
 	"el_poblador/game"
 	"fmt"
 )
 
+// placeInitial builds the turn holder's settlement at the first free spot,
+// with a road leaving it
+func placeInitial(g *game.Game) {
+	for x := 0; x <= 5; x++ {
+		for y := 0; y <= 10; y++ {
+			at, ok := board.NewCrossCoord(x, y)
+			if !ok || g.Apply(g.PlayerTurn, game.BuildSettlement{At: at}) != nil {
+				continue
+			}
+			player := g.PlayerTurn
+			for _, to := range at.Neighbors() {
+				if g.Apply(player, game.BuildRoad{Road: board.PathCoord{From: at, To: to}}) == nil {
+					return
+				}
+			}
+		}
+	}
+}
+
 func main() {
 	g := &game.Game{}
 	g.Start([]string{"Fred", "George", "Harold", "Ivor"})
 	// 2 rounds of placing settlements and roads
 	for i := 0; i < 2*4; i++ {
-		g.MoveCursorToPlaceSettlement()
-		g.ConfirmAction(nil) // place settlement
-		g.ConfirmAction(nil) // place road
+		placeInitial(g)
 	}
-	g.ConfirmAction(nil) // roll dice
+	g.Apply(g.PlayerTurn, game.RollDice{})
 
 	fmt.Println(g.Print(80, 32, nil, 0, 0))
 }
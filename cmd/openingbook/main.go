@@ -0,0 +1,53 @@
+// Command openingbook simulates games to build the opening book the bots and
+// hints use to place their first settlements, and writes it to a file.
+package main
+
+import (
+	"el_poblador/game"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	games := flag.Int("games", 5000, "games to simulate for each table size")
+	players := flag.String("players", "3,4", "table sizes to simulate, comma separated")
+	seed := flag.Uint64("seed", 1, "seed of the first game")
+	maxTurns := flag.Int("max-turns", 500, "turns after which a game counts as lost by everyone")
+	out := flag.String("o", "opening_book.gz", "file to write the book to")
+	flag.Parse()
+
+	config := game.BookConfig{Games: *games, Seed: *seed, MaxTurns: *maxTurns}
+	for _, size := range strings.Split(*players, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(size))
+		if err != nil || n < 3 || n > 4 {
+			fmt.Fprintf(os.Stderr, "Error: table sizes are 3 or 4, got %q\n", size)
+			os.Exit(1)
+		}
+		config.Players = append(config.Players, n)
+	}
+	config.Progress = func(played, total int) {
+		if played%100 == 0 || played == total {
+			fmt.Fprintf(os.Stderr, "\r%d/%d games", played, total)
+		}
+	}
+	book := game.BuildOpeningBook(config)
+	fmt.Fprintln(os.Stderr)
+
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	size, err := book.WriteTo(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %d spots to %s (%d bytes)\n", book.Len(), *out, size)
+}
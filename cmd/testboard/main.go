@@ -53,7 +53,7 @@ func main() {
 		fmt.Printf("Robber placed on the board for testing\n\n")
 	}
 
-	lines := boardInstance.Print(nil)
+	lines := boardInstance.Print(nil, board.PrintOptions{})
 
 	total := strings.Join(lines, "\n")
 	fmt.Println(total)
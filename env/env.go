@@ -0,0 +1,260 @@
+// Package env wraps a game as a reinforcement-learning environment: Reset
+// deals a game from a seed, Step carries out an action picked by its index
+// in a fixed action space, and every observation encodes the game as a
+// fixed-size vector along with a mask of the legal actions.
+//
+// The agent plays every seat that isn't given to a bot, so with no bots it
+// plays against itself, always from the seat that is to act. Observations and
+// masks are reused by the next Step, copy them to keep them.
+package env
+
+import (
+	"el_poblador/board"
+	"el_poblador/game"
+	"errors"
+	"slices"
+)
+
+// Action space: a block of indexes for each kind of action, in this order
+const (
+	ActionSettlement = 0                                // one per crossing
+	ActionCity       = ActionSettlement + crossingCount // one per crossing
+	ActionRoad       = ActionCity + crossingCount       // one per path
+	ActionRobber     = ActionRoad + pathCount           // one per tile
+	// ActionSteal steals from the player so many seats after the one acting,
+	// starting at one
+	ActionSteal        = ActionRobber + tileCount
+	ActionPickResource = ActionSteal + maxPlayers - 1 // one per resource
+	// ActionPlayDevCard plays a Knight, Road Building, Monopoly or Year of
+	// Plenty
+	ActionPlayDevCard = ActionPickResource + resourceCount
+	ActionBuyDevCard  = ActionPlayDevCard + 4
+	ActionRollDice    = ActionBuyDevCard + 1
+	ActionEndTurn     = ActionRollDice + 1
+	// ActionTrade gives the bank four of a resource for one of another, at
+	// give*5+get
+	ActionTrade = ActionEndTurn + 1
+	// ActionDiscard discards one card of a resource; the discard is made once
+	// enough cards are picked
+	ActionDiscard = ActionTrade + resourceCount*resourceCount
+	// ActionCount is the size of the action space
+	ActionCount = ActionDiscard + resourceCount
+)
+
+const (
+	maxPlayers    = 4
+	resourceCount = 5
+	// defaultMaxTurns stops games that go on without a winner
+	defaultMaxTurns = 500
+)
+
+var playableCards = []game.DevCard{game.DevCardKnight, game.DevCardRoadBuilding, game.DevCardMonopoly, game.DevCardYearOfPlenty}
+
+var ErrIllegalAction = errors.New("the action isn't legal now")
+
+// Env is a game played through indexes of the action space
+type Env struct {
+	// Players is how many seats the games have, 4 if not set
+	Players int
+	// Bots play the seats they are given instead of the agent
+	Bots map[int]func() game.Bot
+	// MaxTurns truncates games that last longer, 0 for 500 turns
+	MaxTurns int
+
+	game  *game.Game
+	obs   Observation
+	legal []game.Action // by action index, nil when not legal
+	// the cards picked so far by the player discarding, of discardAmount
+	discard       map[board.ResourceType]int
+	discardPlayer int
+	discardAmount int
+}
+
+// Observation is the game as seen by the seat that is to act
+type Observation struct {
+	// Player is the seat that is to act, -1 once the game is done
+	Player int
+	// Features encodes the game from Player's seat, see FeatureCount
+	Features []float32
+	// Mask is set for the legal actions
+	Mask []bool
+}
+
+// Step is the outcome of an action
+type Step struct {
+	Observation
+	// Rewards are by seat: 1 for the winner and -1 for the others when the
+	// game ends, 0 until then
+	Rewards []float64
+	// Done is set once the game is won or truncated
+	Done bool
+	// Truncated is set if the game was stopped at MaxTurns without a winner
+	Truncated bool
+}
+
+// Game returns the game being played, to look at but not to change
+func (e *Env) Game() *game.Game {
+	return e.game
+}
+
+// Reset starts a game dealt from the seed and returns the first observation
+func (e *Env) Reset(seed uint64) Observation {
+	players := e.Players
+	if players == 0 {
+		players = maxPlayers
+	}
+	seats := make([]game.Seat, players)
+	for i := range seats {
+		// no colors, so nothing is styled for a terminal
+		seats[i] = game.Seat{Name: string(rune('A' + i))}
+	}
+	e.game = &game.Game{}
+	e.game.Seed(seed)
+	e.game.StartSeated(seats)
+	for seat, newBot := range e.Bots {
+		e.game.SetBot(seat, newBot())
+	}
+	if e.obs.Features == nil {
+		e.obs.Features = make([]float32, FeatureCount)
+		e.obs.Mask = make([]bool, ActionCount)
+		e.legal = make([]game.Action, ActionCount)
+	}
+	e.game.PlayBots()
+	e.observe()
+	return e.obs
+}
+
+// Step carries out the action of the seat that is to act
+func (e *Env) Step(action int) (Step, error) {
+	if e.game == nil || action < 0 || action >= ActionCount || !e.obs.Mask[action] {
+		return Step{}, ErrIllegalAction
+	}
+	player := e.obs.Player
+	if action >= ActionDiscard {
+		resource := board.RESOURCE_TYPES[action-ActionDiscard]
+		e.discard[resource]++
+		if e.total(e.discard) == e.discardAmount {
+			e.discardAmount = 0
+			if err := e.game.Apply(player, game.Discard{Resources: e.discard}); err != nil {
+				return Step{}, err
+			}
+		}
+	} else if err := e.game.Apply(player, e.legal[action]); err != nil {
+		return Step{}, err
+	}
+	e.game.PlayBots()
+
+	step := Step{Rewards: make([]float64, len(e.game.Players))}
+	winner := e.game.CheckGameEnd()
+	maxTurns := e.MaxTurns
+	if maxTurns == 0 {
+		maxTurns = defaultMaxTurns
+	}
+	switch {
+	case winner != nil:
+		step.Done = true
+		for seat := range e.game.Players {
+			step.Rewards[seat] = -1
+			if &e.game.Players[seat] == winner {
+				step.Rewards[seat] = 1
+			}
+		}
+	case e.game.TurnsPlayed >= maxTurns:
+		step.Done, step.Truncated = true, true
+	}
+	if step.Done {
+		e.obs.Player = -1
+		clear(e.obs.Features)
+		clear(e.obs.Mask)
+	} else {
+		e.observe()
+	}
+	step.Observation = e.obs
+	return step, nil
+}
+
+func (e *Env) total(resources map[board.ResourceType]int) int {
+	total := 0
+	for _, count := range resources {
+		total += count
+	}
+	return total
+}
+
+// observe encodes the game and the legal actions for the seat that is to act
+func (e *Env) observe() {
+	e.obs.Player = -1
+	for _, seat := range e.game.WaitingOn() {
+		if !e.game.Players[seat].Bot {
+			e.obs.Player = seat
+			break
+		}
+	}
+	clear(e.obs.Mask)
+	clear(e.legal)
+	if e.obs.Player < 0 {
+		clear(e.obs.Features)
+		return
+	}
+	player := e.obs.Player
+	encode(e.game, player, e.obs.Features)
+
+	if due := e.game.DiscardDue(player); due > 0 {
+		// one card at a time, rather than every way to discard them
+		if e.discardAmount == 0 || e.discardPlayer != player {
+			e.discard = make(map[board.ResourceType]int)
+			e.discardPlayer, e.discardAmount = player, due
+		}
+		hand := e.game.Players[player].Resources
+		for i, resource := range board.RESOURCE_TYPES {
+			e.obs.Mask[ActionDiscard+i] = hand[resource] > e.discard[resource]
+		}
+		return
+	}
+	for _, action := range e.game.LegalActions(player) {
+		if i := e.index(player, action); i >= 0 {
+			e.obs.Mask[i] = true
+			e.legal[i] = action
+		}
+	}
+}
+
+// index returns where the action is in the action space, or -1
+func (e *Env) index(player int, action game.Action) int {
+	switch a := action.(type) {
+	case game.BuildSettlement:
+		return ActionSettlement + crossingIndex[a.At]
+	case game.BuildCity:
+		return ActionCity + crossingIndex[a.At]
+	case game.BuildRoad:
+		return ActionRoad + pathIndex[board.NewPathCoord(a.Road.From, a.Road.To)]
+	case game.MoveRobber:
+		return ActionRobber + tileIndex[a.To]
+	case game.Steal:
+		return ActionSteal + (a.From-player+len(e.game.Players))%len(e.game.Players) - 1
+	case game.PickResource:
+		return ActionPickResource + resourceIndex(a.Resource)
+	case game.PlayDevCard:
+		if i := slices.Index(playableCards, a.Card); i >= 0 {
+			return ActionPlayDevCard + i
+		}
+	case game.BuyDevCard:
+		return ActionBuyDevCard
+	case game.RollDice:
+		return ActionRollDice
+	case game.EndTurn:
+		return ActionEndTurn
+	case game.Trade:
+		// bank trades offer four of one resource for one of another
+		for give := range a.Offer {
+			for get := range a.Request {
+				return ActionTrade + resourceIndex(give)*resourceCount + resourceIndex(get)
+			}
+		}
+	}
+	return -1
+}
+
+func resourceIndex(resource board.ResourceType) int {
+	return slices.Index(board.RESOURCE_TYPES, resource)
+}
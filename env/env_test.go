@@ -0,0 +1,128 @@
+package env
+
+import (
+	"el_poblador/game"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestLayoutCoversTheBoard(t *testing.T) {
+	if len(tiles) != tileCount || len(crossings) != crossingCount || len(paths) != pathCount {
+		t.Errorf("Expected %d tiles, %d crossings and %d paths, got %d, %d and %d",
+			tileCount, crossingCount, pathCount, len(tiles), len(crossings), len(paths))
+	}
+}
+
+// playRandomly takes random legal actions until the game is done or the steps
+// run out, and returns the last step
+func playRandomly(t testing.TB, e *Env, rng *rand.Rand, steps int) Step {
+	var last Step
+	obs := e.Reset(rng.Uint64())
+	for i := 0; i < steps; i++ {
+		var legal []int
+		for action, ok := range obs.Mask {
+			if ok {
+				legal = append(legal, action)
+			}
+		}
+		if len(legal) == 0 {
+			t.Fatalf("Expected a legal action for player %d", obs.Player)
+		}
+		step, err := e.Step(legal[rng.IntN(len(legal))])
+		if err != nil {
+			t.Fatalf("Expected a legal action to be carried out, got %v", err)
+		}
+		if step.Done {
+			return step
+		}
+		obs, last = step.Observation, step
+	}
+	return last
+}
+
+func TestRandomSelfPlay(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 5; i++ {
+		e := &Env{Players: 3 + i%2}
+		step := playRandomly(t, e, rng, 20000)
+		if !step.Done {
+			t.Fatalf("Expected the game to be won or truncated")
+		}
+		total := 0.0
+		for _, reward := range step.Rewards {
+			total += reward
+		}
+		if step.Truncated != (total == 0) {
+			t.Errorf("Expected a winner unless truncated, got rewards %v", step.Rewards)
+		}
+	}
+}
+
+func TestIllegalActionsAreRefused(t *testing.T) {
+	e := &Env{}
+	obs := e.Reset(1)
+	if obs.Mask[ActionEndTurn] {
+		t.Fatalf("Expected no end turn while placing the first settlement")
+	}
+	if _, err := e.Step(ActionEndTurn); err != ErrIllegalAction {
+		t.Errorf("Expected ErrIllegalAction, got %v", err)
+	}
+}
+
+func TestResetIsRepeatable(t *testing.T) {
+	var first, second Env
+	a, b := first.Reset(42), second.Reset(42)
+	if !slices.Equal(a.Features, b.Features) || !slices.Equal(a.Mask, b.Mask) {
+		t.Errorf("Expected the same seed to deal the same game")
+	}
+}
+
+func TestBotsPlayTheirSeats(t *testing.T) {
+	newBot := func() game.Bot { return game.NewHeuristicBot(game.Normal) }
+	e := &Env{Players: 3, Bots: map[int]func() game.Bot{1: newBot, 2: newBot}}
+	obs := e.Reset(7)
+	rng := rand.New(rand.NewPCG(3, 4))
+	for i := 0; i < 200; i++ {
+		if obs.Player != 0 {
+			t.Fatalf("Expected the agent to act for seat 0 only, got %d", obs.Player)
+		}
+		var legal []int
+		for action, ok := range obs.Mask {
+			if ok {
+				legal = append(legal, action)
+			}
+		}
+		step, err := e.Step(legal[rng.IntN(len(legal))])
+		if err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		if step.Done {
+			return
+		}
+		obs = step.Observation
+	}
+}
+
+func BenchmarkRandomSelfPlay(b *testing.B) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	e := &Env{}
+	obs := e.Reset(rng.Uint64())
+	legal := make([]int, 0, ActionCount)
+	for i := 0; i < b.N; i++ {
+		legal = legal[:0]
+		for action, ok := range obs.Mask {
+			if ok {
+				legal = append(legal, action)
+			}
+		}
+		step, err := e.Step(legal[rng.IntN(len(legal))])
+		if err != nil {
+			b.Fatal(err)
+		}
+		obs = step.Observation
+		if step.Done {
+			obs = e.Reset(rng.Uint64())
+		}
+	}
+}
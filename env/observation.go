@@ -0,0 +1,165 @@
+package env
+
+import (
+	"el_poblador/board"
+	"el_poblador/game"
+)
+
+// The board has the same shape in every game, so each tile, crossing and path
+// has a fixed place in the observation and the action space, in the order of
+// their coordinates.
+const (
+	tileCount     = 19
+	crossingCount = 54
+	pathCount     = 72
+)
+
+var (
+	tiles, crossings, paths             = layout()
+	tileIndex, crossingIndex, pathIndex = indexes()
+)
+
+func layout() ([]board.TileCoord, []board.CrossCoord, []board.PathCoord) {
+	var tiles []board.TileCoord
+	var crossings []board.CrossCoord
+	var paths []board.PathCoord
+	seen := make(map[board.PathCoord]bool)
+	for x := 0; x <= 5; x++ {
+		for y := 0; y <= 10; y++ {
+			if tile, ok := board.NewTileCoord(x, y); ok {
+				tiles = append(tiles, tile)
+			}
+			if cross, ok := board.NewCrossCoord(x, y); ok {
+				crossings = append(crossings, cross)
+			}
+		}
+	}
+	for _, cross := range crossings {
+		for _, neighbor := range cross.Neighbors() {
+			if path := board.NewPathCoord(cross, neighbor); !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	return tiles, crossings, paths
+}
+
+func indexes() (map[board.TileCoord]int, map[board.CrossCoord]int, map[board.PathCoord]int) {
+	tileIndex := make(map[board.TileCoord]int)
+	for i, tile := range tiles {
+		tileIndex[tile] = i
+	}
+	crossingIndex := make(map[board.CrossCoord]int)
+	for i, cross := range crossings {
+		crossingIndex[cross] = i
+	}
+	pathIndex := make(map[board.PathCoord]int)
+	for i, path := range paths {
+		pathIndex[path] = i
+	}
+	return tileIndex, crossingIndex, pathIndex
+}
+
+// Observation layout: blocks of features in this order. Players are counted
+// from the seat observing, which is always player 0, and every value is
+// scaled to about 0..1.
+const (
+	// per tile: its terrain one-hot (6), how many of the 36 rolls pay it
+	// (5 at most), and whether the robber is on it
+	TileFeatures = 8
+	// per crossing: a settlement, then a city, of each player (2×4)
+	CrossingFeatures = 2 * maxPlayers
+	// per path: a road of each player
+	PathFeatures = maxPlayers
+	// per player: public victory points, hand size, unplayed development
+	// cards, and played knights
+	PlayerFeatures = 4
+	// the observer's own hand: resources (5) and unplayed cards by kind (5),
+	// the cards left in the deck, the last roll one-hot (11), and whether
+	// it is the observer's turn
+	HandFeatures = resourceCount + 5 + 1 + 11 + 1
+
+	tileOffset     = 0
+	crossingOffset = tileOffset + tileCount*TileFeatures
+	pathOffset     = crossingOffset + crossingCount*CrossingFeatures
+	playerOffset   = pathOffset + pathCount*PathFeatures
+	handOffset     = playerOffset + maxPlayers*PlayerFeatures
+	// FeatureCount is the size of an observation
+	FeatureCount = handOffset + HandFeatures
+)
+
+var allCards = []game.DevCard{game.DevCardKnight, game.DevCardRoadBuilding, game.DevCardMonopoly, game.DevCardYearOfPlenty, game.DevCardVictoryPoint}
+
+// encode writes what the observer can see of the game into features
+func encode(g *game.Game, observer int, features []float32) {
+	clear(features)
+	n := len(g.Players)
+	relative := func(player int) int {
+		return (player - observer + n) % n
+	}
+	b := g.Board
+
+	robber := b.GetRobber()
+	for i, coord := range tiles {
+		f := features[tileOffset+i*TileFeatures:]
+		tile := b.Tiles[coord]
+		f[int(tile.Terrain)] = 1
+		f[6] = float32(board.Pips(tile.DiceNumber)) / 5
+		if coord == robber {
+			f[7] = 1
+		}
+	}
+	var points [maxPlayers]int
+	for cross, owner := range b.Settlements {
+		f := features[crossingOffset+crossingIndex[cross]*CrossingFeatures:]
+		points[owner]++
+		if _, city := b.CityUpgrades[cross]; city {
+			f[maxPlayers+relative(owner)] = 1
+			points[owner]++
+		} else {
+			f[relative(owner)] = 1
+		}
+	}
+	for path, owner := range b.Roads {
+		features[pathOffset+pathIndex[path]*PathFeatures+relative(owner)] = 1
+	}
+
+	for player := range g.Players {
+		p := &g.Players[player]
+		f := features[playerOffset+relative(player)*PlayerFeatures:]
+		knights := 0
+		for _, card := range p.PlayedDevCards {
+			switch card {
+			case game.DevCardVictoryPoint:
+				points[player]++
+			case game.DevCardKnight:
+				knights++
+			}
+		}
+		f[0] = float32(points[player]) / 10
+		f[1] = float32(p.TotalResources()) / 20
+		f[2] = float32(len(p.HiddenDevCards)) / 5
+		f[3] = float32(knights) / 5
+	}
+
+	f := features[handOffset:]
+	me := &g.Players[observer]
+	for i, resource := range board.RESOURCE_TYPES {
+		f[i] = float32(me.Resources[resource]) / 10
+	}
+	for _, card := range me.HiddenDevCards {
+		for i, kind := range allCards {
+			if card == kind {
+				f[resourceCount+i] += 0.2
+			}
+		}
+	}
+	f[resourceCount+5] = float32(len(g.DevCardDeck)) / 25
+	if roll := g.LastDice[0] + g.LastDice[1]; roll >= 2 {
+		f[resourceCount+6+roll-2] = 1
+	}
+	if g.PlayerTurn == observer {
+		f[HandFeatures-1] = 1
+	}
+}
@@ -0,0 +1,332 @@
+package game
+
+import (
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"slices"
+)
+
+// Action is a move in the game, carried out with Game.Apply. The phases are a
+// front-end that builds actions from the cursor, so anything a player can do
+// with the keyboard can also be done with an action.
+type Action interface {
+	action()
+}
+
+// BuildSettlement places a settlement, paying for it unless it is one of the
+// initial settlements
+type BuildSettlement struct {
+	At board.CrossCoord
+}
+
+// BuildRoad places a road, paying for it unless it is an initial or free road.
+// From must be the end connected to the player's roads or settlements.
+type BuildRoad struct {
+	Road board.PathCoord
+}
+
+// BuildCity upgrades one of the player's settlements
+type BuildCity struct {
+	At board.CrossCoord
+}
+
+type BuyDevCard struct{}
+
+// PlayDevCard plays a card from the player's hand. Monopoly and Year of
+// Plenty are followed by PickResource, Knight by MoveRobber and Road Building
+// by two free BuildRoad.
+type PlayDevCard struct {
+	Card DevCard
+}
+
+// PickResource chooses the resource of a Monopoly, or one of the two of a
+// Year of Plenty
+type PickResource struct {
+	Resource board.ResourceType
+}
+
+type RollDice struct{}
+
+type MoveRobber struct {
+	To board.TileCoord
+}
+
+// Steal takes a random card from a player next to the robber
+type Steal struct {
+	From int
+}
+
+// Discard gives up half of the player's hand after a 7 is rolled
+type Discard struct {
+	Resources map[board.ResourceType]int
+}
+
+// Trade exchanges resources, only with the bank at 4:1 for now
+type Trade struct {
+	Offer   map[board.ResourceType]int
+	Request map[board.ResourceType]int
+}
+
+type EndTurn struct{}
+
+func (BuildSettlement) action() {}
+func (BuildRoad) action()       {}
+func (BuildCity) action()       {}
+func (BuyDevCard) action()      {}
+func (PlayDevCard) action()     {}
+func (PickResource) action()    {}
+func (RollDice) action()        {}
+func (MoveRobber) action()      {}
+func (Steal) action()           {}
+func (Discard) action()         {}
+func (Trade) action()           {}
+func (EndTurn) action()         {}
+
+var (
+	ErrNotWaitingOn = i18n.Errorf("err.not_your_turn")
+	ErrNotNow       = i18n.Errorf("err.not_now")
+	ErrNoResources  = i18n.Errorf("err.no_resources")
+)
+
+// Apply carries out the player's action, or returns why the rules don't
+// allow it, in which case nothing changes.
+func (g *Game) Apply(player int, action Action) error {
+	if player < 0 || player >= len(g.Players) || g.isOver() {
+		return ErrNotWaitingOn
+	}
+	if d := g.decisionFor(player); d != nil {
+		discard, ok := action.(Discard)
+		decision, isDiscard := d.(*discardDecision)
+		if !ok || !isDiscard {
+			return ErrNotNow
+		}
+		if err := decision.apply(discard.Resources); err != nil {
+			return err
+		}
+		g.settleDecision(player)
+		return nil
+	}
+	if !g.phaseWaitsOn(player) {
+		return ErrNotWaitingOn
+	}
+	next, err := g.applyIn(g.phase, action)
+	if err != nil {
+		return err
+	}
+	g.phase = next
+	return nil
+}
+
+// applyIn carries out an action of the turn holder in the phase and returns
+// the phase that follows
+func (g *Game) applyIn(phase Phase, action Action) (Phase, error) {
+	switch p := phase.(type) {
+	case *phaseInitialSettlements:
+		if a, ok := action.(BuildSettlement); ok {
+			return p.place(a.At)
+		}
+	case *phaseInitialRoad:
+		if a, ok := action.(BuildRoad); ok {
+			return p.place(a.Road)
+		}
+	case *phaseDiceRoll:
+		switch a := action.(type) {
+		case RollDice:
+			return rollDice(g), nil
+		case PlayDevCard:
+			if a.Card == DevCardKnight {
+				return g.playDevCard(a.Card, p)
+			}
+		}
+	case *phasePlaceRobber:
+		if a, ok := action.(MoveRobber); ok {
+			return p.place(a.To)
+		}
+	case *phaseStealCard:
+		if a, ok := action.(Steal); ok {
+			return p.steal(a.From)
+		}
+	case *phaseMonopoly:
+		if a, ok := action.(PickResource); ok {
+			return p.pick(a.Resource)
+		}
+	case *phaseYearOfPlenty:
+		if a, ok := action.(PickResource); ok {
+			return p.pick(a.Resource)
+		}
+	case *phaseRoadStart:
+		if a, ok := action.(BuildRoad); ok && p.isFree {
+			return g.buildFreeRoad(a.Road, p.continuation)
+		}
+	case *phaseRoadEnd:
+		if a, ok := action.(BuildRoad); ok && p.isFree {
+			return g.buildFreeRoad(a.Road, p.continuation)
+		}
+	}
+	if inTurn(phase) {
+		return g.applyTurnAction(action)
+	}
+	return nil, ErrNotNow
+}
+
+// inTurn reports whether the phase is the main part of the turn, or one of its
+// menus, where the turn holder can build, trade, play cards and end the turn
+func inTurn(phase Phase) bool {
+	switch p := phase.(type) {
+	case *phaseIdle, *phaseBuilding, *phaseSettlementPlacement, *phaseCityPlacement,
+		*phaseTradeOffer, *phaseTradeSelectReceive, *phasePlayDevelopmentCard:
+		return true
+	case *phaseRoadStart:
+		return !p.isFree
+	case *phaseRoadEnd:
+		return !p.isFree
+	}
+	return false
+}
+
+func (g *Game) applyTurnAction(action Action) (Phase, error) {
+	switch a := action.(type) {
+	case BuildRoad:
+		return g.buildRoad(a.Road, false, nil)
+	case BuildSettlement:
+		return g.buildSettlement(a.At)
+	case BuildCity:
+		return g.buildCity(a.At)
+	case BuyDevCard:
+		return g.buyDevCard()
+	case PlayDevCard:
+		return g.playDevCard(a.Card, PhaseIdle(g))
+	case Trade:
+		return g.trade(a.Offer, a.Request)
+	case EndTurn:
+		g.TurnsPlayed++
+		g.PlayerTurn++
+		g.PlayerTurn %= len(g.Players)
+		g.LogAction("log.turn", playerName(g.PlayerTurn))
+		return PhaseDiceRoll(g), nil
+	}
+	return nil, ErrNotNow
+}
+
+// buildRoad places a road of the turn holder and continues with next, or with
+// the idle phase if next is nil
+func (g *Game) buildRoad(road board.PathCoord, free bool, next Phase) (Phase, error) {
+	player := &g.Players[g.PlayerTurn]
+	if !g.canBuildRoad(road, g.PlayerTurn) {
+		return nil, i18n.Errorf("err.road_here")
+	}
+	if !free && !player.BuildRoad() {
+		return nil, ErrNoResources
+	}
+	g.Board.SetRoad(board.NewPathCoord(road.From, road.To), g.PlayerTurn)
+	if free {
+		g.LogAction("log.free_road", playerName(g.PlayerTurn))
+	} else {
+		g.LogAction("log.road", playerName(g.PlayerTurn))
+	}
+	if next == nil {
+		return PhaseIdleWithNotification(g, i18n.M("notice.road")), nil
+	}
+	return next, nil
+}
+
+// buildFreeRoad places a road of Road Building, skipping the second one if
+// there is nowhere left to build it
+func (g *Game) buildFreeRoad(road board.PathCoord, next Phase) (Phase, error) {
+	next, err := g.buildRoad(road, true, next)
+	if p, ok := next.(*phaseRoadStart); ok && p.isFree && len(g.roadSpots(g.PlayerTurn)) == 0 {
+		return p.continuation, nil
+	}
+	return next, err
+}
+
+func (g *Game) buildSettlement(at board.CrossCoord) (Phase, error) {
+	player := &g.Players[g.PlayerTurn]
+	if !g.canBuildSettlement(at, g.PlayerTurn) {
+		return nil, i18n.Errorf("err.settlement_here")
+	}
+	if !player.BuildSettlement() {
+		return nil, ErrNoResources
+	}
+	g.Board.SetSettlement(at, g.PlayerTurn)
+	g.LogAction("log.settlement", playerName(g.PlayerTurn))
+	if winner := g.CheckGameEnd(); winner != nil {
+		return PhaseGameEnd(g, winner), nil
+	}
+	return PhaseIdleWithNotification(g, i18n.M("notice.settlement")), nil
+}
+
+func (g *Game) buildCity(at board.CrossCoord) (Phase, error) {
+	player := &g.Players[g.PlayerTurn]
+	if !g.Board.CanUpgradeToCity(at, g.PlayerTurn) {
+		return nil, i18n.Errorf("err.city_here")
+	}
+	if !player.BuildCity() {
+		return nil, ErrNoResources
+	}
+	g.Board.UpgradeToCity(at, g.PlayerTurn)
+	g.LogAction("log.city", playerName(g.PlayerTurn))
+	if winner := g.CheckGameEnd(); winner != nil {
+		return PhaseGameEnd(g, winner), nil
+	}
+	return PhaseIdleWithNotification(g, i18n.M("notice.city")), nil
+}
+
+func (g *Game) buyDevCard() (Phase, error) {
+	player := &g.Players[g.PlayerTurn]
+	if len(g.DevCardDeck) == 0 {
+		return nil, i18n.Errorf("err.no_cards_left")
+	}
+	if !player.BuyDevelopmentCard() {
+		return nil, ErrNoResources
+	}
+	card := g.DrawDevelopmentCard()
+	player.HiddenDevCards = append(player.HiddenDevCards, *card)
+	g.LogAction("log.bought_card", playerName(g.PlayerTurn))
+	// the card may be a victory point
+	if winner := g.CheckGameEnd(); winner != nil {
+		return PhaseGameEnd(g, winner), nil
+	}
+	return PhaseIdleWithNotification(g, i18n.M("notice.bought_card", *card)), nil
+}
+
+// playDevCard plays a card of the turn holder, returning to the previous
+// phase once the card is resolved
+func (g *Game) playDevCard(card DevCard, previous Phase) (Phase, error) {
+	player := &g.Players[g.PlayerTurn]
+	if card == DevCardVictoryPoint {
+		return nil, i18n.Errorf("err.victory_point_card")
+	}
+	if !player.PlayDevCard(card) {
+		return nil, i18n.Errorf("err.no_card", card)
+	}
+	g.LogAction("log.played", playerName(g.PlayerTurn), card)
+	switch card {
+	case DevCardKnight:
+		return PhasePlaceRobber(g, previous), nil
+	case DevCardRoadBuilding:
+		return PhaseRoadBuilding(g), nil
+	case DevCardMonopoly:
+		return PhaseMonopoly(g, previous), nil
+	case DevCardYearOfPlenty:
+		return PhaseYearOfPlenty(g, previous), nil
+	default:
+		panic("This card does not exist")
+	}
+}
+
+func (g *Game) trade(offer, request map[board.ResourceType]int) (Phase, error) {
+	player := &g.Players[g.PlayerTurn]
+	tradeType, offeredResource, requestedResource := isBankTrade(offer, request)
+	if tradeType != "bank" || !slices.Contains(board.RESOURCE_TYPES, requestedResource) {
+		return nil, i18n.Errorf("err.trade_type")
+	}
+	if player.Resources[offeredResource] < 4 {
+		return nil, i18n.Errorf("err.bank_trade")
+	}
+	player.Resources[offeredResource] -= 4
+	player.Resources[requestedResource]++
+	g.LogAction("log.bank_trade", playerName(g.PlayerTurn), offeredResource, requestedResource)
+	return PhaseIdleWithNotification(g,
+		i18n.M("notice.bank_trade", offeredResource, requestedResource)), nil
+}
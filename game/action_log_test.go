@@ -2,6 +2,7 @@ package game
 
 import (
 	"el_poblador/board"
+	"el_poblador/i18n"
 	"testing"
 )
 
@@ -12,40 +13,41 @@ func TestActionLogBasicFunctionality(t *testing.T) {
 	// Test basic logging
 	game.LogAction("Test action 1")
 	game.LogAction("Test action 2")
+	log := game.LogLines(i18n.English)
 
-	if len(game.ActionLog) != 2 {
-		t.Errorf("Expected 2 actions, got %d", len(game.ActionLog))
+	if len(log) != 2 {
+		t.Errorf("Expected 2 actions, got %d", len(log))
 	}
 
-	if game.ActionLog[0] != "Test action 2" {
-		t.Errorf("Expected first action 'Test action 2' (most recent), got '%s'", game.ActionLog[0])
+	if log[0] != "Test action 2" {
+		t.Errorf("Expected first action 'Test action 2' (most recent), got '%s'", log[0])
 	}
 
-	if game.ActionLog[1] != "Test action 1" {
-		t.Errorf("Expected second action 'Test action 1' (oldest), got '%s'", game.ActionLog[1])
+	if log[1] != "Test action 1" {
+		t.Errorf("Expected second action 'Test action 1' (oldest), got '%s'", log[1])
 	}
 }
 
-func TestActionLogMaxLength(t *testing.T) {
+func TestActionLogKeepsEveryAction(t *testing.T) {
 	game := &Game{}
 	game.Start([]string{"Alice", "Bob", "Charlie"})
 
-	// Add more than 15 actions
 	for i := 1; i <= 20; i++ {
 		game.LogAction("Action " + string(rune('0'+i%10)))
 	}
+	log := game.LogLines(i18n.English)
 
-	if len(game.ActionLog) != 15 {
-		t.Errorf("Expected log length to be capped at 15, got %d", len(game.ActionLog))
+	if len(log) != 20 {
+		t.Errorf("Expected every action to be kept, got %d", len(log))
 	}
 
-	// Newest should be at index 0, oldest at index 14
-	if game.ActionLog[0] != "Action 0" {
-		t.Errorf("Expected first action 'Action 0' (most recent), got '%s'", game.ActionLog[0])
+	// Newest should be at index 0, oldest at index 19
+	if log[0] != "Action 0" {
+		t.Errorf("Expected first action 'Action 0' (most recent), got '%s'", log[0])
 	}
 
-	if game.ActionLog[14] != "Action 6" {
-		t.Errorf("Expected last action 'Action 6' (oldest), got '%s'", game.ActionLog[14])
+	if log[19] != "Action 1" {
+		t.Errorf("Expected last action 'Action 1' (oldest), got '%s'", log[19])
 	}
 }
 
@@ -80,13 +82,14 @@ func TestActionLogDevelopmentCardPurchase(t *testing.T) {
 	}
 
 	// Check that the action was logged
-	if len(game.ActionLog) == 0 {
+	log := game.LogLines(i18n.English)
+	if len(log) == 0 {
 		t.Fatal("Expected at least one action to be logged")
 	}
 
 	expectedAction := firstPlayer.Name + " bought a development card"
 	found := false
-	for _, action := range game.ActionLog {
+	for _, action := range log {
 		if action == expectedAction {
 			found = true
 			break
@@ -94,7 +97,7 @@ func TestActionLogDevelopmentCardPurchase(t *testing.T) {
 	}
 
 	if !found {
-		t.Errorf("Expected to find action '%s' in log: %v", expectedAction, game.ActionLog)
+		t.Errorf("Expected to find action '%s' in log: %v", expectedAction, log)
 	}
 }
 
@@ -136,11 +139,12 @@ func TestActionLogUsesRenderNameInsteadOfPlainName(t *testing.T) {
 	game.phase = game.phase.Confirm()
 
 	// Check that the action was logged
-	if len(game.ActionLog) == 0 {
+	log := game.LogLines(i18n.English)
+	if len(log) == 0 {
 		t.Fatal("Expected at least one action to be logged")
 	}
 
-	loggedAction := game.ActionLog[0]
+	loggedAction := log[0]
 
 	// Verify it contains the expected text structure
 	if !containsText(loggedAction, "bought a development card") {
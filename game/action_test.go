@@ -0,0 +1,82 @@
+package game
+
+import (
+	"el_poblador/board"
+	"errors"
+	"testing"
+)
+
+func TestApplyInitialPlacement(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	at, _ := board.NewCrossCoord(2, 4)
+	to := at.Neighbors()[0]
+
+	if err := game.Apply(1, BuildSettlement{At: at}); !errors.Is(err, ErrNotWaitingOn) {
+		t.Errorf("Expected p2 to wait for their turn, got %v", err)
+	}
+	if err := game.Apply(0, BuildRoad{Road: board.PathCoord{From: at, To: to}}); !errors.Is(err, ErrNotNow) {
+		t.Errorf("Expected the settlement to come before the road, got %v", err)
+	}
+	if err := game.Apply(0, BuildSettlement{At: board.CrossCoord{X: 40, Y: 40}}); err == nil {
+		t.Errorf("Expected a settlement off the board to be refused")
+	}
+	if err := game.Apply(0, BuildSettlement{At: at}); err != nil {
+		t.Fatalf("Failed to place the first settlement: %v", err)
+	}
+	far, _ := board.NewCrossCoord(0, 2)
+	if err := game.Apply(0, BuildRoad{Road: board.PathCoord{From: far, To: far.Neighbors()[0]}}); err == nil {
+		t.Errorf("Expected a road away from the settlement to be refused")
+	}
+	if err := game.Apply(0, BuildRoad{Road: board.PathCoord{From: at, To: to}}); err != nil {
+		t.Fatalf("Failed to place the first road: %v", err)
+	}
+	if game.PlayerTurn != 1 || len(game.Board.Settlements) != 1 || len(game.Board.Roads) != 1 {
+		t.Errorf("Expected the placement to pass the turn to p2, turn %d", game.PlayerTurn)
+	}
+}
+
+func TestApplyTurnActions(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	at, _ := board.NewCrossCoord(2, 4)
+	to := at.Neighbors()[0]
+	game.Board.SetSettlement(at, 0)
+	game.Board.SetRoad(board.NewPathCoord(at, to), 0)
+	game.phase = PhaseIdle(game)
+
+	next := to.Neighbors()[0]
+	if next == at {
+		next = to.Neighbors()[1]
+	}
+	road := BuildRoad{Road: board.PathCoord{From: to, To: next}}
+	if err := game.Apply(0, road); !errors.Is(err, ErrNoResources) {
+		t.Fatalf("Expected a road without resources to be refused, got %v", err)
+	}
+	game.Players[0].Resources[board.ResourceWood] = 5
+	game.Players[0].Resources[board.ResourceBrick] = 1
+	if err := game.Apply(0, road); err != nil {
+		t.Fatalf("Failed to build a road: %v", err)
+	}
+	if game.Board.Roads[board.NewPathCoord(to, next)] != 0 || game.Players[0].Resources[board.ResourceWood] != 4 {
+		t.Errorf("Expected the road to be built and paid for")
+	}
+
+	trade := Trade{
+		Offer:   map[board.ResourceType]int{board.ResourceWood: 4},
+		Request: map[board.ResourceType]int{board.ResourceOre: 1},
+	}
+	if err := game.Apply(0, trade); err != nil {
+		t.Fatalf("Failed to trade with the bank: %v", err)
+	}
+	if game.Players[0].Resources[board.ResourceOre] != 1 || game.Players[0].Resources[board.ResourceWood] != 0 {
+		t.Errorf("Expected 4 wood to become 1 ore, got %v", game.Players[0].Resources)
+	}
+
+	if err := game.Apply(0, RollDice{}); !errors.Is(err, ErrNotNow) {
+		t.Errorf("Expected a second roll to be refused, got %v", err)
+	}
+	if err := game.Apply(0, EndTurn{}); err != nil || game.PlayerTurn != 1 {
+		t.Errorf("Expected the turn to pass to p2, got %v and turn %d", err, game.PlayerTurn)
+	}
+}
@@ -0,0 +1,151 @@
+package game
+
+import "el_poblador/board"
+
+// AutoPlay makes the default decisions for the turn holder: roll, don't
+// build and pass the turn. It stops once their turn is over, or when it has
+// to wait on the other players' decisions. Decisions that can't be skipped,
+// like placing the robber or the initial settlements, are taken at the first
+// legal spot. It is used to keep a game going when a player has abandoned
+// their seat.
+func (g *Game) AutoPlay() {
+	player := g.PlayerTurn
+	// every phase is resolved within a few steps, this is just a safety net
+	for i := 0; i < 50 && g.PlayerTurn == player; i++ {
+		if _, over := g.phase.(*phaseGameEnd); over || g.shouldQuit {
+			return
+		}
+		if _, waiting := g.phase.(*phaseAwaitDecisions); waiting {
+			// the other players have to decide first
+			return
+		}
+		g.phase = g.autoStep()
+	}
+}
+
+// AutoPlayFor makes the default decisions for the player: their pending
+// decisions first, then their turn if the game is waiting on it. It reports
+// whether the game moved on.
+func (g *Game) AutoPlayFor(player int) bool {
+	progressed := false
+	for d := g.decisionFor(player); d != nil; d = g.decisionFor(player) {
+		autoDecide(g, d)
+		g.settleDecision(player)
+		progressed = true
+	}
+	if g.phaseWaitsOn(player) && player == g.PlayerTurn {
+		g.AutoPlay()
+		progressed = progressed || g.PlayerTurn != player
+	}
+	return progressed
+}
+
+// autoDecide settles a decision the way AutoPlay would
+func autoDecide(g *Game, d Decision) {
+	switch d := d.(type) {
+	case *discardDecision:
+		// give up the most plentiful resources first
+		player := &g.Players[d.player]
+		for d.total() < d.amount {
+			most := board.RESOURCE_TYPES[0]
+			for _, resourceType := range board.RESOURCE_TYPES {
+				if player.Resources[resourceType]-d.discard[resourceType] > player.Resources[most]-d.discard[most] {
+					most = resourceType
+				}
+			}
+			d.discard[most]++
+		}
+		d.Confirm()
+	default:
+		// other decisions are settled with whatever they start with
+		d.Confirm()
+	}
+}
+
+func (g *Game) autoStep() Phase {
+	switch p := g.phase.(type) {
+	case *phaseInitialSettlements:
+		for _, coord := range allCrossCoords() {
+			if g.Board.CanPlaceSettlement(coord) {
+				p.cursorCross = coord
+				break
+			}
+		}
+		return p.Confirm()
+	case *phaseInitialRoad:
+		for _, neighbor := range p.sourceCross.Neighbors() {
+			if _, taken := g.Board.Roads[board.NewPathCoord(p.sourceCross, neighbor)]; !taken {
+				p.cursorCross = neighbor
+				break
+			}
+		}
+		return p.Confirm()
+	case *phaseDiceRoll:
+		p.selected = 0
+		return p.Confirm()
+	case *phaseIdle:
+		p.selected = len(p.options) - 1 // End Turn
+		return p.Confirm()
+	case *phasePlaceRobber:
+		for _, coord := range allTileCoords() {
+			if coord != g.Board.GetRobber() {
+				p.tileCoord = coord
+				break
+			}
+		}
+		return p.Confirm()
+	case *phaseStealCard:
+		return p.Confirm()
+	case *phaseMonopoly:
+		p.selected = 0
+		return p.Confirm()
+	case *phaseYearOfPlenty:
+		p.selected = 0
+		return p.Confirm()
+	case *phaseRoadStart:
+		if !p.isFree {
+			return p.Cancel()
+		}
+		for _, coord := range allCrossCoords() {
+			if !g.Board.HasRoadConnected(coord, g.PlayerTurn) && !g.Board.HasSettlementAt(coord, g.PlayerTurn) {
+				continue
+			}
+			for _, neighbor := range coord.Neighbors() {
+				if g.Board.CanPlaceRoad(board.NewPathCoord(coord, neighbor), g.PlayerTurn) {
+					p.cursorCross = coord
+					return p.Confirm()
+				}
+			}
+		}
+		// nowhere to build the free road, give it up
+		return PhaseIdle(g)
+	case *phaseRoadEnd:
+		if !p.isFree {
+			return p.Cancel()
+		}
+		for _, neighbor := range p.startCross.Neighbors() {
+			if g.Board.CanPlaceRoad(board.NewPathCoord(p.startCross, neighbor), g.PlayerTurn) {
+				p.cursorCross = neighbor
+				return p.Confirm()
+			}
+		}
+		return PhaseIdle(g)
+	case PhaseCancelable:
+		return p.Cancel()
+	default:
+		return g.phase.Confirm()
+	}
+}
+
+// allCrossCoords lists every crossing on the board
+func allCrossCoords() []board.CrossCoord {
+	var coords []board.CrossCoord
+	for x := 0; x <= 5; x++ {
+		for y := 0; y <= 10; y++ {
+			if coord, valid := board.NewCrossCoord(x, y); valid {
+				coords = append(coords, coord)
+			}
+		}
+	}
+	return coords
+}
@@ -0,0 +1,34 @@
+package game
+
+import "testing"
+
+func TestAutoPlayPassesTurn(t *testing.T) {
+	game := &Game{}
+	game.Start([]string{"p1", "p2", "p3"})
+
+	// the autopilot places both initial settlements for every player
+	for i := 0; i < 2*len(game.Players); i++ {
+		game.AutoPlay()
+	}
+	if _, ok := game.phase.(*phaseDiceRoll); !ok {
+		t.Fatalf("Expected dice roll after the initial placements, got %T", game.phase)
+	}
+	if len(game.Board.Settlements) != 6 || len(game.Board.Roads) != 6 {
+		t.Fatalf("Expected 6 settlements and roads, got %d and %d", len(game.Board.Settlements), len(game.Board.Roads))
+	}
+
+	// a regular turn is rolled, robber included, and passed on
+	for i := 0; i < 20; i++ {
+		before := game.PlayerTurn
+		game.AutoPlay()
+		// on a 7 the others may have to discard before the turn goes on
+		for game.PlayerTurn == before {
+			for _, player := range game.WaitingOn() {
+				game.AutoPlayFor(player)
+			}
+		}
+		if game.PlayerTurn != (before+1)%len(game.Players) {
+			t.Fatalf("Turn %d: expected the turn to pass from %d, got %d (%T)", i, before, game.PlayerTurn, game.phase)
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package game
+
+import (
+	"el_poblador/board"
+	"slices"
+)
+
+// Bot decides for a seat played by the computer. The game consults it
+// whenever the seat has something to decide, and carries out the answers
+// with Apply, just like a human's. Answers the rules don't allow are refused,
+// and the game falls back to AutoPlay for that step.
+type Bot interface {
+	InitialSettlement(view BotView) board.CrossCoord
+	// InitialRoad picks the other end of the road leaving the settlement
+	InitialRoad(view BotView, settlement board.CrossCoord) board.CrossCoord
+	// KnightBeforeRoll reports whether to play a knight before rolling
+	KnightBeforeRoll(view BotView) bool
+	// Turn picks the next move of the turn, until it returns MoveEndTurn
+	Turn(view BotView) Move
+	// Road picks where to build a road, From being the end already connected
+	Road(view BotView) board.PathCoord
+	Settlement(view BotView) board.CrossCoord
+	City(view BotView) board.CrossCoord
+	Robber(view BotView) board.TileCoord
+	// Steal picks one of the victims, by player id
+	Steal(view BotView, victims []int) int
+	Discard(view BotView, amount int) map[board.ResourceType]int
+	Monopoly(view BotView) board.ResourceType
+	YearOfPlenty(view BotView) (board.ResourceType, board.ResourceType)
+}
+
+// ActionBot is a bot that picks its answers straight from the legal actions
+// instead of answering the questions of Bot, which it still implements for
+// the places that ask them
+type ActionBot interface {
+	Bot
+	// Act picks one of the actions, of which there are at least two
+	Act(view BotView, actions []Action) Action
+}
+
+type MoveKind int
+
+const (
+	MoveEndTurn MoveKind = iota
+	MoveBuildRoad
+	MoveBuildSettlement
+	MoveBuildCity
+	MoveBuyDevCard
+	MovePlayDevCard
+	MoveBankTrade
+)
+
+// Move is something a bot does in its turn
+type Move struct {
+	Kind MoveKind
+	// Card to play for MovePlayDevCard
+	Card DevCard
+	// Give four of a resource to the bank to Get one, for MoveBankTrade
+	Give, Get board.ResourceType
+}
+
+// maxBotMoves ends a bot's turn if it keeps trying moves that get refused
+const maxBotMoves = 20
+
+// SetBot hands the player's seat to a bot, or back to a human with nil.
+// Loaded games give the seats that were played by the computer to a SimpleBot.
+func (g *Game) SetBot(player int, bot Bot) {
+	if g.bots == nil {
+		g.bots = make(map[int]Bot)
+	}
+	if bot == nil {
+		delete(g.bots, player)
+	} else {
+		g.bots[player] = bot
+	}
+	g.Players[player].Bot = bot != nil
+}
+
+func (g *Game) botFor(player int) Bot {
+	if !g.Players[player].Bot {
+		return nil
+	}
+	if g.bots[player] == nil {
+		g.SetBot(player, NewSimpleBot())
+	}
+	return g.bots[player]
+}
+
+// PlayBots lets the bots decide for every seat they play that the game is
+// waiting on, until it waits on humans only.
+func (g *Game) PlayBots() {
+	// a few full rounds at most, so a game of bots doesn't hog the caller
+	g.playBots(500)
+}
+
+func (g *Game) playBots(steps int) {
+	moves := 0
+	for ; steps > 0; steps-- {
+		player, bot := g.waitingBot()
+		if bot == nil {
+			return
+		}
+		g.botStep(player, bot, &moves)
+	}
+}
+
+// BotMove is one step of a bot the game waits on. A bot that picks among the
+// legal actions works its pick out on a copy of the game, so interfaces can
+// keep drawing the game while a slow bot thinks: take NextBotMove, Think
+// away from the game, then PlayBotMove.
+type BotMove struct {
+	player  int
+	logged  int
+	actor   ActionBot
+	view    BotView
+	actions []Action
+	picked  Action
+}
+
+// NextBotMove returns the next step of a bot the game waits on, or nil if it
+// waits on humans only
+func (g *Game) NextBotMove() *BotMove {
+	player, bot := g.waitingBot()
+	if bot == nil {
+		return nil
+	}
+	m := &BotMove{player: player, logged: g.logged}
+	if actor, ok := bot.(ActionBot); ok {
+		if actions := g.LegalActions(player); len(actions) > 1 {
+			m.actor, m.view, m.actions = actor, BotView{game: g.Clone(), Me: player}, actions
+		}
+	}
+	return m
+}
+
+// Think lets the bot pick its action. It doesn't touch the game the move
+// comes from, and can run without holding it.
+func (m *BotMove) Think() {
+	if m.actor != nil {
+		m.picked = m.actor.Act(m.view, m.actions)
+	}
+}
+
+// PlayBotMove carries out the step, and reports whether it did. Steps the
+// game has moved on from since NextBotMove are dropped.
+func (g *Game) PlayBotMove(m *BotMove) bool {
+	if player, bot := g.waitingBot(); bot == nil || player != m.player || g.logged != m.logged {
+		return false
+	}
+	bot := g.botFor(m.player)
+	actor, ok := bot.(ActionBot)
+	if !ok || m.picked == nil {
+		g.botStep(m.player, bot, &g.botMoves)
+		return true
+	}
+	if !slices.ContainsFunc(g.LegalActions(m.player), sameAction(m.picked)) {
+		return false
+	}
+	g.actorStep(m.player, pickedActor{ActionBot: actor, picked: m.picked}, BotView{game: g, Me: m.player}, &g.botMoves)
+	return true
+}
+
+// pickedActor answers with an action its bot already picked
+type pickedActor struct {
+	ActionBot
+	picked Action
+}
+
+func (a pickedActor) Act(BotView, []Action) Action { return a.picked }
+
+// waitingBot returns the first player the game is waiting on that is played
+// by a bot
+func (g *Game) waitingBot() (int, Bot) {
+	for _, player := range g.WaitingOn() {
+		if bot := g.botFor(player); bot != nil {
+			return player, bot
+		}
+	}
+	return -1, nil
+}
+
+// botStep carries out one answer of the bot with Apply, like a human would
+// with the keyboard
+func (g *Game) botStep(player int, bot Bot, moves *int) {
+	view := BotView{game: g, Me: player}
+	if actor, ok := bot.(ActionBot); ok {
+		g.actorStep(player, actor, view, moves)
+		return
+	}
+	if d := g.decisionFor(player); d != nil {
+		if action := askBot(bot, view); action != nil && g.Apply(player, action) == nil {
+			return
+		}
+		autoDecide(g, d)
+		g.settleDecision(player)
+		return
+	}
+
+	var action Action = EndTurn{}
+	if inTurn(g.phase) {
+		*moves++
+	}
+	if !inTurn(g.phase) || *moves <= maxBotMoves {
+		action = askBot(bot, view)
+	}
+	if action == nil {
+		g.phase = g.autoStep()
+		return
+	}
+	if _, ending := action.(EndTurn); ending {
+		*moves = 0
+	}
+	if g.Apply(player, action) != nil {
+		// the rules refused the answer, let the autopilot take this step instead
+		g.phase = g.autoStep()
+	}
+}
+
+// askBot returns the bot's answer to what the game waits on it for, or nil if
+// it has nothing to ask the bot
+func askBot(bot Bot, view BotView) Action {
+	g := view.game
+	if d := g.decisionFor(view.Me); d != nil {
+		if discard, ok := d.(*discardDecision); ok {
+			return Discard{Resources: bot.Discard(view, discard.amount)}
+		}
+		return nil
+	}
+	switch p := g.phase.(type) {
+	case *phaseInitialSettlements:
+		return BuildSettlement{At: bot.InitialSettlement(view)}
+	case *phaseInitialRoad:
+		return BuildRoad{Road: board.PathCoord{From: p.sourceCross, To: bot.InitialRoad(view, p.sourceCross)}}
+	case *phaseDiceRoll:
+		if slices.Contains(view.DevCards(), DevCardKnight) && bot.KnightBeforeRoll(view) {
+			return PlayDevCard{Card: DevCardKnight}
+		}
+		return RollDice{}
+	case *phaseRoadStart, *phaseRoadEnd:
+		if inTurn(p) {
+			break
+		}
+		return BuildRoad{Road: bot.Road(view)}
+	case *phasePlaceRobber:
+		return MoveRobber{To: bot.Robber(view)}
+	case *phaseStealCard:
+		return Steal{From: bot.Steal(view, slices.Clone(p.victims))}
+	case *phaseMonopoly:
+		return PickResource{Resource: bot.Monopoly(view)}
+	case *phaseYearOfPlenty:
+		first, second := bot.YearOfPlenty(view)
+		return PickResource{Resource: [2]board.ResourceType{first, second}[p.selectedCount]}
+	}
+	if inTurn(g.phase) {
+		return bot.Turn(view).action(bot, view)
+	}
+	return nil
+}
+
+// actorStep lets the bot pick among the legal actions, ending its turn if it
+// keeps going without end
+func (g *Game) actorStep(player int, actor ActionBot, view BotView, moves *int) {
+	actions := g.LegalActions(player)
+	if len(actions) == 0 {
+		g.phase = g.autoStep()
+		return
+	}
+	var action Action = EndTurn{}
+	if inTurn(g.phase) {
+		*moves++
+	}
+	if *moves <= maxBotMoves || !slices.Contains(actions, action) {
+		action = actions[0]
+		if len(actions) > 1 {
+			action = actor.Act(view, actions)
+		}
+	}
+	if _, ending := action.(EndTurn); ending {
+		*moves = 0
+	}
+	if g.Apply(player, action) != nil {
+		g.phase = g.autoStep()
+	}
+}
+
+// action turns a move into the action that carries it out, asking the bot
+// where to build
+func (m Move) action(bot Bot, view BotView) Action {
+	switch m.Kind {
+	case MoveBuildRoad:
+		return BuildRoad{Road: bot.Road(view)}
+	case MoveBuildSettlement:
+		return BuildSettlement{At: bot.Settlement(view)}
+	case MoveBuildCity:
+		return BuildCity{At: bot.City(view)}
+	case MoveBuyDevCard:
+		return BuyDevCard{}
+	case MovePlayDevCard:
+		return PlayDevCard{Card: m.Card}
+	case MoveBankTrade:
+		return Trade{
+			Offer:   map[board.ResourceType]int{m.Give: 4},
+			Request: map[board.ResourceType]int{m.Get: 1},
+		}
+	default:
+		return EndTurn{}
+	}
+}
+
+// BotView is what a bot can see of the game: the public board and its own
+// hand. It can't change the game.
+type BotView struct {
+	game *Game
+	// Me is the player the bot plays
+	Me int
+}
+
+// Players is the number of players
+func (v BotView) Players() int {
+	return len(v.game.Players)
+}
+
+// Resources returns a copy of the bot's resources
+func (v BotView) Resources() map[board.ResourceType]int {
+	resources := make(map[board.ResourceType]int)
+	for resource, count := range v.game.Players[v.Me].Resources {
+		resources[resource] = count
+	}
+	return resources
+}
+
+// DevCards returns the development cards the bot can play
+func (v BotView) DevCards() []DevCard {
+	return slices.Clone(v.game.Players[v.Me].HiddenDevCards)
+}
+
+// HandSize returns how many resource cards a player holds
+func (v BotView) HandSize(player int) int {
+	return v.game.Players[player].TotalResources()
+}
+
+func (v BotView) VictoryPoints() int {
+	return v.game.Players[v.Me].VictoryPoints(v.game)
+}
+
+// PublicVictoryPoints is what everyone can see of a player's points: their
+// buildings and played cards
+func (v BotView) PublicVictoryPoints(player int) int {
+	points := v.game.Board.CountSettlements(player) + v.game.Board.CountCities(player)
+	for _, card := range v.game.Players[player].PlayedDevCards {
+		if card == DevCardVictoryPoint {
+			points++
+		}
+	}
+	return points
+}
+
+// CanAfford reports whether the bot has the resources to build or buy
+func (v BotView) CanAfford(kind MoveKind) bool {
+	player := &v.game.Players[v.Me]
+	switch kind {
+	case MoveBuildRoad:
+		return player.CanBuildRoad()
+	case MoveBuildSettlement:
+		return player.CanBuildSettlement()
+	case MoveBuildCity:
+		return player.CanBuildCity()
+	case MoveBuyDevCard:
+		return player.CanBuyDevelopmentCard() && len(v.game.DevCardDeck) > 0
+	default:
+		return true
+	}
+}
+
+// SettlementSpots lists where the bot can place a settlement right now
+func (v BotView) SettlementSpots() []board.CrossCoord {
+	_, initial := v.game.phase.(*phaseInitialSettlements)
+	return v.game.settlementSpots(v.Me, initial)
+}
+
+// CitySpots lists the bot's settlements that can become cities
+func (v BotView) CitySpots() []board.CrossCoord {
+	return v.game.citySpots(v.Me)
+}
+
+// RoadSpots lists where the bot can build a road, From being the end that is
+// already connected to its roads or settlements
+func (v BotView) RoadSpots() []board.PathCoord {
+	return v.game.roadSpots(v.Me)
+}
+
+// InitialRoadSpots lists the free crossings next to a new settlement
+func (v BotView) InitialRoadSpots(settlement board.CrossCoord) []board.CrossCoord {
+	return v.game.initialRoadSpots(settlement)
+}
+
+// Production is how many dice sums out of 36 pay out to a crossing
+func (v BotView) Production(coord board.CrossCoord) int {
+	return v.game.Board.CrossPips(coord)
+}
+
+// ResourcesAt lists the resources produced around a crossing
+func (v BotView) ResourcesAt(coord board.CrossCoord) []board.ResourceType {
+	var resources []board.ResourceType
+	for _, tile := range v.game.Board.AdjacentTiles(coord) {
+		if resource, ok := board.TileResource(tile); ok {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// CanSettle reports whether the crossing is far enough from every settlement,
+// regardless of roads
+func (v BotView) CanSettle(coord board.CrossCoord) bool {
+	return v.game.Board.CanPlaceSettlement(coord)
+}
+
+// Tiles lists every tile of the board and its number
+func (v BotView) Tiles() map[board.TileCoord]board.Tile {
+	tiles := make(map[board.TileCoord]board.Tile, len(v.game.Board.Tiles))
+	for coord, tile := range v.game.Board.Tiles {
+		tiles[coord] = tile
+	}
+	return tiles
+}
+
+func (v BotView) Robber() board.TileCoord {
+	return v.game.Board.GetRobber()
+}
+
+// PlayersAround returns the owners of the settlements and cities around a tile
+func (v BotView) PlayersAround(tile board.TileCoord) []int {
+	return v.game.Board.PlayersAround(tile)
+}
@@ -0,0 +1,177 @@
+package game
+
+import (
+	"el_poblador/board"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBotsPlayAGame(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	for player := range game.Players {
+		game.SetBot(player, NewSimpleBot())
+	}
+
+	for i := 0; i < 200; i++ {
+		game.PlayBots()
+		if _, over := game.phase.(*phaseGameEnd); over {
+			break
+		}
+	}
+	if _, over := game.phase.(*phaseGameEnd); !over {
+		t.Fatalf("Expected the bots to finish the game, got %T with %d, %d and %d points", game.phase,
+			game.Players[0].VictoryPoints(game), game.Players[1].VictoryPoints(game), game.Players[2].VictoryPoints(game))
+	}
+}
+
+func TestBotWaitsForHumans(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.SetBot(0, NewSimpleBot())
+
+	game.PlayBots()
+	if game.PlayerTurn != 1 {
+		t.Fatalf("Expected the bot to place its settlement and pass to p2, turn is %d", game.PlayerTurn)
+	}
+	if len(game.Board.Settlements) != 1 || len(game.Board.Roads) != 1 {
+		t.Errorf("Expected the bot's settlement and road, got %d and %d", len(game.Board.Settlements), len(game.Board.Roads))
+	}
+}
+
+func TestBotsPlayAGameMoveByMove(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.SetBot(0, NewRandomBot(1))
+	game.SetBot(1, NewHeuristicBotSeeded(Normal, 2))
+	game.SetBot(2, NewRandomBot(3))
+
+	for i := 0; i < 20000; i++ {
+		move := game.NextBotMove()
+		if move == nil {
+			break
+		}
+		move.Think()
+		if !game.PlayBotMove(move) {
+			t.Fatalf("Expected the move to be played, nothing happened in between")
+		}
+	}
+	if _, over := game.phase.(*phaseGameEnd); !over {
+		t.Fatalf("Expected the bots to finish the game, got %T", game.phase)
+	}
+}
+
+func TestBotMovesGoStale(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.SetBot(0, NewRandomBot(1))
+
+	move := game.NextBotMove()
+	move.Think()
+	// a clock running out plays the bot's settlement in the meantime
+	game.AutoPlayFor(0)
+	if game.PlayBotMove(move) {
+		t.Errorf("Expected a move the game has moved on from to be dropped")
+	}
+	if len(game.Board.Settlements) != 1 {
+		t.Errorf("Expected only the settlement played for the bot, got %d", len(game.Board.Settlements))
+	}
+}
+
+func TestHeuristicBotsPlayAGame(t *testing.T) {
+	for _, level := range []Difficulty{Easy, Normal, Hard} {
+		game := &Game{}
+		game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+		for player := range game.Players {
+			game.SetBot(player, NewHeuristicBot(level))
+		}
+
+		for i := 0; i < 200 && !game.isOver(); i++ {
+			game.PlayBots()
+		}
+		if !game.isOver() {
+			t.Errorf("Expected %s bots to finish the game, got %T with %d, %d and %d points", level, game.phase,
+				game.Players[0].VictoryPoints(game), game.Players[1].VictoryPoints(game), game.Players[2].VictoryPoints(game))
+		}
+	}
+}
+
+func TestHeuristicBotRobsTheLeader(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	view := BotView{game: game, Me: 0}
+	// p3 leads with two settlements to p2's one
+	for player, coords := range map[int][][2]int{1: {{2, 4}}, 2: {{0, 2}, {4, 6}}} {
+		for _, xy := range coords {
+			at, _ := board.NewCrossCoord(xy[0], xy[1])
+			game.Board.SetSettlement(at, player)
+		}
+	}
+
+	bot := NewHeuristicBot(Normal)
+	if victim := bot.Steal(view, []int{1, 2}); victim != 2 {
+		t.Errorf("Expected to steal from the leader p3, got p%d", victim+1)
+	}
+	if around := game.Board.PlayersAround(bot.Robber(view)); !slices.Contains(around, 2) {
+		t.Errorf("Expected the robber next to the leader p3, got %v", around)
+	}
+}
+
+func TestMCTSBotTakesTheWin(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	// p1 has 9 points and can afford a city, which wins
+	for _, xy := range [][2]int{{0, 2}, {2, 4}, {4, 6}, {1, 8}, {3, 2}} {
+		at, _ := board.NewCrossCoord(xy[0], xy[1])
+		game.Board.SetSettlement(at, 0)
+	}
+	for _, at := range game.citySpots(0)[:4] {
+		game.Board.UpgradeToCity(at, 0)
+	}
+	game.Players[0].Resources[board.ResourceWheat] = 2
+	game.Players[0].Resources[board.ResourceOre] = 3
+	game.phase = PhaseIdle(game)
+
+	bot := &MCTSBot{HeuristicBot: NewHeuristicBot(Hard), Playouts: 100}
+	game.SetBot(0, bot)
+	view := BotView{game: game, Me: 0}
+	action := bot.Act(view, game.LegalActions(0))
+	if _, ok := action.(BuildCity); !ok {
+		t.Fatalf("Expected the bot to build the winning city, got %#v", action)
+	}
+	if len(game.citySpots(0)) != 1 || game.Players[0].Resources[board.ResourceOre] != 3 {
+		t.Errorf("Expected the search to leave the game alone")
+	}
+}
+
+func TestMCTSBotPlaysATurn(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.SetBot(0, &MCTSBot{HeuristicBot: NewHeuristicBot(Hard), Playouts: 20})
+	game.SetBot(1, NewSimpleBot())
+	game.SetBot(2, NewSimpleBot())
+
+	for i := 0; i < 10 && game.LastDice == [2]int{}; i++ {
+		game.PlayBots()
+	}
+	if len(game.Board.Settlements) < 6 || game.LastDice == [2]int{} {
+		t.Errorf("Expected the bots to place their settlements and roll, got %d settlements", len(game.Board.Settlements))
+	}
+}
+
+func TestMCTSBotKeepsToItsBudget(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.phase = PhasePlaceRobber(game, PhaseIdle(game))
+
+	bot := NewMCTSBot(50 * time.Millisecond)
+	start := time.Now()
+	action := bot.Act(BotView{game: game, Me: 0}, game.LegalActions(0))
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the bot to think for about 50ms, took %v", elapsed)
+	}
+	if err := game.Apply(0, action); err != nil {
+		t.Errorf("Expected a legal action, got %#v: %v", action, err)
+	}
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"el_poblador/i18n"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ChatEveryone is the recipient of public chat messages
+const ChatEveryone = -1
+
+// ChatMessage is a line of chat between players. Messages are public unless
+// To is a player id, in which case only the sender and the recipient see it.
+type ChatMessage struct {
+	From int
+	To   int
+	Text string
+	Time time.Time
+}
+
+// SendChat posts a message from the player. Messages starting with
+// "/w <name> " are whispered to the named player.
+func (g *Game) SendChat(from int, text string) error {
+	if from < 0 || from >= len(g.Players) {
+		return i18n.Errorf("chat.err_spectator")
+	}
+	text = strings.TrimSpace(text)
+	to := ChatEveryone
+	if rest, ok := strings.CutPrefix(text, "/w "); ok {
+		name, message, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		to = g.playerByName(name)
+		if to < 0 {
+			return i18n.Errorf("chat.err_no_player", name)
+		}
+		if to == from {
+			return i18n.Errorf("chat.err_yourself")
+		}
+		text = strings.TrimSpace(message)
+	}
+	if text == "" {
+		return i18n.Errorf("chat.err_empty")
+	}
+	g.Chat = append(g.Chat, ChatMessage{From: from, To: to, Text: text, Time: time.Now()})
+	return nil
+}
+
+// ChatFor returns the messages the viewer is allowed to see, oldest first.
+// Spectators (viewer -1) only see public messages.
+func (g *Game) ChatFor(viewer int) []ChatMessage {
+	var visible []ChatMessage
+	for _, message := range g.Chat {
+		if message.To == ChatEveryone || message.To == viewer || message.From == viewer {
+			visible = append(visible, message)
+		}
+	}
+	return visible
+}
+
+// playerByName finds a player by case-insensitive name, or returns -1
+func (g *Game) playerByName(name string) int {
+	for i, player := range g.Players {
+		if strings.EqualFold(player.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Game) renderChatMessage(message ChatMessage) string {
+	from := &g.Players[message.From]
+	line := fmt.Sprintf("%s %s: %s", message.Time.Format("15:04"), from.RenderName(), message.Text)
+	if message.To != ChatEveryone {
+		to := &g.Players[message.To]
+		whisper := lipgloss.NewStyle().Italic(true)
+		line = whisper.Render(fmt.Sprintf("%s %s → %s: ", message.Time.Format("15:04"), from.RenderName(), to.RenderName())) +
+			whisper.Render(message.Text)
+	}
+	return line
+}
+
+// buildChat renders the inner chat pane for the viewer, with the input line at
+// the bottom when they are typing.
+func (g *Game) buildChat(v Viewport, viewer int, width, height int) string {
+	var input string
+	if v.ChatFocused {
+		input = "> " + v.ChatDraft + "█"
+	} else if v.ChatError != "" {
+		input = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(v.ChatError)
+	} else {
+		input = lipgloss.NewStyle().Faint(true).Render(v.Locale.T("chat.placeholder"))
+	}
+	var rendered []string
+	for _, message := range g.ChatFor(viewer) {
+		rendered = append(rendered, g.renderChatMessage(message))
+	}
+	// keep the newest lines that fit above the input line, after wrapping
+	wrapped := lipgloss.NewStyle().Width(width).Render(strings.Join(rendered, "\n"))
+	lines := strings.Split(wrapped, "\n")
+	if len(rendered) == 0 {
+		lines = nil
+	}
+	room := max(height-1, 0)
+	if len(lines) > room {
+		lines = lines[len(lines)-room:]
+	}
+	for len(lines) < room {
+		lines = append([]string{""}, lines...)
+	}
+	lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(input))
+	return strings.Join(lines, "\n")
+}
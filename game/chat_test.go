@@ -0,0 +1,75 @@
+package game
+
+import (
+	"bytes"
+	"encoding/gob"
+	"strings"
+	"testing"
+)
+
+func TestChatWhispersArePrivate(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "Ana"}, {Name: "Ben"}, {Name: "Cat"}})
+
+	if err := game.SendChat(0, "anyone has brick?"); err != nil {
+		t.Fatalf("SendChat failed: %v", err)
+	}
+	if err := game.SendChat(1, "/w ana I'll give you brick for wheat"); err != nil {
+		t.Fatalf("Whisper failed: %v", err)
+	}
+	if err := game.SendChat(1, "/w Dan hello"); err == nil {
+		t.Error("Whispering to an unknown player should fail")
+	}
+	if err := game.SendChat(2, "   "); err == nil {
+		t.Error("Empty messages should be rejected")
+	}
+
+	if got := len(game.ChatFor(0)); got != 2 {
+		t.Errorf("Ana should see both messages, got %d", got)
+	}
+	if got := len(game.ChatFor(1)); got != 2 {
+		t.Errorf("Ben should see both messages, got %d", got)
+	}
+	if got := len(game.ChatFor(2)); got != 1 {
+		t.Errorf("Cat should only see the public message, got %d", got)
+	}
+	if got := len(game.ChatFor(ChatEveryone)); got != 1 {
+		t.Errorf("Spectators should only see the public message, got %d", got)
+	}
+	whisper := game.ChatFor(0)[1]
+	if whisper.To != 0 || whisper.Text != "I'll give you brick for wheat" {
+		t.Errorf("Unexpected whisper: %+v", whisper)
+	}
+
+	cat := 2
+	render := game.Render(Viewport{Width: 140, Height: 45, Player: &cat})
+	if !strings.Contains(render, "anyone has brick?") {
+		t.Error("Chat pane should show the public message")
+	}
+	if strings.Contains(render, "wheat") {
+		t.Error("Chat pane should not show other players' whispers")
+	}
+}
+
+func TestChatIsSaved(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "Alice"}, {Name: "Bob"}, {Name: "Charlie"}})
+	game.SendChat(0, "good luck")
+	game.SendChat(1, "/w alice you too")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(game); err != nil {
+		t.Fatalf("Failed to encode game: %v", err)
+	}
+	var loaded Game
+	if err := gob.NewDecoder(&buf).Decode(&loaded); err != nil {
+		t.Fatalf("Failed to decode game: %v", err)
+	}
+	if len(loaded.Chat) != 2 {
+		t.Fatalf("Expected 2 chat messages after loading, got %d", len(loaded.Chat))
+	}
+	whisper := loaded.Chat[1]
+	if whisper.From != 1 || whisper.To != 0 || whisper.Text != "you too" || !whisper.Time.Equal(game.Chat[1].Time) {
+		t.Errorf("Whisper changed after loading: %+v", whisper)
+	}
+}
@@ -0,0 +1,141 @@
+package game
+
+import (
+	"el_poblador/i18n"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TimeoutAction is what happens when a player runs out of time
+type TimeoutAction int
+
+const (
+	// TimeoutAutoPlay finishes the player's turn for them: pending decisions
+	// like the robber are resolved and the turn is passed on
+	TimeoutAutoPlay TimeoutAction = iota
+	// TimeoutWarn only flags the player in the sidebar
+	TimeoutWarn
+)
+
+// TimeControl sets optional time budgets. Zero limits are unlimited.
+type TimeControl struct {
+	// TurnLimit is how long a player can take for each of their turns
+	TurnLimit time.Duration
+	// GameLimit is how much time each player has for the whole game
+	GameLimit time.Duration
+	OnTimeout TimeoutAction
+}
+
+func (c TimeControl) Enabled() bool {
+	return c.TurnLimit > 0 || c.GameLimit > 0
+}
+
+// Clock tracks how much time the players have used. It is saved with the game.
+type Clock struct {
+	Control TimeControl
+	// Used is the total time used by each player
+	Used []time.Duration
+	// Turn is the time used so far by ClockPlayer in their current turn
+	Turn        time.Duration
+	ClockPlayer int
+	// Decisions is how long the game has waited on the other players'
+	// decisions, like discards, in ClockPlayer's turn. TurnLimit applies to
+	// it too.
+	Decisions time.Duration
+}
+
+// SetTimeControl enables time budgets for the game.
+func (g *Game) SetTimeControl(control TimeControl) {
+	g.Clock = Clock{
+		Control:     control,
+		Used:        make([]time.Duration, len(g.Players)),
+		ClockPlayer: g.PlayerTurn,
+	}
+}
+
+// Tick charges the elapsed time to the players the game is waiting on and
+// applies the timeout action to those who ran out of time. Interfaces call it
+// regularly while the game is on screen.
+func (g *Game) Tick(elapsed time.Duration) {
+	if !g.Clock.Control.Enabled() || g.isOver() {
+		return
+	}
+	if g.Clock.ClockPlayer != g.PlayerTurn {
+		g.Clock.ClockPlayer = g.PlayerTurn
+		g.Clock.Turn = 0
+		g.Clock.Decisions = 0
+	}
+	waiting := g.WaitingOn()
+	wasOut := make([]bool, len(waiting))
+	for i, player := range waiting {
+		wasOut[i] = g.outOfTime(player)
+	}
+	if slices.Contains(waiting, g.PlayerTurn) {
+		g.Clock.Turn += elapsed
+	}
+	if slices.ContainsFunc(waiting, func(player int) bool { return player != g.PlayerTurn }) {
+		g.Clock.Decisions += elapsed
+	}
+	for i, player := range waiting {
+		g.Clock.Used[player] += elapsed
+		if !g.outOfTime(player) {
+			continue
+		}
+		if !wasOut[i] {
+			g.LogAction("log.out_of_time", playerName(player))
+		}
+		if g.Clock.Control.OnTimeout == TimeoutAutoPlay {
+			g.AutoPlayFor(player)
+		}
+	}
+}
+
+func (g *Game) outOfTime(player int) bool {
+	control := g.Clock.Control
+	if control.TurnLimit > 0 && player == g.Clock.ClockPlayer && g.Clock.Turn >= control.TurnLimit {
+		return true
+	}
+	if control.TurnLimit > 0 && player != g.Clock.ClockPlayer && g.decisionFor(player) != nil && g.Clock.Decisions >= control.TurnLimit {
+		return true
+	}
+	return control.GameLimit > 0 && g.Clock.Used[player] >= control.GameLimit
+}
+
+func (g *Game) isOver() bool {
+	_, over := g.phase.(*phaseGameEnd)
+	return over || g.shouldQuit
+}
+
+// clockText describes the clock for the sidebar, empty without time control
+func (g *Game) clockText(l i18n.Locale, playerPerspective int) string {
+	control := g.Clock.Control
+	if !control.Enabled() {
+		return ""
+	}
+	var lines []string
+	if control.TurnLimit > 0 {
+		turn := control.TurnLimit
+		if g.Clock.ClockPlayer == g.PlayerTurn {
+			turn -= g.Clock.Turn
+		}
+		lines = append(lines, l.T("clock.turn", formatClock(turn)))
+	}
+	if control.GameLimit > 0 {
+		lines = append(lines, l.T("clock.yours", formatClock(control.GameLimit-g.Clock.Used[playerPerspective])))
+	}
+	if g.outOfTime(g.PlayerTurn) {
+		lines = append(lines, l.T("clock.out", g.Players[g.PlayerTurn].RenderName()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatClock shows a duration as m:ss, never negative
+func formatClock(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	seconds := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"bytes"
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"encoding/gob"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClockAutoPlaysOnTurnTimeout(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.SetTimeControl(TimeControl{TurnLimit: time.Minute})
+
+	game.Tick(59 * time.Second)
+	if game.PlayerTurn != 0 {
+		t.Fatalf("Expected p1 to still have time, turn is %d", game.PlayerTurn)
+	}
+	game.Tick(time.Second)
+	if len(game.Board.Settlements) != 1 || len(game.Board.Roads) != 1 {
+		t.Fatalf("Expected the timeout to place p1's settlement and road, got %d and %d", len(game.Board.Settlements), len(game.Board.Roads))
+	}
+	if game.PlayerTurn != 1 {
+		t.Fatalf("Expected the turn to pass to p2, got %d", game.PlayerTurn)
+	}
+	if game.Clock.Used[0] != time.Minute {
+		t.Errorf("Expected p1 to have used a minute, got %v", game.Clock.Used[0])
+	}
+
+	// the next player starts with a fresh turn clock
+	game.Tick(30 * time.Second)
+	if game.PlayerTurn != 1 || game.Clock.Turn != 30*time.Second {
+		t.Errorf("Expected p2 to have 30s on the clock, got turn %d with %v", game.PlayerTurn, game.Clock.Turn)
+	}
+}
+
+func TestClockAutoPlaysPendingDiscards(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.SetTimeControl(TimeControl{TurnLimit: time.Minute})
+	game.Players[1].Resources[board.ResourceWood] = 10
+	game.phase = sevenRolled(game)
+
+	game.Tick(59 * time.Second)
+	if waiting := game.WaitingOn(); len(waiting) != 1 || waiting[0] != 1 {
+		t.Fatalf("Expected the game to wait on p2's discard, got %v", waiting)
+	}
+	game.Tick(time.Second)
+	if got := game.Players[1].Resources[board.ResourceWood]; got != 5 {
+		t.Errorf("Expected the timeout to discard half of p2's cards, left %d", got)
+	}
+	if _, ok := game.phase.(*phasePlaceRobber); !ok {
+		t.Errorf("Expected p1 to move the robber next, got %T", game.phase)
+	}
+	if game.Clock.Used[0] != 0 || game.Clock.Used[1] != time.Minute {
+		t.Errorf("Expected the wait charged to p2 only, got %v", game.Clock.Used)
+	}
+}
+
+func TestClockWarnOnly(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.SetTimeControl(TimeControl{GameLimit: time.Minute, OnTimeout: TimeoutWarn})
+
+	game.Tick(2 * time.Minute)
+	if game.PlayerTurn != 0 || len(game.Board.Settlements) != 0 {
+		t.Fatalf("Expected no move to be made for p1")
+	}
+	if !game.outOfTime(0) {
+		t.Errorf("Expected p1 to be out of time")
+	}
+	if got := game.clockText(i18n.English, 0); !strings.Contains(got, "out of time") {
+		t.Errorf("Expected the sidebar to flag p1, got %q", got)
+	}
+}
+
+func TestClockIsSaved(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.SetTimeControl(TimeControl{TurnLimit: time.Minute, GameLimit: 10 * time.Minute})
+	game.Tick(20 * time.Second)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(game); err != nil {
+		t.Fatalf("Failed to encode game: %v", err)
+	}
+	var loaded Game
+	if err := gob.NewDecoder(&buf).Decode(&loaded); err != nil {
+		t.Fatalf("Failed to decode game: %v", err)
+	}
+	if loaded.Clock.Control != game.Clock.Control || loaded.Clock.Turn != 20*time.Second || loaded.Clock.Used[0] != 20*time.Second {
+		t.Errorf("Clock not restored: %+v", loaded.Clock)
+	}
+}
@@ -0,0 +1,259 @@
+package game
+
+import (
+	"el_poblador/board"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Clone returns a deep copy of the game, pending phase, decisions and random
+// numbers included, so the copy plays out exactly like the original would.
+// Bots are shared with the original. What is only there to be shown is left
+// out: the win chances and the counters that keep them up to date, and the
+// hint on screen.
+func (g *Game) Clone() *Game {
+	g.random() // seed now so both games roll the same dice
+	rng := *g.rng
+	clone := &Game{
+		LastDice:    g.LastDice,
+		PlayerTurn:  g.PlayerTurn,
+		TurnsPlayed: g.TurnsPlayed,
+		DevCardDeck: slices.Clone(g.DevCardDeck),
+		Log:         slices.Clone(g.Log),
+		Chat:        slices.Clone(g.Chat),
+		Clock:       g.Clock,
+		bots:        maps.Clone(g.bots),
+		rng:         &rng,
+		botMoves:    g.botMoves,
+		shouldQuit:  g.shouldQuit,
+		noSaving:    g.noSaving,
+	}
+	clone.Clock.Used = slices.Clone(g.Clock.Used)
+	if g.Board != nil {
+		clone.Board = g.Board.Clone()
+	}
+	clone.Players = make([]Player, len(g.Players))
+	for i, player := range g.Players {
+		clone.Players[i] = player.clone()
+	}
+
+	c := &cloner{from: g, to: clone, phases: make(map[Phase]Phase)}
+	clone.phase = c.phase(g.phase)
+	if g.decisions != nil {
+		clone.decisions = make([][]Decision, len(g.decisions))
+		for player, decisions := range g.decisions {
+			for _, d := range decisions {
+				clone.decisions[player] = append(clone.decisions[player], c.decision(d))
+			}
+		}
+	}
+	return clone
+}
+
+// DeterminizedClone returns a clone as the observer could imagine it: the
+// cards they can't see, the deck and the other players' unplayed development
+// cards and resources, are dealt again from the seed, which also rolls the
+// dice from then on. Every hand keeps its size, and the other players' hands
+// together the resources they held, which the log gives away anyway.
+func (g *Game) DeterminizedClone(observer int, seed uint64) *Game {
+	clone := g.Clone()
+	clone.Seed(seed)
+	unseen := slices.Clone(clone.DevCardDeck)
+	for i := range clone.Players {
+		if i != observer {
+			unseen = append(unseen, clone.Players[i].HiddenDevCards...)
+		}
+	}
+	clone.random().Shuffle(len(unseen), func(i, j int) {
+		unseen[i], unseen[j] = unseen[j], unseen[i]
+	})
+	for i := range clone.Players {
+		if i == observer {
+			continue
+		}
+		hand := &clone.Players[i]
+		n := len(hand.HiddenDevCards)
+		hand.HiddenDevCards, unseen = slices.Clone(unseen[:n]), unseen[n:]
+	}
+	clone.DevCardDeck = unseen
+
+	var resources []board.ResourceType
+	for i := range clone.Players {
+		if i == observer {
+			continue
+		}
+		for _, resource := range board.RESOURCE_TYPES {
+			for range clone.Players[i].Resources[resource] {
+				resources = append(resources, resource)
+			}
+		}
+	}
+	clone.random().Shuffle(len(resources), func(i, j int) {
+		resources[i], resources[j] = resources[j], resources[i]
+	})
+	for i := range clone.Players {
+		if i == observer {
+			continue
+		}
+		hand := clone.Players[i].Resources
+		n := 0
+		for resource, count := range hand {
+			n += count
+			hand[resource] = 0
+		}
+		for _, resource := range resources[:n] {
+			hand[resource]++
+		}
+		resources = resources[n:]
+	}
+	return clone
+}
+
+func (p Player) clone() Player {
+	p.Resources = maps.Clone(p.Resources)
+	p.HiddenDevCards = slices.Clone(p.HiddenDevCards)
+	p.PlayedDevCards = slices.Clone(p.PlayedDevCards)
+	return p
+}
+
+// cloner copies phases onto another game, keeping phases that are shared
+// between continuations shared in the copy
+type cloner struct {
+	from   *Game
+	to     *Game
+	phases map[Phase]Phase
+}
+
+func (c *cloner) options(p phaseWithOptions) phaseWithOptions {
+	p.game = c.to
+	p.options = slices.Clone(p.options)
+	p.disabled = slices.Clone(p.disabled)
+	return p
+}
+
+func (c *cloner) phase(phase Phase) Phase {
+	if phase == nil {
+		return nil
+	}
+	if clone, ok := c.phases[phase]; ok {
+		return clone
+	}
+	var clone Phase
+	switch p := phase.(type) {
+	case *phaseInitialSettlements:
+		q := *p
+		q.game = c.to
+		clone = &q
+	case *phaseInitialRoad:
+		q := *p
+		q.game = c.to
+		clone = &q
+	case *phaseDiceRoll:
+		q := *p
+		q.phaseWithOptions = c.options(p.phaseWithOptions)
+		clone = &q
+	case *phaseIdle:
+		q := *p
+		q.phaseWithOptions = c.options(p.phaseWithOptions)
+		clone = &q
+	case *phaseBuilding:
+		q := *p
+		q.phaseWithOptions = c.options(p.phaseWithOptions)
+		q.previousPhase = c.phase(p.previousPhase)
+		clone = &q
+	case *phaseSettlementPlacement:
+		q := *p
+		q.game = c.to
+		q.previousPhase = c.phase(p.previousPhase)
+		clone = &q
+	case *phaseCityPlacement:
+		q := *p
+		q.game = c.to
+		q.previousPhase = c.phase(p.previousPhase)
+		clone = &q
+	case *phaseRoadStart:
+		q := *p
+		q.game = c.to
+		q.previousPhase = c.phase(p.previousPhase)
+		q.continuation = c.phase(p.continuation)
+		clone = &q
+	case *phaseRoadEnd:
+		q := *p
+		q.game = c.to
+		q.previousPhase = c.phase(p.previousPhase)
+		q.continuation = c.phase(p.continuation)
+		clone = &q
+	case *phasePlayDevelopmentCard:
+		q := *p
+		q.phaseWithOptions = c.options(p.phaseWithOptions)
+		q.previousPhase = c.phase(p.previousPhase)
+		clone = &q
+	case *phaseMonopoly:
+		q := *p
+		q.phaseWithOptions = c.options(p.phaseWithOptions)
+		q.previousPhase = c.phase(p.previousPhase)
+		clone = &q
+	case *phaseYearOfPlenty:
+		q := *p
+		q.phaseWithOptions = c.options(p.phaseWithOptions)
+		q.previousPhase = c.phase(p.previousPhase)
+		clone = &q
+	case *phasePlaceRobber:
+		q := *p
+		q.game = c.to
+		q.continuation = c.phase(p.continuation)
+		clone = &q
+	case *phaseStealCard:
+		q := *p
+		q.game = c.to
+		q.continuation = c.phase(p.continuation)
+		q.victims = slices.Clone(p.victims)
+		// the victims share their hands with the game's players
+		q.stealablePlayers = make([]Player, len(p.victims))
+		for i, victim := range p.victims {
+			q.stealablePlayers[i] = c.to.Players[victim]
+		}
+		clone = &q
+	case *phaseTradeOffer:
+		q := *p
+		q.game = c.to
+		q.offer = maps.Clone(p.offer)
+		clone = &q
+	case *phaseTradeSelectReceive:
+		q := *p
+		q.game = c.to
+		q.offer = maps.Clone(p.offer)
+		q.request = maps.Clone(p.request)
+		q.previousPhase = c.phase(p.previousPhase)
+		clone = &q
+	case *phaseAwaitDecisions:
+		q := *p
+		q.game = c.to
+		q.continuation = c.phase(p.continuation)
+		clone = &q
+	case *phaseGameEnd:
+		q := *p
+		q.game = c.to
+		if i := c.from.getPlayerID(p.winner); i >= 0 {
+			q.winner = &c.to.Players[i]
+		}
+		clone = &q
+	default:
+		panic(fmt.Sprintf("can't clone phase %T", phase))
+	}
+	c.phases[phase] = clone
+	return clone
+}
+
+func (c *cloner) decision(decision Decision) Decision {
+	switch d := decision.(type) {
+	case *discardDecision:
+		q := *d
+		q.game = c.to
+		q.discard = maps.Clone(d.discard)
+		return &q
+	default:
+		panic(fmt.Sprintf("can't clone decision %T", decision))
+	}
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"el_poblador/board"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// playRandomly applies the same random legal actions to every game
+func playRandomly(t *testing.T, choices *rand.Rand, steps int, games ...*Game) {
+	t.Helper()
+	for step := 0; step < steps && !games[0].isOver(); step++ {
+		waiting := games[0].WaitingOn()
+		player := waiting[choices.IntN(len(waiting))]
+		actions := games[0].LegalActions(player)
+		action := actions[choices.IntN(len(actions))]
+		for _, game := range games {
+			if err := game.Apply(player, action); err != nil {
+				t.Fatalf("Action %#v refused: %v", action, err)
+			}
+		}
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	playRandomly(t, rand.New(rand.NewPCG(1, 2)), 200, game)
+
+	clone := game.Clone()
+	settlements := len(game.Board.Settlements)
+	clone.Board.Settlements[board.CrossCoord{X: 99, Y: 99}] = 2
+	clone.Players[0].Resources[board.ResourceOre] += 10
+	clone.DevCardDeck = clone.DevCardDeck[:0]
+
+	if len(game.Board.Settlements) != settlements {
+		t.Errorf("Expected the board to be copied")
+	}
+	if game.Players[0].Resources[board.ResourceOre] == clone.Players[0].Resources[board.ResourceOre] {
+		t.Errorf("Expected the hands to be copied")
+	}
+	if len(game.DevCardDeck) == 0 {
+		t.Errorf("Expected the deck to be copied")
+	}
+}
+
+func TestClonePlaysLikeTheOriginal(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	playRandomly(t, rand.New(rand.NewPCG(3, 4)), 150, game)
+
+	clone := game.Clone()
+	if reflect.TypeOf(clone.phase) != reflect.TypeOf(game.phase) {
+		t.Fatalf("Expected the clone to be in %T, got %T", game.phase, clone.phase)
+	}
+	playRandomly(t, rand.New(rand.NewPCG(5, 6)), 300, game, clone)
+	if clone.LastDice != game.LastDice || !reflect.DeepEqual(clone.Players, game.Players) ||
+		!reflect.DeepEqual(clone.Board.Settlements, game.Board.Settlements) {
+		t.Errorf("Expected the clone to roll the same dice and end up the same")
+	}
+}
+
+func TestDeterminizedCloneKeepsWhatTheObserverSees(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.Players[0].HiddenDevCards = []DevCard{DevCardKnight}
+	game.Players[1].HiddenDevCards = []DevCard{DevCardMonopoly, DevCardVictoryPoint}
+	game.DevCardDeck = game.DevCardDeck[2:]
+	game.Players[0].Resources[board.ResourceOre] = 2
+	game.Players[1].Resources[board.ResourceWood] = 3
+	game.Players[2].Resources[board.ResourceBrick] = 1
+
+	dealt := false
+	for seed := uint64(0); seed < 10; seed++ {
+		clone := game.DeterminizedClone(0, seed)
+		if !slices.Equal(clone.Players[0].HiddenDevCards, game.Players[0].HiddenDevCards) {
+			t.Errorf("Expected the observer's cards to stay the same")
+		}
+		if clone.Players[0].Resources[board.ResourceOre] != 2 {
+			t.Errorf("Expected the observer's resources to stay the same")
+		}
+		if clone.Players[1].TotalResources() != 3 || clone.Players[2].TotalResources() != 1 ||
+			clone.Players[1].Resources[board.ResourceWood]+clone.Players[2].Resources[board.ResourceWood] != 3 {
+			t.Errorf("Expected the unseen resources dealt again in the same amounts, got %v and %v",
+				clone.Players[1].Resources, clone.Players[2].Resources)
+		}
+		dealt = dealt || clone.Players[2].Resources[board.ResourceWood] == 1
+		if len(clone.Players[1].HiddenDevCards) != 2 || len(clone.DevCardDeck) != len(game.DevCardDeck) {
+			t.Errorf("Expected the unseen cards to be dealt in the same amounts")
+		}
+		unseen := append(slices.Clone(clone.DevCardDeck), clone.Players[1].HiddenDevCards...)
+		want := append(slices.Clone(game.DevCardDeck), game.Players[1].HiddenDevCards...)
+		slices.Sort(unseen)
+		slices.Sort(want)
+		if !slices.Equal(unseen, want) {
+			t.Errorf("Expected the unseen cards to be the same cards")
+		}
+	}
+	if !dealt {
+		t.Errorf("Expected the resources to change hands in some dealing")
+	}
+	if !slices.Equal(game.Players[1].HiddenDevCards, []DevCard{DevCardMonopoly, DevCardVictoryPoint}) ||
+		game.Players[1].Resources[board.ResourceWood] != 3 {
+		t.Errorf("Expected the original to be left alone")
+	}
+}
@@ -0,0 +1,416 @@
+package game
+
+import (
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Commands are typed lines that carry out actions, for players who would
+// rather type than move the cursor, and for scripted games:
+//
+//	settle 2,4             road 2,4 3,4         city 2,4
+//	buy                    roll                 end
+//	trade 4 wood for 1 ore
+//	knight 1,2 steal Bob   robber 1,2 steal Bob steal Bob
+//	monopoly ore           plenty wood ore      roadbuilding
+//	pick ore               discard 2 wood 1 ore
+//
+// Crossings and tiles are given as x,y, see board/coordinates.md. Several
+// commands can be chained with ";". A leading ":" is allowed.
+var commandNames = []string{
+	"buy", "city", "discard", "end", "knight", "monopoly", "pick", "plenty",
+	"road", "roadbuilding", "robber", "roll", "settle", "steal", "trade",
+}
+
+var commandAliases = map[string]string{
+	"settlement": "settle", "dev": "buy", "endturn": "end", "yearofplenty": "plenty",
+}
+
+var resourceWords = map[string]board.ResourceType{
+	"ore": board.ResourceOre, "wood": board.ResourceWood, "lumber": board.ResourceWood,
+	"wool": board.ResourceSheep, "sheep": board.ResourceSheep, "wheat": board.ResourceWheat,
+	"grain": board.ResourceWheat, "brick": board.ResourceBrick, "clay": board.ResourceBrick,
+}
+
+// RunCommand carries out a command line for the player, stopping at the first
+// command that fails
+func (g *Game) RunCommand(requestPlayer *int, line string) error {
+	player := g.playerPerspective(requestPlayer)
+	for _, command := range strings.Split(line, ";") {
+		actions, err := g.parseCommand(player, command)
+		if err != nil {
+			return err
+		}
+		for _, action := range actions {
+			if err := g.Apply(player, action); err != nil {
+				return i18n.Errorf("command.failed", strings.TrimSpace(command), err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseCommand turns a command into the actions it stands for
+func (g *Game) parseCommand(player int, command string) ([]Action, error) {
+	words := strings.Fields(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(command), ":")))
+	if len(words) == 0 {
+		return nil, i18n.Errorf("command.empty")
+	}
+	name, args := words[0], words[1:]
+	if alias, ok := commandAliases[name]; ok {
+		name = alias
+	}
+	need := func(n int, usage string) error {
+		if len(args) != n {
+			return i18n.Errorf("command.usage", usage)
+		}
+		return nil
+	}
+	switch name {
+	case "settle":
+		if err := need(1, "settle x,y"); err != nil {
+			return nil, err
+		}
+		at, err := parseCross(args[0])
+		return []Action{BuildSettlement{At: at}}, err
+	case "city":
+		if err := need(1, "city x,y"); err != nil {
+			return nil, err
+		}
+		at, err := parseCross(args[0])
+		return []Action{BuildCity{At: at}}, err
+	case "road":
+		if err := need(2, "road x,y x,y"); err != nil {
+			return nil, err
+		}
+		from, err := parseCross(args[0])
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseCross(args[1])
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(from.Neighbors(), to) {
+			return nil, i18n.Errorf("command.not_next", args[0], args[1])
+		}
+		// the ends can be given either way round
+		road := board.PathCoord{From: from, To: to}
+		if reversed := (board.PathCoord{From: to, To: from}); !g.isLegal(player, BuildRoad{Road: road}) &&
+			g.isLegal(player, BuildRoad{Road: reversed}) {
+			road = reversed
+		}
+		return []Action{BuildRoad{Road: road}}, nil
+	case "buy":
+		return []Action{BuyDevCard{}}, need(0, "buy")
+	case "roll":
+		return []Action{RollDice{}}, need(0, "roll")
+	case "end":
+		return []Action{EndTurn{}}, need(0, "end")
+	case "roadbuilding":
+		return []Action{PlayDevCard{Card: DevCardRoadBuilding}}, need(0, "roadbuilding")
+	case "knight", "robber":
+		usage := name + " x,y [steal name]"
+		if len(args) != 1 && len(args) != 3 {
+			return nil, i18n.Errorf("command.usage", usage)
+		}
+		to, err := parseTile(args[0])
+		if err != nil {
+			return nil, err
+		}
+		var actions []Action
+		if name == "knight" {
+			actions = append(actions, PlayDevCard{Card: DevCardKnight})
+		}
+		actions = append(actions, MoveRobber{To: to})
+		if len(args) == 3 {
+			if args[1] != "steal" {
+				return nil, i18n.Errorf("command.usage", usage)
+			}
+			victim, err := g.parsePlayer(args[2])
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, Steal{From: victim})
+		}
+		return actions, nil
+	case "steal":
+		if err := need(1, "steal name"); err != nil {
+			return nil, err
+		}
+		victim, err := g.parsePlayer(args[0])
+		return []Action{Steal{From: victim}}, err
+	case "monopoly", "pick":
+		if err := need(1, name+" resource"); err != nil {
+			return nil, err
+		}
+		resource, err := parseResource(args[0])
+		if err != nil {
+			return nil, err
+		}
+		var actions []Action
+		if name == "monopoly" {
+			actions = append(actions, PlayDevCard{Card: DevCardMonopoly})
+		}
+		return append(actions, PickResource{Resource: resource}), nil
+	case "plenty":
+		if err := need(2, "plenty resource resource"); err != nil {
+			return nil, err
+		}
+		actions := []Action{PlayDevCard{Card: DevCardYearOfPlenty}}
+		for _, arg := range args {
+			resource, err := parseResource(arg)
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, PickResource{Resource: resource})
+		}
+		return actions, nil
+	case "discard":
+		resources, err := parseAmounts(args)
+		if err != nil {
+			return nil, i18n.Errorf("command.usage_because", "discard 2 wood 1 ore", err)
+		}
+		return []Action{Discard{Resources: resources}}, nil
+	case "trade":
+		i := slices.Index(args, "for")
+		if i < 0 {
+			return nil, i18n.Errorf("command.usage", "trade 4 wood for 1 ore")
+		}
+		offer, err := parseAmounts(args[:i])
+		if err != nil {
+			return nil, i18n.Errorf("command.usage_because", "trade 4 wood for 1 ore", err)
+		}
+		request, err := parseAmounts(args[i+1:])
+		if err != nil {
+			return nil, i18n.Errorf("command.usage_because", "trade 4 wood for 1 ore", err)
+		}
+		return []Action{Trade{Offer: offer, Request: request}}, nil
+	}
+	return nil, i18n.Errorf("command.unknown", name, strings.Join(commandNames, ", "))
+}
+
+func (g *Game) isLegal(player int, action Action) bool {
+	return slices.ContainsFunc(g.LegalActions(player), func(a Action) bool {
+		return actionKey(a) == actionKey(action)
+	})
+}
+
+func parseCoords(s string) (int, int, error) {
+	xs, ys, ok := strings.Cut(s, ",")
+	x, errX := strconv.Atoi(xs)
+	y, errY := strconv.Atoi(ys)
+	if !ok || errX != nil || errY != nil {
+		return 0, 0, i18n.Errorf("command.not_a_place", s)
+	}
+	return x, y, nil
+}
+
+func parseCross(s string) (board.CrossCoord, error) {
+	x, y, err := parseCoords(s)
+	if err != nil {
+		return board.CrossCoord{}, err
+	}
+	cross, ok := board.NewCrossCoord(x, y)
+	if !ok {
+		return cross, i18n.Errorf("command.no_crossing", s)
+	}
+	return cross, nil
+}
+
+func parseTile(s string) (board.TileCoord, error) {
+	x, y, err := parseCoords(s)
+	if err != nil {
+		return board.TileCoord{}, err
+	}
+	tile, ok := board.NewTileCoord(x, y)
+	if !ok {
+		return tile, i18n.Errorf("command.no_tile", s)
+	}
+	return tile, nil
+}
+
+func parseResource(s string) (board.ResourceType, error) {
+	resource, ok := resourceWords[strings.TrimSuffix(s, "s")]
+	if !ok {
+		resource, ok = resourceWords[s]
+	}
+	if !ok {
+		return resource, i18n.Errorf("command.unknown_resource", s)
+	}
+	return resource, nil
+}
+
+// parseAmounts reads "2 wood 1 ore", "and" between them is allowed
+func parseAmounts(words []string) (map[board.ResourceType]int, error) {
+	amounts := make(map[board.ResourceType]int)
+	words = slices.DeleteFunc(slices.Clone(words), func(w string) bool { return w == "and" })
+	if len(words) == 0 || len(words)%2 != 0 {
+		return nil, i18n.Errorf("command.amounts")
+	}
+	for i := 0; i < len(words); i += 2 {
+		n, err := strconv.Atoi(words[i])
+		if err != nil || n <= 0 {
+			return nil, i18n.Errorf("command.not_an_amount", words[i])
+		}
+		resource, err := parseResource(words[i+1])
+		if err != nil {
+			return nil, err
+		}
+		amounts[resource] += n
+	}
+	return amounts, nil
+}
+
+// parsePlayer finds a player by the start of their name
+func (g *Game) parsePlayer(name string) (int, error) {
+	found := -1
+	for i, player := range g.Players {
+		if strings.HasPrefix(strings.ToLower(player.Name), name) {
+			if found >= 0 {
+				return 0, i18n.Errorf("command.ambiguous_player", name, g.Players[found].Name, player.Name)
+			}
+			found = i
+		}
+	}
+	if found < 0 {
+		return 0, i18n.Errorf("command.no_player", name)
+	}
+	return found, nil
+}
+
+// CompleteCommand completes the last word of a command line with what the
+// player could legally type there. It returns the line, extended as far as
+// all the choices agree, and the choices.
+func (g *Game) CompleteCommand(requestPlayer *int, line string) (string, []string) {
+	player := g.playerPerspective(requestPlayer)
+	start := strings.LastIndex(line, ";") + 1
+	words := strings.Fields(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(line[start:]), ":")))
+	if strings.HasSuffix(line, " ") || len(words) == 0 {
+		words = append(words, "")
+	}
+	last := words[len(words)-1]
+
+	var choices []string
+	for _, choice := range g.commandChoices(player, words) {
+		if strings.HasPrefix(choice, last) && !slices.Contains(choices, choice) {
+			choices = append(choices, choice)
+		}
+	}
+	if len(choices) == 0 {
+		return line, nil
+	}
+	common := choices[0]
+	for _, choice := range choices[1:] {
+		for !strings.HasPrefix(choice, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	completed := line[:len(line)-len(last)] + common
+	if len(choices) == 1 {
+		completed += " "
+	}
+	return completed, choices
+}
+
+// commandChoices lists what can follow the words before the last one
+func (g *Game) commandChoices(player int, words []string) []string {
+	if len(words) == 1 {
+		return commandNames
+	}
+	name, arg := words[0], len(words)-2
+	if alias, ok := commandAliases[name]; ok {
+		name = alias
+	}
+	legal := g.LegalActions(player)
+	var choices []string
+	switch name {
+	case "settle":
+		for _, action := range legal {
+			if a, ok := action.(BuildSettlement); ok && arg == 0 {
+				choices = append(choices, crossText(a.At))
+			}
+		}
+	case "city":
+		for _, action := range legal {
+			if a, ok := action.(BuildCity); ok && arg == 0 {
+				choices = append(choices, crossText(a.At))
+			}
+		}
+	case "road":
+		for _, action := range legal {
+			a, ok := action.(BuildRoad)
+			if !ok {
+				continue
+			}
+			if arg == 0 {
+				choices = append(choices, crossText(a.Road.From))
+			} else if arg == 1 && crossText(a.Road.From) == words[1] {
+				choices = append(choices, crossText(a.Road.To))
+			}
+		}
+	case "knight", "robber":
+		switch arg {
+		case 0:
+			for _, tile := range allTileCoords() {
+				if _, ok := g.Board.Tiles[tile]; ok && tile != g.Board.GetRobber() {
+					choices = append(choices, fmt.Sprintf("%d,%d", tile.X, tile.Y))
+				}
+			}
+		case 1:
+			choices = []string{"steal"}
+		case 2:
+			if tile, err := parseTile(words[1]); err == nil {
+				for _, victim := range slices.Compact(g.Board.PlayersAround(tile)) {
+					if victim != player && g.Players[victim].TotalResources() > 0 {
+						choices = append(choices, strings.ToLower(g.Players[victim].Name))
+					}
+				}
+			}
+		}
+	case "steal":
+		for _, action := range legal {
+			if a, ok := action.(Steal); ok && arg == 0 {
+				choices = append(choices, strings.ToLower(g.Players[a.From].Name))
+			}
+		}
+	case "monopoly", "pick", "plenty", "discard", "trade":
+		for _, resource := range board.RESOURCE_TYPES {
+			choices = append(choices, strings.ToLower(resource.String()))
+		}
+		if name == "trade" && !slices.Contains(words, "for") {
+			choices = append(choices, "for")
+		}
+	}
+	return choices
+}
+
+func crossText(cross board.CrossCoord) string {
+	return fmt.Sprintf("%d,%d", cross.X, cross.Y)
+}
+
+// commandLine is what replaces the help line while the user types a command
+// or after one failed, empty otherwise
+func (g *Game) commandLine(v Viewport) string {
+	if v.CommandFocused {
+		line := ":" + v.CommandDraft + "█"
+		if len(v.CommandOptions) > 0 {
+			line += lipgloss.NewStyle().Faint(true).Render("  " + strings.Join(v.CommandOptions, " "))
+		} else if cross, ok := g.phase.BoardCursor().(board.CrossCoord); ok {
+			line += lipgloss.NewStyle().Faint(true).Render("  " + v.Locale.T("command.cursor", crossText(cross)))
+		} else if tile, ok := g.phase.BoardCursor().(board.TileCoord); ok {
+			line += lipgloss.NewStyle().Faint(true).Render("  " + v.Locale.T("command.cursor", fmt.Sprintf("%d,%d", tile.X, tile.Y)))
+		}
+		return line
+	}
+	if v.CommandError != "" {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(v.CommandError)
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"el_poblador/board"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCommandsPlaceTheFirstSettlement(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	first := 0
+
+	// the road's ends are given the wrong way round on purpose
+	if err := game.RunCommand(&first, ":settle 2,4; road 3,4 2,4"); err != nil {
+		t.Fatalf("Failed to run the commands: %v", err)
+	}
+	at, _ := board.NewCrossCoord(2, 4)
+	to, _ := board.NewCrossCoord(3, 4)
+	if owner, ok := game.Board.Settlements[at]; !ok || owner != 0 {
+		t.Errorf("Expected p1 to settle 2,4")
+	}
+	if owner, ok := game.Board.Roads[board.NewPathCoord(at, to)]; !ok || owner != 0 {
+		t.Errorf("Expected p1 to build the road from 2,4 to 3,4")
+	}
+	if err := game.RunCommand(&first, "settle 0,2"); !errors.Is(err, ErrNotWaitingOn) {
+		t.Errorf("Expected p1 to wait for their turn, got %v", err)
+	}
+}
+
+func TestCommandsAreParsedIntoActions(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "Alice"}, {Name: "Bob"}, {Name: "Carol"}})
+	tests := []struct {
+		line    string
+		actions []Action
+	}{
+		{"city 2,4", []Action{BuildCity{At: board.CrossCoord{X: 2, Y: 4}}}},
+		{"trade 4 wood for 1 ore", []Action{Trade{
+			Offer:   map[board.ResourceType]int{board.ResourceWood: 4},
+			Request: map[board.ResourceType]int{board.ResourceOre: 1},
+		}}},
+		{"knight 1,2 steal bo", []Action{
+			PlayDevCard{Card: DevCardKnight}, MoveRobber{To: board.TileCoord{X: 1, Y: 2}}, Steal{From: 1},
+		}},
+		{"plenty sheep grain", []Action{
+			PlayDevCard{Card: DevCardYearOfPlenty}, PickResource{Resource: board.ResourceSheep}, PickResource{Resource: board.ResourceWheat},
+		}},
+		{"discard 2 bricks and 1 ore", []Action{Discard{Resources: map[board.ResourceType]int{
+			board.ResourceBrick: 2, board.ResourceOre: 1,
+		}}}},
+	}
+	for _, test := range tests {
+		actions, err := game.parseCommand(0, test.line)
+		if err != nil {
+			t.Errorf("%q: %v", test.line, err)
+			continue
+		}
+		if !slices.EqualFunc(actions, test.actions, func(a, b Action) bool { return actionKey(a) == actionKey(b) }) {
+			t.Errorf("%q: expected %v, got %v", test.line, test.actions, actions)
+		}
+	}
+
+	for _, line := range []string{"fly 1,2", "city", "city 40,40", "trade 4 wood", "steal zed", "pick gold"} {
+		if _, err := game.parseCommand(0, line); err == nil {
+			t.Errorf("Expected %q to be refused", line)
+		}
+	}
+}
+
+func TestCompleteCommand(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	first := 0
+
+	if line, options := game.CompleteCommand(&first, "ro"); line != "ro" || !slices.Equal(options, []string{"road", "roadbuilding", "robber", "roll"}) {
+		t.Errorf("Expected the commands starting with ro, got %q %v", line, options)
+	}
+	if line, _ := game.CompleteCommand(&first, "set"); line != "settle " {
+		t.Errorf("Expected settle to be completed, got %q", line)
+	}
+	_, spots := game.CompleteCommand(&first, "settle ")
+	if len(spots) != len(game.LegalActions(0)) {
+		t.Errorf("Expected every legal spot to be offered, got %d of %d", len(spots), len(game.LegalActions(0)))
+	}
+
+	if err := game.RunCommand(&first, "settle 2,4"); err != nil {
+		t.Fatal(err)
+	}
+	_, ends := game.CompleteCommand(&first, "road 2,4 ")
+	at, _ := board.NewCrossCoord(2, 4)
+	if len(ends) != len(at.Neighbors()) {
+		t.Errorf("Expected the road to lead to any neighbor of 2,4, got %v", ends)
+	}
+}
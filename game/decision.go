@@ -0,0 +1,220 @@
+package game
+
+import (
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"slices"
+	"strings"
+)
+
+// Decision is something a player settles on their own, independently of
+// whose turn it is, like discarding half their hand when a 7 is rolled. Every
+// player has a queue of pending decisions with its own cursor, and their input
+// goes to the first one before anything else.
+type Decision interface {
+	MoveCursor(direction string)
+	// Confirm reports whether the decision is settled
+	Confirm() bool
+	HelpText(l i18n.Locale) string
+	Menu(l i18n.Locale) string
+}
+
+// PhaseWaiting is implemented by phases that wait on other players than the
+// turn holder. Other phases wait on the turn holder only.
+type PhaseWaiting interface {
+	Phase
+	WaitingOn() []int
+}
+
+// WaitingOn lists the players the game needs input from, in player order:
+// those with pending decisions and those the current phase waits on.
+func (g *Game) WaitingOn() []int {
+	if g.isOver() {
+		return nil
+	}
+	var waiting []int
+	for player := range g.Players {
+		if g.decisionFor(player) != nil || g.phaseWaitsOn(player) {
+			waiting = append(waiting, player)
+		}
+	}
+	return waiting
+}
+
+func (g *Game) phaseWaitsOn(player int) bool {
+	if p, ok := g.phase.(PhaseWaiting); ok {
+		for _, waiting := range p.WaitingOn() {
+			if waiting == player {
+				return true
+			}
+		}
+		return false
+	}
+	return player == g.PlayerTurn
+}
+
+// decisionFor returns the first pending decision of the player, or nil
+func (g *Game) decisionFor(player int) Decision {
+	if player < 0 || player >= len(g.decisions) || len(g.decisions[player]) == 0 {
+		return nil
+	}
+	return g.decisions[player][0]
+}
+
+// DiscardDue returns how many cards the player has to discard, 0 unless the
+// game waits on them to
+func (g *Game) DiscardDue(player int) int {
+	if d, ok := g.decisionFor(player).(*discardDecision); ok {
+		return d.amount
+	}
+	return 0
+}
+
+func (g *Game) queueDecision(player int, d Decision) {
+	for len(g.decisions) < len(g.Players) {
+		g.decisions = append(g.decisions, nil)
+	}
+	g.decisions[player] = append(g.decisions[player], d)
+}
+
+func (g *Game) hasPendingDecisions() bool {
+	for player := range g.decisions {
+		if len(g.decisions[player]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// settleDecision drops the player's first decision, and resumes the turn once
+// nobody has anything left to decide
+func (g *Game) settleDecision(player int) {
+	g.decisions[player] = g.decisions[player][1:]
+	if p, ok := g.phase.(*phaseAwaitDecisions); ok && !g.hasPendingDecisions() {
+		g.phase = p.continuation
+	}
+}
+
+// phaseAwaitDecisions holds the turn until every pending decision is settled
+type phaseAwaitDecisions struct {
+	game         *Game
+	continuation Phase
+}
+
+func PhaseAwaitDecisions(game *Game, continuation Phase) Phase {
+	return &phaseAwaitDecisions{game: game, continuation: continuation}
+}
+
+func (p *phaseAwaitDecisions) WaitingOn() []int {
+	return nil
+}
+
+func (p *phaseAwaitDecisions) Confirm() Phase {
+	return p
+}
+
+func (p *phaseAwaitDecisions) MoveCursor(direction string) {}
+
+func (p *phaseAwaitDecisions) BoardCursor() interface{} {
+	return nil
+}
+
+func (p *phaseAwaitDecisions) HelpText(l i18n.Locale) string {
+	var names i18n.List
+	for _, player := range p.game.WaitingOn() {
+		names = append(names, p.game.Players[player].RenderName())
+	}
+	return l.T("help.waiting", names)
+}
+
+// discardDecision makes a player give up half their hand when a 7 is rolled
+type discardDecision struct {
+	game     *Game
+	player   int
+	amount   int
+	discard  map[board.ResourceType]int
+	selected int
+}
+
+func newDiscardDecision(game *Game, player int) *discardDecision {
+	return &discardDecision{
+		game:    game,
+		player:  player,
+		amount:  game.Players[player].TotalResources() / 2,
+		discard: make(map[board.ResourceType]int),
+	}
+}
+
+func (d *discardDecision) total() int {
+	total := 0
+	for _, amount := range d.discard {
+		total += amount
+	}
+	return total
+}
+
+func (d *discardDecision) MoveCursor(direction string) {
+	numResources := len(board.RESOURCE_TYPES)
+	resourceType := board.RESOURCE_TYPES[d.selected]
+	switch direction {
+	case "up":
+		d.selected = (d.selected + numResources - 1) % numResources
+	case "down":
+		d.selected = (d.selected + 1) % numResources
+	case "left":
+		if d.discard[resourceType] > 0 {
+			d.discard[resourceType]--
+		}
+	case "right":
+		player := &d.game.Players[d.player]
+		if d.discard[resourceType] < player.Resources[resourceType] && d.total() < d.amount {
+			d.discard[resourceType]++
+		}
+	}
+}
+
+func (d *discardDecision) Confirm() bool {
+	return d.apply(d.discard) == nil
+}
+
+// apply discards the cards if they are the right amount
+func (d *discardDecision) apply(discard map[board.ResourceType]int) error {
+	player := &d.game.Players[d.player]
+	total := 0
+	for resource, amount := range discard {
+		if amount < 0 || !slices.Contains(board.RESOURCE_TYPES, resource) {
+			return i18n.Errorf("err.discard_that")
+		}
+		total += amount
+	}
+	if total != d.amount {
+		return i18n.Errorf("err.discard_amount", d.amount)
+	}
+	if !player.ConsumeResources(discard) {
+		return ErrNoResources
+	}
+	d.game.LogAction("log.discard", playerName(d.player), d.amount)
+	return nil
+}
+
+func (d *discardDecision) HelpText(l i18n.Locale) string {
+	if left := d.amount - d.total(); left > 0 {
+		return l.T("help.discard_more", left)
+	}
+	return l.T("help.discard")
+}
+
+func (d *discardDecision) Menu(l i18n.Locale) string {
+	player := &d.game.Players[d.player]
+	lines := []string{l.T("menu.discard", d.amount), ""}
+	for i, resourceType := range board.RESOURCE_TYPES {
+		line := l.T("menu.amount_of", resourceType, d.discard[resourceType], player.Resources[resourceType])
+		if i == d.selected {
+			line = player.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"el_poblador/board"
+	"slices"
+	"testing"
+)
+
+func TestDiscardWaitsOnOffTurnPlayers(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.Players[1].Resources[board.ResourceOre] = 9
+
+	game.phase = sevenRolled(game)
+	if got := game.WaitingOn(); !slices.Equal(got, []int{1}) {
+		t.Fatalf("Expected to wait on p2 only, got %v", got)
+	}
+	if got := game.PerspectiveOf(nil); got != 1 {
+		t.Errorf("Expected the keyboard to go to p2, got %d", got)
+	}
+
+	// the turn holder can't move the robber yet
+	turnHolder := 0
+	game.ConfirmAction(&turnHolder)
+	if _, ok := game.phase.(*phaseAwaitDecisions); !ok {
+		t.Fatalf("Expected the turn to wait on the discard, got %T", game.phase)
+	}
+
+	discarder := 1
+	game.ConfirmAction(&discarder)
+	if game.Players[1].Resources[board.ResourceOre] != 9 {
+		t.Fatalf("Expected no discard before choosing the cards")
+	}
+	for i := 0; i < 6; i++ {
+		game.MoveCursor("right", &discarder)
+	}
+	game.ConfirmAction(&discarder)
+	if got := game.Players[1].Resources[board.ResourceOre]; got != 5 {
+		t.Errorf("Expected p2 to keep 5 ore, got %d", got)
+	}
+	if _, ok := game.phase.(*phasePlaceRobber); !ok {
+		t.Fatalf("Expected the robber to move after the discard, got %T", game.phase)
+	}
+	if got := game.WaitingOn(); !slices.Equal(got, []int{0}) {
+		t.Errorf("Expected to wait on p1 again, got %v", got)
+	}
+}
@@ -1,6 +1,9 @@
 package game
 
-import "math/rand/v2"
+import (
+	"el_poblador/i18n"
+	"math/rand/v2"
+)
 
 type DevCard string
 
@@ -13,11 +16,28 @@ const (
 )
 
 func (d DevCard) String() string {
-	// TODO: Localize
 	return string(d)
 }
 
-func shuffleDevCards() []DevCard {
+// Localize names the card in the locale
+func (d DevCard) Localize(l i18n.Locale) string {
+	switch d {
+	case DevCardKnight:
+		return l.T("card.knight")
+	case DevCardRoadBuilding:
+		return l.T("card.road_building")
+	case DevCardMonopoly:
+		return l.T("card.monopoly")
+	case DevCardYearOfPlenty:
+		return l.T("card.year_of_plenty")
+	case DevCardVictoryPoint:
+		return l.T("card.victory_point")
+	default:
+		return string(d)
+	}
+}
+
+func shuffleDevCards(rng *rand.Rand) []DevCard {
 	unshuffled := []DevCard{}
 
 	for i := 0; i < 14; i++ {
@@ -36,7 +56,7 @@ func shuffleDevCards() []DevCard {
 		unshuffled = append(unshuffled, DevCardVictoryPoint)
 	}
 
-	rand.Shuffle(len(unshuffled), func(i, j int) {
+	rng.Shuffle(len(unshuffled), func(i, j int) {
 		unshuffled[i], unshuffled[j] = unshuffled[j], unshuffled[i]
 	})
 
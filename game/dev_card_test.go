@@ -2,6 +2,7 @@ package game
 
 import (
 	"el_poblador/board"
+	"el_poblador/i18n"
 	"strings"
 	"testing"
 )
@@ -105,7 +106,7 @@ func TestKnightCardUsage(t *testing.T) {
 	// Test that the dice roll phase always shows the knight option
 	dicePhase := PhaseDiceRoll(game)
 	if phaseWithMenu, ok := dicePhase.(PhaseWithMenu); ok {
-		menu := phaseWithMenu.Menu()
+		menu := phaseWithMenu.Menu(i18n.English)
 		if !strings.Contains(menu, "Play Knight") {
 			t.Fatal("Dice roll phase should always show Play Knight option")
 		}
@@ -114,7 +115,7 @@ func TestKnightCardUsage(t *testing.T) {
 	// Test that the idle phase shows the development card option
 	idlePhase := PhaseIdle(game)
 	if phaseWithMenu, ok := idlePhase.(PhaseWithMenu); ok {
-		menu := phaseWithMenu.Menu()
+		menu := phaseWithMenu.Menu(i18n.English)
 		if !strings.Contains(menu, "Play Development Card") {
 			t.Fatal("Idle phase should show Play Development Card option")
 		}
@@ -123,7 +124,7 @@ func TestKnightCardUsage(t *testing.T) {
 	// Test that the development card phase shows knight options when available
 	devCardPhase := PhasePlayDevelopmentCard(game, idlePhase)
 	if phaseWithMenu, ok := devCardPhase.(PhaseWithMenu); ok {
-		menu := phaseWithMenu.Menu()
+		menu := phaseWithMenu.Menu(i18n.English)
 		if !strings.Contains(menu, "Knight") {
 			t.Fatal("Development card phase should show Knight option when player has knight cards")
 		}
@@ -280,7 +281,7 @@ func TestYearOfPlentyCard(t *testing.T) {
 	}
 
 	// Verify help text shows what was selected
-	helpText := yearOfPlentyPhaseImpl2.HelpText()
+	helpText := yearOfPlentyPhaseImpl2.HelpText(i18n.English)
 	if !strings.Contains(helpText, "Wheat") {
 		t.Errorf("Help text should show selected wheat, got: %s", helpText)
 	}
@@ -336,7 +337,7 @@ func TestRoadBuildingCard(t *testing.T) {
 	}
 
 	// Verify help text indicates first free road
-	helpText := roadStartPhaseImpl.HelpText()
+	helpText := roadStartPhaseImpl.HelpText(i18n.English)
 	if !strings.Contains(helpText, "first") {
 		t.Errorf("Help text should indicate first road, got: %s", helpText)
 	}
@@ -0,0 +1,409 @@
+package game
+
+import (
+	"bufio"
+	"el_poblador/board"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Engine protocol
+//
+// An engine is any program that plays a seat by reading newline-delimited JSON
+// on its stdin and answering on its stdout, much like a chess engine speaks
+// UCI. Whenever the seat has a choice the game sends an "act" message with
+// what the seat can see of the game and the legal actions, and the engine
+// answers with one of the actions, copied as it was sent. When the game is
+// done with the engine it sends "quit" and closes its stdin.
+//
+//	→ {"type":"act","state":{"you":0,...},"actions":[{"type":"roll_dice"},{"type":"play_dev_card","card":"Knight"}]}
+//	← {"type":"roll_dice"}
+//	→ {"type":"quit"}
+//
+// The state holds the board, public information about every player, and the
+// seat's own resources and development cards; it never shows the others'
+// hands. Coordinates are [x, y] pairs. An engine that answers with something
+// that isn't one of the actions has that choice made by the heuristics, and
+// lines it prints after its answer are dropped. One that crashes or takes
+// longer than its Timeout is stopped and the heuristics play the rest of the
+// game.
+
+const (
+	engineAct  = "act"
+	engineQuit = "quit"
+)
+
+type engineMessage struct {
+	Type    string         `json:"type"`
+	State   *engineState   `json:"state,omitempty"`
+	Actions []engineAction `json:"actions,omitempty"`
+}
+
+type engineState struct {
+	You          int              `json:"you"`
+	Turn         int              `json:"turn"`
+	Dice         [2]int           `json:"dice"`
+	Robber       [2]int           `json:"robber"`
+	Tiles        []engineTile     `json:"tiles"`
+	Buildings    []engineBuilding `json:"buildings"`
+	Roads        []engineRoad     `json:"roads"`
+	Players      []enginePlayer   `json:"players"`
+	DevCardsLeft int              `json:"dev_cards_left"`
+}
+
+type engineTile struct {
+	At      [2]int `json:"at"`
+	Terrain string `json:"terrain"`
+	Number  int    `json:"number"`
+}
+
+type engineBuilding struct {
+	At     [2]int `json:"at"`
+	Player int    `json:"player"`
+	City   bool   `json:"city"`
+}
+
+type engineRoad struct {
+	From   [2]int `json:"from"`
+	To     [2]int `json:"to"`
+	Player int    `json:"player"`
+}
+
+type enginePlayer struct {
+	Name           string    `json:"name"`
+	Points         int       `json:"points"` // what everyone can see
+	HandSize       int       `json:"hand_size"`
+	DevCardCount   int       `json:"dev_card_count"`
+	PlayedDevCards []DevCard `json:"played_dev_cards"`
+	// only for the engine's own seat
+	Resources map[string]int `json:"resources,omitempty"`
+	DevCards  []DevCard      `json:"dev_cards,omitempty"`
+}
+
+// engineAction is an Action as the engine sees it, with only the fields of
+// its type set
+type engineAction struct {
+	Type      string         `json:"type"`
+	At        *[2]int        `json:"at,omitempty"`
+	From      *[2]int        `json:"from,omitempty"`
+	To        *[2]int        `json:"to,omitempty"`
+	Card      DevCard        `json:"card,omitempty"`
+	Resource  string         `json:"resource,omitempty"`
+	Victim    *int           `json:"victim,omitempty"`
+	Resources map[string]int `json:"resources,omitempty"`
+	Offer     map[string]int `json:"offer,omitempty"`
+	Request   map[string]int `json:"request,omitempty"`
+}
+
+func pair(x, y int) *[2]int {
+	return &[2]int{x, y}
+}
+
+func resourceNames(resources map[board.ResourceType]int) map[string]int {
+	names := make(map[string]int)
+	for resource, count := range resources {
+		if count > 0 {
+			names[resource.String()] = count
+		}
+	}
+	return names
+}
+
+func toEngineAction(action Action) engineAction {
+	switch a := action.(type) {
+	case BuildSettlement:
+		return engineAction{Type: "build_settlement", At: pair(a.At.X, a.At.Y)}
+	case BuildRoad:
+		return engineAction{Type: "build_road",
+			From: pair(a.Road.From.X, a.Road.From.Y), To: pair(a.Road.To.X, a.Road.To.Y)}
+	case BuildCity:
+		return engineAction{Type: "build_city", At: pair(a.At.X, a.At.Y)}
+	case BuyDevCard:
+		return engineAction{Type: "buy_dev_card"}
+	case PlayDevCard:
+		return engineAction{Type: "play_dev_card", Card: a.Card}
+	case PickResource:
+		return engineAction{Type: "pick_resource", Resource: a.Resource.String()}
+	case RollDice:
+		return engineAction{Type: "roll_dice"}
+	case MoveRobber:
+		return engineAction{Type: "move_robber", At: pair(a.To.X, a.To.Y)}
+	case Steal:
+		return engineAction{Type: "steal", Victim: &a.From}
+	case Discard:
+		return engineAction{Type: "discard", Resources: resourceNames(a.Resources)}
+	case Trade:
+		return engineAction{Type: "trade", Offer: resourceNames(a.Offer), Request: resourceNames(a.Request)}
+	default:
+		return engineAction{Type: "end_turn"}
+	}
+}
+
+// engineStateFor is what the view's seat can see of the game
+func engineStateFor(view BotView) *engineState {
+	g := view.game
+	state := &engineState{
+		You:          view.Me,
+		Turn:         g.PlayerTurn,
+		Dice:         g.LastDice,
+		Robber:       [2]int{g.Board.Robber.X, g.Board.Robber.Y},
+		DevCardsLeft: len(g.DevCardDeck),
+	}
+	for coord, tile := range g.Board.Tiles {
+		state.Tiles = append(state.Tiles, engineTile{At: [2]int{coord.X, coord.Y},
+			Terrain: tile.Terrain.String(), Number: tile.DiceNumber})
+	}
+	for coord, player := range g.Board.Settlements {
+		_, city := g.Board.CityUpgrades[coord]
+		state.Buildings = append(state.Buildings, engineBuilding{At: [2]int{coord.X, coord.Y}, Player: player, City: city})
+	}
+	for path, player := range g.Board.Roads {
+		state.Roads = append(state.Roads, engineRoad{From: [2]int{path.From.X, path.From.Y},
+			To: [2]int{path.To.X, path.To.Y}, Player: player})
+	}
+	// map order is random, keep the messages stable
+	byCoord := func(a, b [2]int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	}
+	slices.SortFunc(state.Tiles, func(a, b engineTile) int { return byCoord(a.At, b.At) })
+	slices.SortFunc(state.Buildings, func(a, b engineBuilding) int { return byCoord(a.At, b.At) })
+	slices.SortFunc(state.Roads, func(a, b engineRoad) int {
+		if c := byCoord(a.From, b.From); c != 0 {
+			return c
+		}
+		return byCoord(a.To, b.To)
+	})
+	for i := range g.Players {
+		player := &g.Players[i]
+		info := enginePlayer{
+			Name:           player.Name,
+			Points:         view.PublicVictoryPoints(i),
+			HandSize:       player.TotalResources(),
+			DevCardCount:   len(player.HiddenDevCards),
+			PlayedDevCards: slices.Clone(player.PlayedDevCards),
+		}
+		if i == view.Me {
+			info.Resources = resourceNames(player.Resources)
+			info.DevCards = slices.Clone(player.HiddenDevCards)
+		}
+		state.Players = append(state.Players, info)
+	}
+	return state
+}
+
+// EngineBot plays a seat with an external program speaking the engine
+// protocol above. It answers the questions of Bot, and any choice the engine
+// doesn't make, like a Normal HeuristicBot.
+type EngineBot struct {
+	*HeuristicBot
+	// Timeout is how long the engine may take to answer, 0 for no limit
+	Timeout time.Duration
+	// QuitTimeout is how long Close waits for the engine to quit before
+	// stopping it
+	QuitTimeout time.Duration
+
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	replies chan []byte
+	done    chan struct{} // closed once the engine is stopped
+
+	// guards err, which Close may set while the bot thinks
+	mu  sync.Mutex
+	err error
+}
+
+// defaultEngineTimeout keeps a stuck engine from freezing the game
+const defaultEngineTimeout = 10 * time.Second
+
+// defaultEngineQuitTimeout leaves a slow engine time to exit cleanly
+const defaultEngineQuitTimeout = 5 * time.Second
+
+var errEngineTimeout = errors.New("engine took too long to answer")
+
+// NewEngineBot starts the engine, running the program with the arguments
+func NewEngineBot(program string, args ...string) (*EngineBot, error) {
+	cmd := exec.Command(program, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting engine: %w", err)
+	}
+	b := &EngineBot{
+		HeuristicBot: NewHeuristicBot(Normal),
+		Timeout:      defaultEngineTimeout,
+		QuitTimeout:  defaultEngineQuitTimeout,
+		cmd:          cmd,
+		stdin:        stdin,
+		replies:      make(chan []byte),
+		done:         make(chan struct{}),
+	}
+	go func() {
+		defer close(b.replies)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			if len(scanner.Bytes()) == 0 {
+				continue
+			}
+			select {
+			case b.replies <- slices.Clone(scanner.Bytes()):
+			case <-b.done:
+				return
+			}
+		}
+	}()
+	return b, nil
+}
+
+// Err returns why the engine stopped playing, if it did
+func (b *EngineBot) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+func (b *EngineBot) Act(view BotView, actions []Action) Action {
+	if b.Err() == nil {
+		action, err := b.ask(view, actions)
+		if err == nil {
+			return action
+		}
+		if !errors.Is(err, errIllegalReply) {
+			b.stop(err)
+		}
+	}
+	// the heuristics choose for the engine
+	if planned := askBot(b.HeuristicBot, view); planned != nil {
+		if i := slices.IndexFunc(actions, sameAction(planned)); i >= 0 {
+			return actions[i]
+		}
+	}
+	return actions[0]
+}
+
+var errIllegalReply = errors.New("engine answered with an action that isn't legal")
+
+// ask sends the state and the actions to the engine and returns the one it
+// answers with
+func (b *EngineBot) ask(view BotView, actions []Action) (Action, error) {
+	message := engineMessage{Type: engineAct, State: engineStateFor(view)}
+	keys := make([]string, len(actions))
+	for i, action := range actions {
+		encoded := toEngineAction(action)
+		message.Actions = append(message.Actions, encoded)
+		key, _ := json.Marshal(encoded)
+		keys[i] = string(key)
+	}
+	b.drain()
+	if err := b.send(message); err != nil {
+		return nil, err
+	}
+
+	var timeout <-chan time.Time
+	if b.Timeout > 0 {
+		timer := time.NewTimer(b.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	var line []byte
+	select {
+	case reply, ok := <-b.replies:
+		if !ok {
+			return nil, errors.New("engine stopped answering")
+		}
+		line = reply
+	case <-timeout:
+		return nil, errEngineTimeout
+	}
+	var reply engineAction
+	if err := json.Unmarshal(line, &reply); err != nil {
+		return nil, errIllegalReply
+	}
+	// compare as JSON, which sorts the resources
+	key, _ := json.Marshal(reply)
+	if i := slices.Index(keys, string(key)); i >= 0 {
+		return actions[i], nil
+	}
+	return nil, errIllegalReply
+}
+
+// drain drops whatever the engine printed after its last answer, so it isn't
+// taken for the answer to the next question
+func (b *EngineBot) drain() {
+	for {
+		select {
+		case _, ok := <-b.replies:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (b *EngineBot) send(message engineMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = b.stdin.Write(append(data, '\n'))
+	return err
+}
+
+// finish records why the engine is done, and reports whether it is the first
+// to, which then has to stop the engine
+func (b *EngineBot) finish(err error) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return false
+	}
+	b.err = err
+	close(b.done)
+	return true
+}
+
+// stop ends the engine for good, the heuristics playing on
+func (b *EngineBot) stop(err error) {
+	if !b.finish(err) {
+		return
+	}
+	b.stdin.Close()
+	b.cmd.Process.Kill()
+	b.cmd.Wait()
+}
+
+// Close tells the engine to quit, stopping it if it doesn't within
+// QuitTimeout
+func (b *EngineBot) Close() error {
+	if !b.finish(errors.New("engine closed")) {
+		return nil
+	}
+	b.send(engineMessage{Type: engineQuit})
+	b.stdin.Close()
+	done := make(chan error, 1)
+	go func() { done <- b.cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(b.QuitTimeout):
+		b.cmd.Process.Kill()
+		return <-done
+	}
+}
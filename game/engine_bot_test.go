@@ -0,0 +1,153 @@
+package game
+
+import (
+	"bufio"
+	"el_poblador/board"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestStubEngine isn't a test but the engine the tests below start, running
+// the test binary again. It plays the first action it is offered, or in
+// "silent" mode never answers, or in "twice" mode answers twice.
+func TestStubEngine(t *testing.T) {
+	mode := os.Getenv("STUB_ENGINE")
+	if mode == "" {
+		t.Skip("only run as an engine")
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var message struct {
+			Type    string
+			Actions []json.RawMessage
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			os.Exit(2)
+		}
+		if message.Type == "quit" {
+			break
+		}
+		switch mode {
+		case "first":
+			fmt.Printf("%s\n", message.Actions[0])
+		case "twice":
+			fmt.Printf("%s\n%s\n", message.Actions[0], message.Actions[0])
+		}
+	}
+	os.Exit(0)
+}
+
+func startStubEngine(t *testing.T, mode string) *EngineBot {
+	t.Setenv("STUB_ENGINE", mode)
+	engine, err := NewEngineBot(os.Args[0], "-test.run=^TestStubEngine$")
+	if err != nil {
+		t.Fatalf("Failed to start the stub engine: %v", err)
+	}
+	t.Cleanup(func() { engine.Close() })
+	return engine
+}
+
+func TestEngineBotPlaysAGame(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	engine := startStubEngine(t, "first")
+	game.SetBot(0, engine)
+	game.SetBot(1, NewHeuristicBot(Normal))
+	game.SetBot(2, NewHeuristicBot(Normal))
+
+	for i := 0; i < 20 && !game.isOver(); i++ {
+		game.PlayBots()
+	}
+	if engine.Err() != nil {
+		t.Fatalf("Expected the engine to keep playing, it stopped with %v", engine.Err())
+	}
+	if game.Board.CountSettlements(0)+game.Board.CountCities(0) < 2 {
+		t.Errorf("Expected the engine to place its initial settlements")
+	}
+	if engine.Close() != nil {
+		t.Errorf("Expected the engine to quit when asked")
+	}
+}
+
+func TestEngineBotFallsBackWhenTheEngineHangs(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	engine := startStubEngine(t, "silent")
+	engine.Timeout = 100 * time.Millisecond
+	game.SetBot(0, engine)
+
+	game.PlayBots()
+	if !errors.Is(engine.Err(), errEngineTimeout) {
+		t.Fatalf("Expected the engine to time out, got %v", engine.Err())
+	}
+	if game.PlayerTurn != 1 || game.Board.CountSettlements(0) != 1 {
+		t.Errorf("Expected the heuristics to place the engine's settlement and pass to p2, turn is %d", game.PlayerTurn)
+	}
+}
+
+func TestEngineBotAnswersTheLatestQuestion(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	engine := startStubEngine(t, "twice")
+	view := BotView{game: game, Me: 0}
+	actions := game.LegalActions(0)
+
+	if action, err := engine.ask(view, actions); err != nil || action != actions[0] {
+		t.Fatalf("Expected the first action, got %v (%v)", action, err)
+	}
+	// the second copy of the answer comes in meanwhile
+	time.Sleep(100 * time.Millisecond)
+	reversed := slices.Clone(actions)
+	slices.Reverse(reversed)
+	if action, err := engine.ask(view, reversed); err != nil || action != reversed[0] {
+		t.Errorf("Expected the answer to the second question, %v, got %v (%v)", reversed[0], action, err)
+	}
+}
+
+func TestEngineBotClosesWhileThinking(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	engine := startStubEngine(t, "silent")
+	engine.Timeout = time.Second
+	actions := game.LegalActions(0)
+
+	acted := make(chan Action)
+	go func() { acted <- engine.Act(BotView{game: game, Me: 0}, actions) }()
+	time.Sleep(50 * time.Millisecond)
+	engine.Close()
+	if action := <-acted; !slices.Contains(actions, action) {
+		t.Errorf("Expected the heuristics to pick a legal action, got %v", action)
+	}
+	if engine.Err() == nil {
+		t.Errorf("Expected the engine to be done")
+	}
+}
+
+func TestEngineStateHidesOtherHands(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.Players[0].Resources[board.ResourceWood] = 2
+	game.Players[1].Resources[board.ResourceOre] = 3
+	game.Players[1].HiddenDevCards = []DevCard{DevCardMonopoly}
+
+	state := engineStateFor(BotView{game: game, Me: 0})
+	if state.Players[0].Resources["Wood"] != 2 {
+		t.Errorf("Expected the engine to see its own hand, got %v", state.Players[0].Resources)
+	}
+	other := state.Players[1]
+	if other.Resources != nil || other.DevCards != nil {
+		t.Errorf("Expected the other hands hidden, got %v and %v", other.Resources, other.DevCards)
+	}
+	if other.HandSize != 3 || other.DevCardCount != 1 {
+		t.Errorf("Expected the sizes of the other hands, got %d and %d", other.HandSize, other.DevCardCount)
+	}
+	if len(state.Tiles) != len(game.Board.Tiles) {
+		t.Errorf("Expected every tile, got %d", len(state.Tiles))
+	}
+}
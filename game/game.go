@@ -2,7 +2,8 @@ package game
 
 import (
 	"el_poblador/board"
-	"fmt"
+	"el_poblador/i18n"
+	"encoding/gob"
 	"math/rand/v2"
 	"strings"
 
@@ -13,12 +14,12 @@ type Phase interface {
 	Confirm() Phase
 	MoveCursor(direction string)
 	BoardCursor() interface{}
-	HelpText() string
+	HelpText(l i18n.Locale) string
 }
 
 type PhaseWithMenu interface {
 	Phase
-	Menu() string
+	Menu(l i18n.Locale) string
 }
 
 type PhaseCancelable interface {
@@ -26,11 +27,42 @@ type PhaseCancelable interface {
 	Cancel() Phase
 }
 
-func (g *Game) LogAction(action string) {
-	g.ActionLog = append([]string{action}, g.ActionLog...)
-	if len(g.ActionLog) > 15 {
-		g.ActionLog = g.ActionLog[:15]
+// LogAction adds the message to the action log. Players it names are given as
+// playerName, so each viewer sees them in the colors they draw in.
+func (g *Game) LogAction(key string, args ...any) {
+	g.Log = append(g.Log, i18n.M(key, args...))
+	g.logged++
+}
+
+// playerName fills in a logged message with the player's name
+type playerName int
+
+func init() {
+	// the types logged messages are filled in with
+	gob.Register(playerName(0))
+	gob.Register(board.ResourceType(0))
+	gob.Register(DevCard(""))
+	gob.Register(resourceCounts{})
+}
+
+// LogLines writes the action log in the locale, newest first
+func (g *Game) LogLines(l i18n.Locale) []string {
+	lines := make([]string, len(g.Log))
+	for i := range g.Log {
+		lines[i] = g.logLine(l, g.Log[len(g.Log)-1-i])
+	}
+	return lines
+}
+
+func (g *Game) logLine(l i18n.Locale, message i18n.Message) string {
+	args := make([]any, len(message.Args))
+	for i, arg := range message.Args {
+		if player, ok := arg.(playerName); ok && int(player) < len(g.Players) {
+			arg = g.Players[player].RenderName()
+		}
+		args[i] = arg
 	}
+	return l.T(message.Key, args...)
 }
 
 type Game struct {
@@ -39,22 +71,116 @@ type Game struct {
 	LastDice    [2]int
 	phase       Phase // not exported - not needed for network serialization
 	PlayerTurn  int
+	TurnsPlayed int // turns ended since the initial placement
 	DevCardDeck []DevCard
-	ActionLog   []string
+	Log         []i18n.Message // the action log, oldest first
+	Chat        []ChatMessage
+	Clock       Clock
+	WinChances  []WinChance  // the meter's estimates, oldest first
+	decisions   [][]Decision // pending decisions by player, not saved like phase
+	bots        map[int]Bot
+	rng         *rand.PCG // dice and steals, copied by Clone so a clone rolls the same dice
+	hint        *shownHint
+	logged      int // actions logged, so the meter knows when to look again
+	estimated   int // actions logged at the meter's last estimate
+	botMoves    int // moves of the bot in turn through PlayBotMove
 	shouldQuit  bool
+	noSaving    bool // games shared over a lobby have no Save & Quit
+}
+
+// Viewport describes how a player is looking at the game.
+type Viewport struct {
+	Width  int
+	Height int
+	// Player is the player that the user is playing as.
+	// If nil, the game will render from the perspective of the player it is
+	// waiting on, usually the turn holder.
+	Player *int
+	// TwoColumnCycle and OneColumnCycle control which columns are visible in responsive layouts.
+	TwoColumnCycle int
+	OneColumnCycle int
+	// Spectator is set for users watching without a seat
+	Spectator bool
+	// ChatFocused is set while the user is typing ChatDraft into the chat
+	ChatFocused bool
+	ChatDraft   string
+	// ChatError explains why the last message could not be sent
+	ChatError string
+	// CommandFocused is set while the user is typing CommandDraft on the
+	// command line, CommandOptions are what tab offered last
+	CommandFocused bool
+	CommandDraft   string
+	CommandOptions []string
+	// CommandError explains why the last command failed
+	CommandError string
+	// Prompt asks the user to confirm something, in place of the help line
+	Prompt string
+	// LogFocused is set while the user reads the whole action log, which
+	// then takes the chat's room too. LogFilter picks the entries it shows
+	// and LogScroll how many of the newest of those are scrolled past.
+	// LogSearching is set while the user types LogFilter.Search.
+	LogFocused   bool
+	LogFilter    LogFilter
+	LogScroll    int
+	LogSearching bool
+	// LogHelp is the help line while the log is focused, naming the user's
+	// keys. If empty, the line names the default keys.
+	LogHelp string
+	// Overlay is drawn over the board and explained under it
+	Overlay board.Overlay
+	// Theme names the board.Theme to draw in, the default if empty. ASCII
+	// draws with ASCII characters only.
+	Theme string
+	ASCII bool
+	// Locale is the language to write in, English if empty
+	Locale i18n.Locale
 }
 
 // requestPlayer is the player that the user is playing as.
 // If nil, the game will render from the perspective of the turn holder.
 // twoColumnCycle and oneColumnCycle control which columns are visible in responsive layouts.
 func (g *Game) Print(width, height int, requestPlayer *int, twoColumnCycle, oneColumnCycle int) string {
-	playerPerspective := g.playerPerspective(requestPlayer)
-	margin := lipgloss.NewStyle().Margin(1)
+	return g.Render(Viewport{
+		Width:          width,
+		Height:         height,
+		Player:         requestPlayer,
+		TwoColumnCycle: twoColumnCycle,
+		OneColumnCycle: oneColumnCycle,
+	})
+}
+
+func (g *Game) Render(v Viewport) string {
+	return g.layout(v).view
+}
 
-	help := g.helpText(width)
-	sidebar := g.buildSidebar(playerPerspective, margin)
+// screen is a rendered game and where its board and menu ended up, for
+// telling what is under the mouse
+type screen struct {
+	view string
+	// board and menu are the top left cells of the board and the menu, if
+	// they are on screen
+	board, menu       [2]int
+	hasBoard, hasMenu bool
+}
 
-	boardLines := g.Board.Print(g.phase.BoardCursor())
+func (g *Game) layout(v Viewport) screen {
+	width, height := v.Width, v.Height
+	playerPerspective := g.playerPerspective(v.Player)
+	margin := lipgloss.NewStyle().Margin(1)
+	theme := v.theme()
+	defer g.wearTheme(theme)()
+
+	help := g.helpText(v, playerPerspective)
+	sidebar, menuLine := g.buildSidebar(v.Locale, playerPerspective, v.Spectator, margin, theme)
+
+	_, roadStarts := g.phase.(*phaseRoadStart)
+	boardLines := g.Board.Print(g.phase.BoardCursor(), board.PrintOptions{
+		Highlights: g.highlights(playerPerspective),
+		RoadStarts: roadStarts,
+		Overlay:    v.Overlay,
+		Theme:      theme,
+		Locale:     v.Locale,
+	})
 	boardContent := strings.Join(boardLines, "\n")
 	boardWidth := lipgloss.Width(boardContent)
 	boardHeight := lipgloss.Height(boardContent)
@@ -66,148 +192,280 @@ func (g *Game) Print(width, height int, requestPlayer *int, twoColumnCycle, oneC
 		actionLogWidth = boardWidth - 4
 	}
 
-	actionLogStyle := margin.Border(lipgloss.NormalBorder()).Width(actionLogWidth).Height(boardHeight - 4)
-	actionLogContent := strings.Join(g.ActionLog, "\n")
-	actionLogRendered := actionLogStyle.Render(actionLogContent)
+	// the action log and the chat share the column, one above the other
+	paneHeight := boardHeight - 6
+	logHeight := paneHeight / 2
+	chatHeight := paneHeight - logHeight
+	pane := lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Width(actionLogWidth)
+	var actionLogRendered string
+	if v.LogFocused {
+		// reading the log, which takes the chat's room too
+		logHeight = paneHeight + 2
+		actionLogStyle := pane.Margin(1).Height(logHeight).MaxHeight(logHeight + 4)
+		actionLogRendered = actionLogStyle.Render(g.buildLog(v, actionLogWidth, logHeight))
+	} else {
+		actionLogStyle := pane.Margin(1, 1, 0, 1).Height(logHeight).MaxHeight(logHeight + 2)
+		actionLogContent := g.buildLog(v, actionLogWidth, logHeight)
+		chatViewer := playerPerspective
+		if v.Spectator {
+			chatViewer = ChatEveryone
+		}
+		chatContent := g.buildChat(v, chatViewer, actionLogWidth, chatHeight)
+		chatRendered := pane.Margin(0, 1, 1, 1).Height(chatHeight).Render(chatContent)
+		actionLogRendered = lipgloss.JoinVertical(lipgloss.Left, actionLogStyle.Render(actionLogContent), chatRendered)
+	}
 
-	var layout string
+	// the columns shown, left to right, and where the board and sidebar are
+	var columns []string
+	boardColumn, sidebarColumn := -1, -1
 	if width >= 120 {
-		layout = lipgloss.JoinHorizontal(lipgloss.Top, actionLogRendered, boardContent, sidebar)
+		columns, boardColumn, sidebarColumn = []string{actionLogRendered, boardContent, sidebar}, 1, 2
 	} else if width >= 90 {
-		if twoColumnCycle == 0 {
-			layout = lipgloss.JoinHorizontal(lipgloss.Top, boardContent, sidebar)
+		if v.TwoColumnCycle == 0 {
+			columns, boardColumn, sidebarColumn = []string{boardContent, sidebar}, 0, 1
 		} else {
-			layout = lipgloss.JoinHorizontal(lipgloss.Top, actionLogRendered, sidebar)
+			columns, sidebarColumn = []string{actionLogRendered, sidebar}, 1
 		}
 	} else {
-		switch oneColumnCycle {
+		switch v.OneColumnCycle {
 		case 0:
-			layout = sidebar
+			columns, sidebarColumn = []string{sidebar}, 0
 		case 1:
-			layout = boardContent
+			columns, boardColumn = []string{boardContent}, 0
 		case 2:
-			layout = actionLogRendered
+			columns = []string{actionLogRendered}
 		}
 	}
+	layout := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
 
 	availableHeight := height - lipgloss.Height(help)
 	mainContent := lipgloss.Place(width, availableHeight, lipgloss.Center, lipgloss.Center, layout)
 
-	return lipgloss.JoinVertical(lipgloss.Left, mainContent, help)
+	s := screen{view: lipgloss.JoinVertical(lipgloss.Left, mainContent, help)}
+	if theme.Monochrome {
+		s.view = stripColors(s.view)
+	}
+	if v.ASCII {
+		s.view = ASCII(s.view)
+	}
+	// Place centers the layout, rounding the free space on the left and top
+	// down
+	left := max(width-lipgloss.Width(layout), 0) / 2
+	top := max(availableHeight-lipgloss.Height(layout), 0) / 2
+	for i, column := range columns {
+		switch i {
+		case boardColumn:
+			s.board, s.hasBoard = [2]int{left, top}, true
+		case sidebarColumn:
+			s.menu, s.hasMenu = [2]int{left + 1, top + menuLine}, menuLine >= 0
+		}
+		left += lipgloss.Width(column)
+	}
+	return s
 }
 
-func (g *Game) buildSidebar(playerPerspective int, margin lipgloss.Style) string {
+// sidebarWidth is how wide the sidebar is on screen
+const sidebarWidth = 30
+
+// buildSidebar returns the sidebar and the line its menu starts on, -1 if
+// there is none. Spectators see what is on the table only: no hand, no menu,
+// and the points everyone can see.
+func (g *Game) buildSidebar(l i18n.Locale, playerPerspective int, spectator bool, margin lipgloss.Style, theme *board.Theme) (string, int) {
 	var dice string
 	if g.LastDice[0] != 0 {
-		dice = fmt.Sprintf("Dice: %d (%d + %d)", g.LastDice[0]+g.LastDice[1], g.LastDice[0], g.LastDice[1])
+		dice = l.T("sidebar.dice", g.LastDice[0]+g.LastDice[1], g.LastDice[0], g.LastDice[1])
 	} else {
-		dice = "Dice: not rolled yet"
+		dice = l.T("sidebar.not_rolled")
+	}
+	if clock := g.clockText(l, playerPerspective); clock != "" {
+		dice += "\n" + clock
 	}
 	dice = margin.Render(dice)
 
 	var playerList []string
 	for i, player := range g.Players {
-		var name string
-		if i == playerPerspective {
-			name = fmt.Sprintf("%s (you)", player.Render(player.Name))
-		} else {
-			name = player.Render(player.Name)
+		name := player.Render(player.Name) + ownerSuffix(theme, i)
+		if i == playerPerspective && !spectator {
+			name = l.T("sidebar.you", name)
 		}
-		info := player.Render(fmt.Sprintf(" has %d resources, %d dev cards", player.TotalResources(), player.TotalDevCards()))
+		info := player.Render(l.T("sidebar.hand", player.TotalResources(), player.TotalDevCards()))
 		playerList = append(playerList, name, info)
+		if spectator {
+			playerList = append(playerList, player.Render(l.T("sidebar.shown_points", player.ShownVictoryPoints(g))))
+		}
 	}
 	otherPlayers := margin.Render(strings.Join(playerList, "\n"))
 
 	myPlayer := g.Players[playerPerspective]
-	myResources := []string{"Your resources:"}
+	myResources := []string{l.T("sidebar.resources")}
 	for _, resource := range board.RESOURCE_TYPES {
-		myResources = append(myResources, fmt.Sprintf("%s: %d", resource, myPlayer.Resources[resource]))
+		myResources = append(myResources, l.T("sidebar.resource", resource, myPlayer.Resources[resource]))
 	}
 	myResources = append(myResources, "")
-	myResources = append(myResources, fmt.Sprintf("Dev Cards: %d", myPlayer.TotalDevCards()))
-	myResources = append(myResources, fmt.Sprintf("Victory Points: %d", myPlayer.VictoryPoints(g)))
+	myResources = append(myResources, l.T("sidebar.dev_cards", myPlayer.TotalDevCards()))
+	myResources = append(myResources, l.T("sidebar.points", myPlayer.VictoryPoints(g)))
 	myResourcesStr := margin.Render(strings.Join(myResources, "\n"))
 
 	var phaseSidebar string
-	if p, ok := g.phase.(PhaseWithMenu); ok {
-		if playerPerspective == g.PlayerTurn {
-			phaseSidebar = margin.Render(p.Menu())
+	if d := g.decisionFor(playerPerspective); d != nil {
+		phaseSidebar = margin.Render(d.Menu(l))
+	} else if p, ok := g.phase.(PhaseWithMenu); ok {
+		if g.phaseWaitsOn(playerPerspective) {
+			phaseSidebar = margin.Render(p.Menu(l))
 		}
 	}
+	if spectator {
+		phaseSidebar = ""
+	}
+
+	blocks := []string{dice, otherPlayers}
+	if chances := g.winChancesText(l); chances != "" {
+		blocks = append(blocks, margin.Render(chances))
+	}
+	if !spectator {
+		blocks = append(blocks, myResourcesStr)
+	}
+	column := lipgloss.NewStyle().Width(sidebarWidth)
+	menuLine := -1
+	if phaseSidebar != "" {
+		// below the blocks above it, as wrapped, and the menu's margin
+		menuLine = lipgloss.Height(column.Render(lipgloss.JoinVertical(lipgloss.Left, blocks...))) + 1
+	}
+	blocks = append(blocks, phaseSidebar)
+	sidebar := lipgloss.JoinVertical(lipgloss.Left, blocks...)
+	return column.Render(sidebar), menuLine
+}
 
-	sidebar := lipgloss.JoinVertical(lipgloss.Left, dice, otherPlayers, myResourcesStr, phaseSidebar)
-	return lipgloss.NewStyle().Width(30).Render(sidebar)
+// PerspectiveOf returns the player a user is acting as, see Viewport.Player.
+func (g *Game) PerspectiveOf(requestPlayer *int) int {
+	return g.playerPerspective(requestPlayer)
 }
 
 func (g *Game) playerPerspective(requestPlayer *int) int {
 	if requestPlayer != nil && *requestPlayer < len(g.Players) {
 		return *requestPlayer
-	} else {
-		return g.PlayerTurn
 	}
+	// hand the keyboard to whoever has to act, e.g. to discard on a 7
+	if waiting := g.WaitingOn(); len(waiting) > 0 && !g.phaseWaitsOn(g.PlayerTurn) {
+		return waiting[0]
+	}
+	return g.PlayerTurn
 }
 
-func (g *Game) helpText(width int) string {
+func (g *Game) helpText(v Viewport, playerPerspective int) string {
+	if line := g.commandLine(v); line != "" {
+		return lipgloss.PlaceHorizontal(v.Width, lipgloss.Center, line)
+	}
+	if v.Prompt != "" {
+		return lipgloss.PlaceHorizontal(v.Width, lipgloss.Center, lipgloss.NewStyle().Bold(true).Render(v.Prompt))
+	}
+	if v.LogFocused {
+		help := v.LogHelp
+		if help == "" {
+			help = v.Locale.T("log.help", "↑", "↓", "PgUp", "PgDn", "p", "e", "/", "esc")
+		}
+		return lipgloss.PlaceHorizontal(v.Width, lipgloss.Center, help)
+	}
 	player := &g.Players[g.PlayerTurn]
-	help := fmt.Sprintf("%s's turn. %s", player.Render(player.Name), g.phase.HelpText())
-	renderedHelp := lipgloss.PlaceHorizontal(width, lipgloss.Center, help)
+	phaseHelp := g.phase.HelpText(v.Locale)
+	if d := g.decisionFor(playerPerspective); d != nil {
+		phaseHelp = d.HelpText(v.Locale)
+	}
+	if hint := g.hintText(v.Locale, playerPerspective); hint != "" {
+		phaseHelp = hint
+	}
+	help := v.Locale.T("help.turn", player.Render(player.Name), phaseHelp)
+	renderedHelp := lipgloss.PlaceHorizontal(v.Width, lipgloss.Center, help)
+	if legend := g.overlayLegend(v.Locale, v.Overlay); legend != "" {
+		legend = lipgloss.NewStyle().MaxWidth(v.Width).Render(legend)
+		renderedHelp = lipgloss.JoinVertical(lipgloss.Left, lipgloss.PlaceHorizontal(v.Width, lipgloss.Center, legend), renderedHelp)
+	}
 	return renderedHelp
 }
 
+// PlayerColor is one of the colors a player can pick for their pieces.
+type PlayerColor struct {
+	Name  string
+	Color lipgloss.AdaptiveColor
+}
+
+// Distinctive colors that work well on both light and dark backgrounds
+var PlayerColors = []PlayerColor{
+	{Name: "blue", Color: board.DefaultTheme.Players[0]},
+	{Name: "red", Color: board.DefaultTheme.Players[1]},
+	{Name: "orange", Color: board.DefaultTheme.Players[2]},
+	{Name: "purple", Color: board.DefaultTheme.Players[3]},
+}
+
+// Seat is a player's place at the table, in turn order.
+type Seat struct {
+	Name  string
+	Color lipgloss.AdaptiveColor
+}
+
+// Start begins a game with the players seated in random order.
 func (g *Game) Start(playerNames []string) {
 	if len(playerNames) < 3 || len(playerNames) > 4 {
 		panic("Game must have 3-4 players")
 	}
-	// Distinctive colors that work well on both light and dark backgrounds
-	colors := []lipgloss.AdaptiveColor{
-		{Light: "#1565C0", Dark: "#42A5F5"}, // Blue
-		{Light: "#C62828", Dark: "#EF5350"}, // Red
-		{Light: "#F57C00", Dark: "#FFB74D"}, // Orange
-		{Light: "#6A1B9A", Dark: "#AB47BC"}, // Purple
-	}
-	g.Players = make([]Player, len(playerNames))
+	seats := make([]Seat, len(playerNames))
 	for i, name := range playerNames {
+		seats[i] = Seat{Name: name, Color: PlayerColors[i].Color}
+	}
+	rand.Shuffle(len(seats), func(i, j int) {
+		seats[i], seats[j] = seats[j], seats[i]
+	})
+	g.StartSeated(seats)
+}
+
+// StartSeated begins a game with the players in exactly the given order,
+// as chosen in the lobby. The first seat places the first settlement.
+func (g *Game) StartSeated(seats []Seat) {
+	if len(seats) < 3 || len(seats) > 4 {
+		panic("Game must have 3-4 players")
+	}
+	g.Players = make([]Player, len(seats))
+	for i, seat := range seats {
 		g.Players[i] = Player{
-			Name:           name,
-			Color:          colors[i],
+			Name:           seat.Name,
+			Color:          seat.Color,
 			Resources:      make(map[board.ResourceType]int),
 			HiddenDevCards: make([]DevCard, 0),
 			PlayedDevCards: make([]DevCard, 0),
 		}
 	}
-	rand.Shuffle(len(g.Players), func(i, j int) {
-		g.Players[i], g.Players[j] = g.Players[j], g.Players[i]
-	})
 	// Create player color map for board rendering
 	playerColors := make(map[int]lipgloss.AdaptiveColor)
 	for i, player := range g.Players {
 		playerColors[i] = player.Color
 	}
-	g.Board = board.NewLegalBoard(playerColors)
+	g.Board = board.NewLegalBoardWith(g.random(), playerColors)
 	g.PlayerTurn = 0
 	g.phase = PhaseInitialSettlements(g, true)
-	g.DevCardDeck = shuffleDevCards()
-	g.ActionLog = make([]string, 0, 15)
+	g.DevCardDeck = shuffleDevCards(g.random())
+	g.Log = nil
 }
 
+// MoveCursor, ConfirmAction and CancelAction route the input of a player to
+// their first pending decision, or to the phase if it is waiting on them.
+// Input from players the game isn't waiting on is ignored. Besides the arrow
+// directions, "next" jumps the cursor to the next place on the board the
+// phase can be confirmed at.
 func (g *Game) MoveCursor(direction string, requestPlayer *int) {
 	playerPerspective := g.playerPerspective(requestPlayer)
-	// for now simply ignore moves from other players
-	if playerPerspective != g.PlayerTurn {
-		return
+	if d := g.decisionFor(playerPerspective); d != nil {
+		d.MoveCursor(direction)
+	} else if g.phaseWaitsOn(playerPerspective) {
+		g.phase.MoveCursor(direction)
 	}
-	g.phase.MoveCursor(direction)
 }
 
 // Function for testing purposes: move the cursor to any valid settlement location
 func (g *Game) MoveCursorToPlaceSettlement() {
 	find := func() board.CrossCoord {
-		for x := 0; x <= 5; x++ {
-			for y := 0; y <= 10; y++ {
-				coord, valid := board.NewCrossCoord(x, y)
-				if valid && g.Board.CanPlaceSettlement(coord) {
-					return coord
-				}
+		for _, coord := range allCrossCoords() {
+			if g.Board.CanPlaceSettlement(coord) {
+				return coord
 			}
 		}
 		panic("no valid settlement location found")
@@ -219,17 +477,21 @@ func (g *Game) MoveCursorToPlaceSettlement() {
 
 func (g *Game) ConfirmAction(requestPlayer *int) {
 	playerPerspective := g.playerPerspective(requestPlayer)
-	// for now simply ignore actions from other players
-	if playerPerspective != g.PlayerTurn {
+	if d := g.decisionFor(playerPerspective); d != nil {
+		if d.Confirm() {
+			g.settleDecision(playerPerspective)
+		}
 		return
 	}
-	g.phase = g.phase.Confirm()
+	if g.phaseWaitsOn(playerPerspective) {
+		g.phase = g.phase.Confirm()
+	}
 }
 
 func (g *Game) CancelAction(requestPlayer *int) {
 	playerPerspective := g.playerPerspective(requestPlayer)
-	// for now simply ignore actions from other players
-	if playerPerspective != g.PlayerTurn {
+	// decisions can't be backed out of
+	if g.decisionFor(playerPerspective) != nil || !g.phaseWaitsOn(playerPerspective) {
 		return
 	}
 	if p, ok := g.phase.(PhaseCancelable); ok {
@@ -237,6 +499,20 @@ func (g *Game) CancelAction(requestPlayer *int) {
 	}
 }
 
+// Seed sets the game's random numbers. Seeded before Start, the same seed
+// deals the same board and cards and rolls the same dice.
+func (g *Game) Seed(seed uint64) {
+	g.rng = rand.NewPCG(seed, seed)
+}
+
+// random returns the game's random numbers, seeding them on first use
+func (g *Game) random() *rand.Rand {
+	if g.rng == nil {
+		g.rng = rand.NewPCG(rand.Uint64(), rand.Uint64())
+	}
+	return rand.New(g.rng)
+}
+
 // DrawDevelopmentCard draws a card from the development card deck
 func (g *Game) DrawDevelopmentCard() *DevCard {
 	if len(g.DevCardDeck) == 0 {
@@ -269,6 +545,12 @@ func (g *Game) CheckGameEnd() *Player {
 	return nil
 }
 
+// DisableSaving takes Save & Quit off the menu, for games that don't belong
+// to the player at the keyboard alone.
+func (g *Game) DisableSaving() {
+	g.noSaving = true
+}
+
 func (g *Game) ShouldQuit() bool {
 	return g.shouldQuit
 }
@@ -14,7 +14,7 @@ func TestInitialSettlementsRender(t *testing.T) {
 
 	// 2 rounds of placing settlements and roads
 	for i := 0; i < 2*len(game.Players); i++ {
-		help := game.helpText(100)
+		help := game.helpText(Viewport{Width: 100}, game.PlayerTurn)
 		expectedTurn := game.Players[expectedTurns[i]].Name
 		if !strings.Contains(help, expectedTurn) {
 			t.Fatalf("Help text '%s' does not contain expected turn '%s'. Iteration %d", help, expectedTurn, i)
@@ -44,3 +44,22 @@ func TestInitialSettlementsRender(t *testing.T) {
 		t.Fatalf("Game render does not contain expected dice text '%s'", diceText)
 	}
 }
+
+func TestStartSeatedKeepsOrder(t *testing.T) {
+	game := &Game{}
+	seats := []Seat{
+		{Name: "carla", Color: PlayerColors[3].Color},
+		{Name: "ana", Color: PlayerColors[0].Color},
+		{Name: "bruno", Color: PlayerColors[2].Color},
+	}
+	game.StartSeated(seats)
+
+	for i, seat := range seats {
+		if game.Players[i].Name != seat.Name || game.Players[i].Color != seat.Color {
+			t.Errorf("Seat %d: expected %s, got %s", i, seat.Name, game.Players[i].Name)
+		}
+	}
+	if game.PlayerTurn != 0 {
+		t.Errorf("Expected the first seat to start, got %d", game.PlayerTurn)
+	}
+}
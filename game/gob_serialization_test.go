@@ -90,8 +90,8 @@ func TestGobSerialization(t *testing.T) {
 	}
 
 	// Verify action log
-	if len(deserializedGame.ActionLog) != len(game.ActionLog) {
-		t.Errorf("ActionLog count mismatch: got %d, want %d", len(deserializedGame.ActionLog), len(game.ActionLog))
+	if len(deserializedGame.Log) != len(game.Log) {
+		t.Errorf("Log count mismatch: got %d, want %d", len(deserializedGame.Log), len(game.Log))
 	}
 
 	t.Logf("Successfully serialized and deserialized game with %d bytes of gob data", len(gobData))
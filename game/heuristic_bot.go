@@ -0,0 +1,392 @@
+package game
+
+import (
+	"el_poblador/board"
+	"math/rand/v2"
+	"slices"
+)
+
+// Difficulty sets how well a HeuristicBot plays
+type Difficulty int
+
+const (
+	// Easy settles and builds somewhat carelessly and never trades
+	Easy Difficulty = iota
+	// Normal follows its plan and trades with the bank when that helps
+	Normal
+	// Hard also weighs scarce resources, saves for whatever it is closest to
+	// and plays knights whenever the robber blocks it
+	Hard
+)
+
+var difficultyNames = []string{"easy", "normal", "hard"}
+
+func (d Difficulty) String() string {
+	return difficultyNames[d]
+}
+
+// ParseDifficulty reads "easy", "normal" or "hard"
+func ParseDifficulty(name string) (Difficulty, bool) {
+	i := slices.Index(difficultyNames, name)
+	return Difficulty(max(i, 0)), i >= 0
+}
+
+// HeuristicBot is a rule-based computer opponent. It settles on productive,
+// varied crossings, builds toward whatever gets it a victory point soonest,
+// and sends the robber after the leader.
+type HeuristicBot struct {
+	Level Difficulty
+	// Book, if set, picks the initial settlements among the bot's favorites
+	Book *OpeningBook
+	rng  *rand.Rand
+}
+
+func NewHeuristicBot(level Difficulty) *HeuristicBot {
+	return NewHeuristicBotSeeded(level, rand.Uint64())
+}
+
+// NewHeuristicBotSeeded makes a bot whose random choices come from the seed,
+// so it plays the same game the same way every time
+func NewHeuristicBotSeeded(level Difficulty, seed uint64) *HeuristicBot {
+	bot := &HeuristicBot{Level: level, rng: rand.New(rand.NewPCG(seed, seed))}
+	if level == Hard {
+		bot.Book = DefaultOpeningBook()
+	}
+	return bot
+}
+
+var devCardCost = map[board.ResourceType]int{board.ResourceWheat: 1, board.ResourceSheep: 1, board.ResourceOre: 1}
+
+// careless reports whether an Easy bot overlooks the best choice this time
+func (b *HeuristicBot) careless() bool {
+	return b.Level == Easy && b.rng.IntN(3) == 0
+}
+
+// best returns the choice with the highest score, or a random one if the bot
+// is careless
+func best[T any](b *HeuristicBot, choices []T, score func(T) int) T {
+	var zero T
+	if len(choices) == 0 {
+		return zero
+	}
+	if b.careless() {
+		return choices[b.rng.IntN(len(choices))]
+	}
+	bestChoice, bestScore := choices[0], score(choices[0])
+	for _, choice := range choices[1:] {
+		if s := score(choice); s > bestScore {
+			bestChoice, bestScore = choice, s
+		}
+	}
+	return bestChoice
+}
+
+// scarcity weighs each resource by how little of it the board produces,
+// 1 for the most common one
+func scarcity(view BotView) map[board.ResourceType]int {
+	production := make(map[board.ResourceType]int)
+	most := 0
+	for _, tile := range view.Tiles() {
+		if resource, ok := board.TileResource(tile); ok {
+			production[resource] += board.Pips(tile.DiceNumber)
+			most = max(most, production[resource])
+		}
+	}
+	weights := make(map[board.ResourceType]int)
+	for _, resource := range board.RESOURCE_TYPES {
+		weights[resource] = most / max(production[resource], 1)
+	}
+	return weights
+}
+
+// settlementScore values a crossing by its pips, plus the resources it adds
+// to those the bot already produces
+func (b *HeuristicBot) settlementScore(view BotView, cross board.CrossCoord) int {
+	owned := make(map[board.ResourceType]bool)
+	for _, settlement := range view.CitySpots() {
+		for _, resource := range view.ResourcesAt(settlement) {
+			owned[resource] = true
+		}
+	}
+	var weights map[board.ResourceType]int
+	if b.Level == Hard {
+		weights = scarcity(view)
+	}
+	score := view.Production(cross)
+	for _, resource := range view.ResourcesAt(cross) {
+		if !owned[resource] {
+			owned[resource] = true
+			score += 3 + weights[resource]
+		}
+	}
+	return score
+}
+
+// InitialSettlement settles the best crossing, nudged by the book if the bot
+// has one
+func (b *HeuristicBot) InitialSettlement(view BotView) board.CrossCoord {
+	return best(b, view.SettlementSpots(), func(cross board.CrossCoord) int {
+		score := b.settlementScore(view, cross)
+		if b.Book != nil {
+			score += b.Book.bonus(view, cross)
+		}
+		return score
+	})
+}
+
+// InitialRoad heads toward the best crossing to settle next
+func (b *HeuristicBot) InitialRoad(view BotView, settlement board.CrossCoord) board.CrossCoord {
+	return best(b, view.InitialRoadSpots(settlement), func(to board.CrossCoord) int {
+		return b.reach(view, to, settlement)
+	})
+}
+
+// reach values a crossing a road leads to by the best place to settle there
+// or one step further
+func (b *HeuristicBot) reach(view BotView, to, from board.CrossCoord) int {
+	if view.CanSettle(to) {
+		return 2 * view.Production(to)
+	}
+	score := 0
+	for _, next := range to.Neighbors() {
+		if next != from && view.CanSettle(next) {
+			score = max(score, view.Production(next))
+		}
+	}
+	return score
+}
+
+// blocked reports whether the robber sits on one of the bot's tiles
+func (b *HeuristicBot) blocked(view BotView) bool {
+	return slices.Contains(view.PlayersAround(view.Robber()), view.Me)
+}
+
+func (b *HeuristicBot) KnightBeforeRoll(view BotView) bool {
+	return b.Level != Easy && b.blocked(view)
+}
+
+// goal is the next build of the bot's plan
+func (b *HeuristicBot) goal(view BotView) MoveKind {
+	var goals []MoveKind
+	if len(view.CitySpots()) > 0 {
+		goals = append(goals, MoveBuildCity)
+	}
+	if len(view.SettlementSpots()) > 0 {
+		goals = append(goals, MoveBuildSettlement)
+	} else if len(view.RoadSpots()) > 0 {
+		goals = append(goals, MoveBuildRoad)
+	}
+	if view.CanAfford(MoveBuyDevCard) || len(goals) == 0 {
+		goals = append(goals, MoveBuyDevCard)
+	}
+	if b.Level != Hard {
+		return goals[0]
+	}
+	// Hard saves for whatever it is closest to
+	return best(b, goals, func(goal MoveKind) int {
+		return -len(b.missingFor(view, goal))
+	})
+}
+
+func costOf(kind MoveKind) map[board.ResourceType]int {
+	switch kind {
+	case MoveBuildCity:
+		return cityCost
+	case MoveBuildSettlement:
+		return settlementCost
+	case MoveBuildRoad:
+		return roadCost
+	default:
+		return devCardCost
+	}
+}
+
+// missingFor lists the cards the bot lacks for the build
+func (b *HeuristicBot) missingFor(view BotView, kind MoveKind) []board.ResourceType {
+	resources := view.Resources()
+	cost := costOf(kind)
+	var missing []board.ResourceType
+	for _, resource := range board.RESOURCE_TYPES {
+		for i := resources[resource]; i < cost[resource]; i++ {
+			missing = append(missing, resource)
+		}
+	}
+	return missing
+}
+
+func (b *HeuristicBot) Turn(view BotView) Move {
+	// points first
+	switch {
+	case view.CanAfford(MoveBuildCity) && len(view.CitySpots()) > 0:
+		return Move{Kind: MoveBuildCity}
+	case view.CanAfford(MoveBuildSettlement) && len(view.SettlementSpots()) > 0:
+		return Move{Kind: MoveBuildSettlement}
+	}
+	goal := b.goal(view)
+	if view.CanAfford(goal) {
+		return Move{Kind: goal}
+	}
+	if b.Level != Easy {
+		if card, ok := b.devCard(view, goal); ok {
+			return Move{Kind: MovePlayDevCard, Card: card}
+		}
+		if give, get, ok := b.bankTrade(view, goal); ok {
+			return Move{Kind: MoveBankTrade, Give: give, Get: get}
+		}
+	}
+	return Move{Kind: MoveEndTurn}
+}
+
+// devCard picks a card worth playing now
+func (b *HeuristicBot) devCard(view BotView, goal MoveKind) (DevCard, bool) {
+	for _, card := range view.DevCards() {
+		switch card {
+		case DevCardRoadBuilding:
+			if len(view.RoadSpots()) > 0 {
+				return card, true
+			}
+		case DevCardMonopoly, DevCardYearOfPlenty:
+			if len(b.missingFor(view, goal)) > 0 {
+				return card, true
+			}
+		case DevCardKnight:
+			if b.Level == Hard && b.blocked(view) {
+				return card, true
+			}
+		}
+	}
+	return "", false
+}
+
+// bankTrade trades four of a resource the goal doesn't need for one it misses
+func (b *HeuristicBot) bankTrade(view BotView, goal MoveKind) (board.ResourceType, board.ResourceType, bool) {
+	missing := b.missingFor(view, goal)
+	if len(missing) == 0 {
+		return 0, 0, false
+	}
+	resources := view.Resources()
+	cost := costOf(goal)
+	for _, resource := range board.RESOURCE_TYPES {
+		if resources[resource] >= 4+cost[resource] {
+			return resource, missing[0], true
+		}
+	}
+	return 0, 0, false
+}
+
+func (b *HeuristicBot) Road(view BotView) board.PathCoord {
+	return best(b, view.RoadSpots(), func(road board.PathCoord) int {
+		return b.reach(view, road.To, road.From)
+	})
+}
+
+func (b *HeuristicBot) Settlement(view BotView) board.CrossCoord {
+	return best(b, view.SettlementSpots(), func(cross board.CrossCoord) int {
+		return b.settlementScore(view, cross)
+	})
+}
+
+func (b *HeuristicBot) City(view BotView) board.CrossCoord {
+	return best(b, view.CitySpots(), view.Production)
+}
+
+// leader returns the opponent with the most visible points, biggest hand first
+// on a tie
+func (b *HeuristicBot) leader(view BotView, players []int) int {
+	leader, leaderScore := -1, -1
+	for _, player := range players {
+		if player == view.Me {
+			continue
+		}
+		if score := view.PublicVictoryPoints(player)*100 + view.HandSize(player); score > leaderScore {
+			leader, leaderScore = player, score
+		}
+	}
+	return leader
+}
+
+// Robber blocks the leader's busiest tile, as long as the bot isn't on it
+func (b *HeuristicBot) Robber(view BotView) board.TileCoord {
+	var coords []board.TileCoord
+	for coord := range view.Tiles() {
+		if coord != view.Robber() && !slices.Contains(view.PlayersAround(coord), view.Me) {
+			coords = append(coords, coord)
+		}
+	}
+	// map order is random, keep the choice stable
+	slices.SortFunc(coords, func(a, b board.TileCoord) int {
+		if a.X != b.X {
+			return a.X - b.X
+		}
+		return a.Y - b.Y
+	})
+	score := b.robberScore(view)
+	return best(b, coords, score)
+}
+
+// robberScore values blocking a tile by how much it takes from the others,
+// the leader counting three times
+func (b *HeuristicBot) robberScore(view BotView) func(board.TileCoord) int {
+	opponents := make([]int, view.Players())
+	for i := range opponents {
+		opponents[i] = i
+	}
+	leader := b.leader(view, opponents)
+	tiles := view.Tiles()
+	return func(coord board.TileCoord) int {
+		score := 0
+		for _, player := range view.PlayersAround(coord) {
+			switch player {
+			case view.Me:
+				return -1
+			case leader:
+				score += 3
+			default:
+				score++
+			}
+		}
+		return score * board.Pips(tiles[coord].DiceNumber)
+	}
+}
+
+// Steal takes from the leader
+func (b *HeuristicBot) Steal(view BotView, victims []int) int {
+	if b.careless() {
+		return victims[b.rng.IntN(len(victims))]
+	}
+	if leader := b.leader(view, victims); leader >= 0 {
+		return leader
+	}
+	return victims[0]
+}
+
+// Discard keeps what the plan needs, giving up the biggest surplus first
+func (b *HeuristicBot) Discard(view BotView, amount int) map[board.ResourceType]int {
+	resources := view.Resources()
+	cost := costOf(b.goal(view))
+	discard := make(map[board.ResourceType]int)
+	for i := 0; i < amount; i++ {
+		var most board.ResourceType
+		mostSurplus := -100
+		for _, resource := range board.RESOURCE_TYPES {
+			if surplus := resources[resource] - cost[resource]; resources[resource] > 0 && surplus > mostSurplus {
+				most, mostSurplus = resource, surplus
+			}
+		}
+		resources[most]--
+		discard[most]++
+	}
+	return discard
+}
+
+func (b *HeuristicBot) Monopoly(view BotView) board.ResourceType {
+	if missing := b.missingFor(view, b.goal(view)); len(missing) > 0 {
+		return missing[0]
+	}
+	return board.ResourceOre
+}
+
+func (b *HeuristicBot) YearOfPlenty(view BotView) (board.ResourceType, board.ResourceType) {
+	missing := append(b.missingFor(view, b.goal(view)), board.ResourceOre, board.ResourceWheat)
+	return missing[0], missing[1]
+}
@@ -0,0 +1,271 @@
+package game
+
+import (
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"slices"
+)
+
+// Hint is the advisor's suggestion of what to do next
+type Hint struct {
+	Action Action
+	// Reason explains the suggestion in a few words
+	Reason i18n.Message
+}
+
+// shownHint is a hint on screen for a player, until they move on from the
+// phase or decision it was for
+type shownHint struct {
+	player int
+	at     any
+	reason i18n.Message
+}
+
+// Hint asks the advisor, which plays like a Hard HeuristicBot and sees only
+// what the player does, what the player should do now
+func (g *Game) Hint(player int) (Hint, bool) {
+	if player < 0 || player >= len(g.Players) || !slices.Contains(g.WaitingOn(), player) {
+		return Hint{}, false
+	}
+	advisor := NewHeuristicBot(Hard)
+	view := BotView{game: g, Me: player}
+	action := askBot(advisor, view)
+	if action == nil {
+		return Hint{}, false
+	}
+	return Hint{Action: action, Reason: advisor.explain(view, action)}, true
+}
+
+// ShowHint points the cursor at the advisor's suggestion and explains it in
+// the help line, for the player acting
+func (g *Game) ShowHint(requestPlayer *int) {
+	player := g.playerPerspective(requestPlayer)
+	hint, ok := g.Hint(player)
+	if !ok {
+		return
+	}
+	var at any = g.phase
+	if d := g.decisionFor(player); d != nil {
+		at = d
+		if discard, ok := d.(*discardDecision); ok {
+			discard.discard = hint.Action.(Discard).Resources
+		}
+	} else {
+		pointAt(g.phase, hint.Action)
+	}
+	g.hint = &shownHint{player: player, at: at, reason: hint.Reason}
+}
+
+// hintText returns the hint shown to the player, if it's still current
+func (g *Game) hintText(l i18n.Locale, player int) string {
+	h := g.hint
+	if h == nil || h.player != player {
+		return ""
+	}
+	if d := g.decisionFor(player); (d != nil && h.at != d) || (d == nil && h.at != g.phase) {
+		return ""
+	}
+	return l.T("hint.shown", h.reason)
+}
+
+// pointAt moves the phase's cursor, or selects the option of its menu, that
+// leads to the action
+func pointAt(phase Phase, action Action) {
+	switch p := phase.(type) {
+	case *phaseInitialSettlements:
+		if a, ok := action.(BuildSettlement); ok {
+			p.cursorCross = a.At
+		}
+	case *phaseInitialRoad:
+		if a, ok := action.(BuildRoad); ok {
+			p.cursorCross = a.Road.To
+		}
+	case *phaseDiceRoll:
+		if _, ok := action.(PlayDevCard); ok {
+			p.selected = 1
+		} else {
+			p.selected = 0
+		}
+	case *phaseIdle:
+		switch action.(type) {
+		case BuildRoad, BuildSettlement, BuildCity, BuyDevCard:
+			p.selected = 0
+		case Trade:
+			p.selected = 1
+		case PlayDevCard:
+			p.selected = 2
+		default:
+			p.selected = 3
+		}
+	case *phaseBuilding:
+		switch action.(type) {
+		case BuildRoad:
+			p.selected = 0
+		case BuildSettlement:
+			p.selected = 1
+		case BuildCity:
+			p.selected = 2
+		case BuyDevCard:
+			p.selected = 3
+		default:
+			p.selected = len(p.options) - 1
+		}
+	case *phasePlayDevelopmentCard:
+		if a, ok := action.(PlayDevCard); ok {
+			p.selected = p.option(a.Card)
+		} else {
+			p.selected = len(p.options) - 1
+		}
+	case *phaseSettlementPlacement:
+		if a, ok := action.(BuildSettlement); ok {
+			p.cursorCross = a.At
+		}
+	case *phaseCityPlacement:
+		if a, ok := action.(BuildCity); ok {
+			p.cursorCross = a.At
+		}
+	case *phaseRoadStart:
+		if a, ok := action.(BuildRoad); ok {
+			p.cursorCross = a.Road.From
+		}
+	case *phaseRoadEnd:
+		if a, ok := action.(BuildRoad); ok && a.Road.From == p.startCross {
+			p.cursorCross = a.Road.To
+		}
+	case *phasePlaceRobber:
+		if a, ok := action.(MoveRobber); ok {
+			p.tileCoord = a.To
+		}
+	case *phaseStealCard:
+		if a, ok := action.(Steal); ok {
+			p.selected = max(slices.Index(p.victims, a.From), 0)
+		}
+	case *phaseMonopoly:
+		if a, ok := action.(PickResource); ok {
+			p.selected = p.option(a.Resource)
+		}
+	case *phaseYearOfPlenty:
+		if a, ok := action.(PickResource); ok {
+			p.selected = p.option(a.Resource)
+		}
+	}
+}
+
+// explain tells why the bot would take the action, in terms a new player can
+// check on the board
+func (b *HeuristicBot) explain(view BotView, action Action) i18n.Message {
+	goal := b.goal(view)
+	switch a := action.(type) {
+	case BuildSettlement:
+		cross := b.describeCross(view, a.At)
+		if _, initial := view.game.phase.(*phaseInitialSettlements); initial && b.Book != nil {
+			if stats, ok := b.Book.Lookup(view, a.At); ok {
+				return i18n.M("hint.settle_book", cross, stats.WinRate()*100, stats.Games)
+			}
+		}
+		return i18n.M("hint.settle", cross)
+	case BuildCity:
+		return i18n.M("hint.city", view.Production(a.At))
+	case BuildRoad:
+		if view.CanSettle(a.Road.To) {
+			return i18n.M("hint.road", view.Production(a.Road.To))
+		}
+		return i18n.M("hint.road_best")
+	case BuyDevCard:
+		return i18n.M("hint.buy_card")
+	case PlayDevCard:
+		switch a.Card {
+		case DevCardKnight:
+			return i18n.M("hint.knight")
+		case DevCardRoadBuilding:
+			return i18n.M("hint.road_building")
+		default:
+			return i18n.M("hint.play_card", a.Card, countResources(b.missingFor(view, goal)), goalName(goal))
+		}
+	case PickResource:
+		return i18n.M("hint.take", a.Resource, goalName(goal))
+	case RollDice:
+		return i18n.M("hint.roll")
+	case MoveRobber:
+		pips := board.Pips(view.Tiles()[a.To].DiceNumber)
+		var names i18n.List
+		for _, player := range slices.Compact(view.PlayersAround(a.To)) {
+			names = append(names, view.game.Players[player].Name)
+		}
+		if len(names) == 0 {
+			return i18n.M("hint.robber", pips)
+		}
+		return i18n.M("hint.block", pips, names)
+	case Steal:
+		return i18n.M("hint.steal", view.game.Players[a.From].Name, view.PublicVictoryPoints(a.From), view.HandSize(a.From))
+	case Discard:
+		return i18n.M("hint.discard", resourceCounts(a.Resources), goalName(goal))
+	case Trade:
+		return i18n.M("hint.trade", resourceCounts(a.Offer), resourceCounts(a.Request), goalName(goal))
+	default:
+		if missing := b.missingFor(view, goal); len(missing) > 0 {
+			return i18n.M("hint.save", goalName(goal), countResources(missing))
+		}
+		return i18n.M("hint.end_turn")
+	}
+}
+
+// describeCross counts the pips of a crossing and the resources it adds to
+// those the player already produces
+func (b *HeuristicBot) describeCross(view BotView, cross board.CrossCoord) i18n.Message {
+	owned := make(map[board.ResourceType]bool)
+	for _, settlement := range view.CitySpots() {
+		for _, resource := range view.ResourcesAt(settlement) {
+			owned[resource] = true
+		}
+	}
+	var resources, added i18n.List
+	for _, resource := range view.ResourcesAt(cross) {
+		resources = append(resources, resource)
+		if !owned[resource] {
+			owned[resource] = true
+			added = append(added, resource)
+		}
+	}
+	pips := view.Production(cross)
+	if len(added) > 0 && len(view.CitySpots()) > 0 {
+		return i18n.M("hint.cross_adding", pips, resources, added)
+	} else if len(added) > 1 {
+		return i18n.M("hint.cross_different", pips, resources, len(added))
+	}
+	return i18n.M("hint.cross", pips, resources)
+}
+
+func goalName(goal MoveKind) i18n.Message {
+	switch goal {
+	case MoveBuildCity:
+		return i18n.M("goal.city")
+	case MoveBuildSettlement:
+		return i18n.M("goal.settlement")
+	case MoveBuildRoad:
+		return i18n.M("goal.road")
+	default:
+		return i18n.M("goal.dev_card")
+	}
+}
+
+// resourceCounts are cards by resource, read like "2 Wood and 1 Ore"
+type resourceCounts map[board.ResourceType]int
+
+func countResources(resources []board.ResourceType) resourceCounts {
+	counts := make(resourceCounts)
+	for _, resource := range resources {
+		counts[resource]++
+	}
+	return counts
+}
+
+func (counts resourceCounts) Localize(l i18n.Locale) string {
+	var parts i18n.List
+	for _, resource := range board.RESOURCE_TYPES {
+		if count := counts[resource]; count > 0 {
+			parts = append(parts, i18n.M("amount", count, resource))
+		}
+	}
+	return parts.Localize(l)
+}
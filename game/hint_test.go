@@ -0,0 +1,73 @@
+package game
+
+import (
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"strings"
+	"testing"
+)
+
+func TestHintPointsAtTheInitialSettlement(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+
+	hint, ok := game.Hint(0)
+	if !ok {
+		t.Fatalf("Expected a hint for the first settlement")
+	}
+	settlement, ok := hint.Action.(BuildSettlement)
+	if !ok {
+		t.Fatalf("Expected a settlement to be suggested, got %T", hint.Action)
+	}
+	if best := NewHeuristicBot(Hard).InitialSettlement(BotView{game: game, Me: 0}); settlement.At != best {
+		t.Errorf("Expected the hard bot's choice %v, got %v", best, settlement.At)
+	}
+	if !strings.Contains(hint.Reason.Localize(i18n.English), "pips") {
+		t.Errorf("Expected the pips in the reason, got %q", hint.Reason)
+	}
+
+	game.ShowHint(nil)
+	if cursor := game.phase.BoardCursor(); cursor != settlement.At {
+		t.Errorf("Expected the cursor on %v, got %v", settlement.At, cursor)
+	}
+	if !strings.Contains(game.helpText(Viewport{Width: 200}, 0), "Hint:") {
+		t.Errorf("Expected the hint in the help line")
+	}
+	if strings.Contains(game.helpText(Viewport{Width: 200}, 1), "Hint:") {
+		t.Errorf("Expected the hint to be shown to the player who asked only")
+	}
+
+	game.ConfirmAction(nil)
+	if strings.Contains(game.helpText(Viewport{Width: 200}, 0), "Hint:") {
+		t.Errorf("Expected the hint gone once the settlement is placed")
+	}
+}
+
+func TestHintPicksTheMonopolyResource(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.Players[0].AddResource(board.ResourceWheat)
+	game.Players[0].AddResource(board.ResourceOre)
+	game.phase = PhaseMonopoly(game, PhaseIdle(game))
+
+	game.ShowHint(nil)
+	hint, _ := game.Hint(0)
+	if pick, ok := hint.Action.(PickResource); !ok || pick.Resource != board.ResourceSheep {
+		t.Fatalf("Expected the wool missing for a development card, got %v", hint.Action)
+	}
+	monopoly := game.phase.(*phaseMonopoly)
+	if monopoly.options[monopoly.selected] != board.ResourceSheep {
+		t.Errorf("Expected Wool selected, got %s", monopoly.options[monopoly.selected])
+	}
+	if !strings.Contains(hint.Reason.Localize(i18n.English), "development card") {
+		t.Errorf("Expected the reason to name the goal, got %q", hint.Reason)
+	}
+}
+
+func TestNoHintForPlayersNotActing(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	if _, ok := game.Hint(1); ok {
+		t.Errorf("Expected no hint for a player the game isn't waiting on")
+	}
+}
@@ -0,0 +1,235 @@
+package game
+
+import (
+	"el_poblador/board"
+	"slices"
+)
+
+// LegalActions lists every action the player can take right now. Apply
+// accepts each of them, and refuses anything else.
+func (g *Game) LegalActions(player int) []Action {
+	if player < 0 || player >= len(g.Players) || g.isOver() {
+		return nil
+	}
+	if d := g.decisionFor(player); d != nil {
+		if discard, ok := d.(*discardDecision); ok {
+			return g.legalDiscards(player, discard.amount)
+		}
+		return nil
+	}
+	if !g.phaseWaitsOn(player) {
+		return nil
+	}
+
+	var actions []Action
+	hand := &g.Players[player]
+	switch p := g.phase.(type) {
+	case *phaseInitialSettlements:
+		for _, at := range g.settlementSpots(player, true) {
+			actions = append(actions, BuildSettlement{At: at})
+		}
+	case *phaseInitialRoad:
+		for _, to := range g.initialRoadSpots(p.sourceCross) {
+			actions = append(actions, BuildRoad{Road: board.PathCoord{From: p.sourceCross, To: to}})
+		}
+	case *phaseDiceRoll:
+		actions = append(actions, RollDice{})
+		if slices.Contains(hand.HiddenDevCards, DevCardKnight) {
+			actions = append(actions, PlayDevCard{Card: DevCardKnight})
+		}
+	case *phasePlaceRobber:
+		for _, tile := range allTileCoords() {
+			if _, ok := g.Board.Tiles[tile]; ok && tile != g.Board.GetRobber() {
+				actions = append(actions, MoveRobber{To: tile})
+			}
+		}
+	case *phaseStealCard:
+		for _, victim := range p.victims {
+			actions = append(actions, Steal{From: victim})
+		}
+	case *phaseMonopoly, *phaseYearOfPlenty:
+		for _, resource := range board.RESOURCE_TYPES {
+			actions = append(actions, PickResource{Resource: resource})
+		}
+	case *phaseRoadStart, *phaseRoadEnd:
+		if !inTurn(p) {
+			// free roads from Road Building
+			for _, road := range g.roadSpots(player) {
+				actions = append(actions, BuildRoad{Road: road})
+			}
+		}
+	}
+	if inTurn(g.phase) {
+		actions = append(actions, g.legalTurnActions(player)...)
+	}
+	return actions
+}
+
+func (g *Game) legalTurnActions(player int) []Action {
+	hand := &g.Players[player]
+	var actions []Action
+	if hand.CanBuildRoad() {
+		for _, road := range g.roadSpots(player) {
+			actions = append(actions, BuildRoad{Road: road})
+		}
+	}
+	if hand.CanBuildSettlement() {
+		for _, at := range g.settlementSpots(player, false) {
+			actions = append(actions, BuildSettlement{At: at})
+		}
+	}
+	if hand.CanBuildCity() {
+		for _, at := range g.citySpots(player) {
+			actions = append(actions, BuildCity{At: at})
+		}
+	}
+	if hand.CanBuyDevelopmentCard() && len(g.DevCardDeck) > 0 {
+		actions = append(actions, BuyDevCard{})
+	}
+	var played []DevCard
+	for _, card := range hand.HiddenDevCards {
+		if card == DevCardVictoryPoint || slices.Contains(played, card) {
+			continue
+		}
+		// Road Building would leave the player stuck with nowhere to build
+		if card != DevCardRoadBuilding || len(g.roadSpots(player)) > 0 {
+			played = append(played, card)
+			actions = append(actions, PlayDevCard{Card: card})
+		}
+	}
+	for _, give := range board.RESOURCE_TYPES {
+		if hand.Resources[give] < 4 {
+			continue
+		}
+		for _, get := range board.RESOURCE_TYPES {
+			if get != give {
+				actions = append(actions, Trade{
+					Offer:   map[board.ResourceType]int{give: 4},
+					Request: map[board.ResourceType]int{get: 1},
+				})
+			}
+		}
+	}
+	return append(actions, EndTurn{})
+}
+
+// legalDiscards lists every way to give up amount cards of the player's hand
+func (g *Game) legalDiscards(player int, amount int) []Action {
+	hand := g.Players[player].Resources
+	var actions []Action
+	discard := make(map[board.ResourceType]int)
+	var choose func(i, left int)
+	choose = func(i, left int) {
+		if i == len(board.RESOURCE_TYPES) {
+			if left == 0 {
+				resources := make(map[board.ResourceType]int)
+				for resource, count := range discard {
+					if count > 0 {
+						resources[resource] = count
+					}
+				}
+				actions = append(actions, Discard{Resources: resources})
+			}
+			return
+		}
+		resource := board.RESOURCE_TYPES[i]
+		for count := 0; count <= min(left, hand[resource]); count++ {
+			discard[resource] = count
+			choose(i+1, left-count)
+		}
+		discard[resource] = 0
+	}
+	choose(0, amount)
+	return actions
+}
+
+// canBuildSettlement checks a settlement the player would pay for
+func (g *Game) canBuildSettlement(at board.CrossCoord, player int) bool {
+	return at.IsInBounds() && g.Board.CanPlaceSettlementForPlayer(at, player)
+}
+
+// canBuildRoad checks a road the player would build after the initial ones
+func (g *Game) canBuildRoad(road board.PathCoord, player int) bool {
+	return validRoad(road) && g.Board.CanPlaceRoad(board.NewPathCoord(road.From, road.To), player)
+}
+
+// canBuildInitialRoad checks a road leaving the settlement just placed
+func (g *Game) canBuildInitialRoad(road board.PathCoord, settlement board.CrossCoord) bool {
+	if road.From != settlement && road.To != settlement || !validRoad(road) {
+		return false
+	}
+	_, taken := g.Board.Roads[board.NewPathCoord(road.From, road.To)]
+	return !taken
+}
+
+// validRoad checks that the road joins two neighboring crossings of the board
+func validRoad(road board.PathCoord) bool {
+	return road.From.IsInBounds() && road.To.IsInBounds() && slices.Contains(road.From.Neighbors(), road.To)
+}
+
+// settlementSpots lists where the player can settle, anywhere free for the
+// initial settlements and along their roads afterwards
+func (g *Game) settlementSpots(player int, initial bool) []board.CrossCoord {
+	var spots []board.CrossCoord
+	for _, at := range allCrossCoords() {
+		if initial && g.Board.CanPlaceSettlement(at) || !initial && g.canBuildSettlement(at, player) {
+			spots = append(spots, at)
+		}
+	}
+	return spots
+}
+
+// citySpots lists the player's settlements that can become cities
+func (g *Game) citySpots(player int) []board.CrossCoord {
+	var spots []board.CrossCoord
+	for _, at := range allCrossCoords() {
+		if g.Board.CanUpgradeToCity(at, player) {
+			spots = append(spots, at)
+		}
+	}
+	return spots
+}
+
+// roadSpots lists where the player can build a road, once each, From being an
+// end already connected to their roads or settlements
+func (g *Game) roadSpots(player int) []board.PathCoord {
+	var spots []board.PathCoord
+	seen := make(map[board.PathCoord]bool)
+	for _, from := range allCrossCoords() {
+		if !g.Board.HasRoadConnected(from, player) && !g.Board.HasSettlementAt(from, player) {
+			continue
+		}
+		for _, to := range from.Neighbors() {
+			road := board.PathCoord{From: from, To: to}
+			if path := board.NewPathCoord(from, to); !seen[path] && g.canBuildRoad(road, player) {
+				seen[path] = true
+				spots = append(spots, road)
+			}
+		}
+	}
+	return spots
+}
+
+// initialRoadSpots lists the free crossings next to a new settlement
+func (g *Game) initialRoadSpots(settlement board.CrossCoord) []board.CrossCoord {
+	var spots []board.CrossCoord
+	for _, to := range settlement.Neighbors() {
+		if g.canBuildInitialRoad(board.PathCoord{From: settlement, To: to}, settlement) {
+			spots = append(spots, to)
+		}
+	}
+	return spots
+}
+
+// allTileCoords lists every tile on the board
+func allTileCoords() []board.TileCoord {
+	var coords []board.TileCoord
+	for x := 0; x <= 5; x++ {
+		for y := 0; y <= 10; y++ {
+			if coord, valid := board.NewTileCoord(x, y); valid {
+				coords = append(coords, coord)
+			}
+		}
+	}
+	return coords
+}
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"el_poblador/board"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// Plays random legal actions, each of which Apply has to accept
+func TestLegalActionsAreAccepted(t *testing.T) {
+	for seed := uint64(0); seed < 5; seed++ {
+		rng := rand.New(rand.NewPCG(seed, seed))
+		game := &Game{}
+		game.Seed(seed)
+		game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+		for step := 0; step < 3000 && !game.isOver(); step++ {
+			waiting := game.WaitingOn()
+			if len(waiting) == 0 {
+				t.Fatalf("Seed %d: nobody to wait on in %T", seed, game.phase)
+			}
+			player := waiting[rng.IntN(len(waiting))]
+			actions := game.LegalActions(player)
+			if len(actions) == 0 {
+				t.Fatalf("Seed %d: no legal actions for p%d in %T", seed, player+1, game.phase)
+			}
+			action := actions[rng.IntN(len(actions))]
+			// end the turn less often so the game moves along
+			if _, ok := action.(EndTurn); ok && len(actions) > 1 && rng.IntN(4) > 0 {
+				action = actions[rng.IntN(len(actions)-1)]
+			}
+			if err := game.Apply(player, action); err != nil {
+				t.Fatalf("Seed %d: legal action %#v refused in %T: %v", seed, action, game.phase, err)
+			}
+		}
+	}
+}
+
+func TestIllegalActionsAreRefused(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	at := board.CrossCoord{X: 2, Y: 4}
+	if err := game.Apply(1, BuildSettlement{At: at}); err != ErrNotWaitingOn {
+		t.Errorf("Expected p2's settlement refused during p1's placement, got %v", err)
+	}
+	if err := game.Apply(0, BuildSettlement{At: at}); err != nil {
+		t.Fatalf("Expected p1's settlement, got %v", err)
+	}
+	if err := game.Apply(0, game.LegalActions(0)[0]); err != nil {
+		t.Fatalf("Expected p1's road, got %v", err)
+	}
+
+	for _, action := range []Action{
+		BuildSettlement{At: at},
+		BuildSettlement{At: at.Neighbors()[0]},
+		RollDice{},
+	} {
+		if slices.Contains(game.LegalActions(1), action) {
+			t.Fatalf("Expected %#v to be illegal for p2", action)
+		}
+		if err := game.Apply(1, action); err == nil {
+			t.Errorf("Expected %#v refused, it isn't among the legal actions", action)
+		}
+	}
+	if len(game.Board.Settlements) != 1 || game.PlayerTurn != 1 {
+		t.Errorf("Expected the refused actions to change nothing")
+	}
+}
+
+func TestLegalActionsOnlyForWaitingPlayers(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	if actions := game.LegalActions(1); actions != nil {
+		t.Errorf("Expected no actions for p2 during p1's placement, got %d", len(actions))
+	}
+	if actions := game.LegalActions(0); len(actions) != len(allCrossCoords()) {
+		t.Errorf("Expected every crossing to be open for the first settlement, got %d", len(actions))
+	}
+}
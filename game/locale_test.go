@@ -0,0 +1,68 @@
+package game
+
+import (
+	"bytes"
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"encoding/gob"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestEachViewerReadsTheirOwnLanguage(t *testing.T) {
+	game := &Game{}
+	game.Start([]string{"Ana", "Bob", "Cleo"})
+	game.phase = PhaseIdle(game)
+	first := game.PlayerTurn
+	if err := game.Apply(first, EndTurn{}); err != nil {
+		t.Fatal(err)
+	}
+	viewer := game.PlayerTurn
+	next := game.Players[viewer].Name
+
+	english := ansi.Strip(game.Render(Viewport{Width: 160, Height: 50, Player: &viewer}))
+	spanish := ansi.Strip(game.Render(Viewport{Width: 160, Height: 50, Player: &viewer, Locale: i18n.Spanish}))
+	for _, want := range []string{"Turn passed to " + next, "Time to roll the dice", "Your resources:"} {
+		if !strings.Contains(english, want) {
+			t.Errorf("Expected %q in English, got\n%s", want, english)
+		}
+	}
+	for _, want := range []string{"Turno de " + next, "Hora de tirar los dados", "Tus recursos:", "Tirar los dados"} {
+		if !strings.Contains(spanish, want) {
+			t.Errorf("Expected %q in Spanish, got\n%s", want, spanish)
+		}
+	}
+	if strings.Contains(spanish, "Turn passed") {
+		t.Errorf("Expected no English in the Spanish log, got\n%s", spanish)
+	}
+
+	// the board is drawn in place in every language
+	englishBoard := game.Board.Print(nil, board.PrintOptions{})
+	spanishBoard := game.Board.Print(nil, board.PrintOptions{Locale: i18n.Spanish})
+	for i := range englishBoard {
+		if ansi.StringWidth(spanishBoard[i]) != ansi.StringWidth(englishBoard[i]) {
+			t.Errorf("Expected board line %d to keep its width %d, got %d", i, ansi.StringWidth(englishBoard[i]), ansi.StringWidth(spanishBoard[i]))
+		}
+	}
+}
+
+func TestLogIsSavedAsMessages(t *testing.T) {
+	game := &Game{}
+	game.Start([]string{"Ana", "Bob", "Cleo"})
+	game.LogAction("log.rolled", playerName(1), countResources(nil), 7)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(game); err != nil {
+		t.Fatal(err)
+	}
+	var loaded Game
+	if err := gob.NewDecoder(&buf).Decode(&loaded); err != nil {
+		t.Fatal(err)
+	}
+	want := game.Players[1].Name + " obtuvo nada con la tirada (7)"
+	if got := ansi.Strip(loaded.LogLines(i18n.Spanish)[0]); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"el_poblador/i18n"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// LogEvent is a kind of entry in the action log, to filter it by
+type LogEvent string
+
+const (
+	LogAnyEvent   LogEvent = ""
+	LogProduction LogEvent = "production"
+	LogTrades     LogEvent = "trades"
+	LogRobber     LogEvent = "robber"
+	LogBuilds     LogEvent = "builds"
+	LogCards      LogEvent = "cards"
+	LogTurns      LogEvent = "turns"
+)
+
+// LogEvents are the kinds of entries, in the order the filter cycles them
+var LogEvents = []LogEvent{LogAnyEvent, LogProduction, LogTrades, LogRobber, LogBuilds, LogCards, LogTurns}
+
+// Next returns the kind that follows, cycling back to any after the last
+func (e LogEvent) Next() LogEvent {
+	return LogEvents[(slices.Index(LogEvents, e)+1)%len(LogEvents)]
+}
+
+// logEvents sorts the logged messages by kind. Cards that bring resources
+// or roads are both.
+var logEvents = map[string][]LogEvent{
+	"log.rolled":            {LogProduction},
+	"log.initial_resources": {LogProduction},
+	"log.monopoly":          {LogProduction, LogCards},
+	"log.monopoly_nothing":  {LogProduction, LogCards},
+	"log.year_of_plenty":    {LogProduction, LogCards},
+	"log.bank_trade":        {LogTrades},
+	"log.robber":            {LogRobber},
+	"log.steal":             {LogRobber},
+	"log.discard":           {LogRobber},
+	"log.road":              {LogBuilds},
+	"log.free_road":         {LogBuilds, LogCards},
+	"log.settlement":        {LogBuilds},
+	"log.city":              {LogBuilds},
+	"log.bought_card":       {LogCards},
+	"log.played":            {LogCards},
+	"log.turn":              {LogTurns},
+	"log.out_of_time":       {LogTurns},
+}
+
+// LogFilter picks the entries of the action log to show. Empty fields let
+// every entry through.
+type LogFilter struct {
+	// Player is the name of a player the entry has to mention
+	Player string `json:"player,omitempty"`
+	// Event is the kind of entry
+	Event LogEvent `json:"event,omitempty"`
+	// Search is text the entry has to contain, in any case
+	Search string `json:"search,omitempty"`
+}
+
+func (g *Game) logMatches(message i18n.Message, line string, f LogFilter) bool {
+	if f.Player != "" && !slices.ContainsFunc(message.Args, func(arg any) bool {
+		player, ok := arg.(playerName)
+		return ok && int(player) < len(g.Players) && strings.EqualFold(g.Players[player].Name, f.Player)
+	}) {
+		return false
+	}
+	if f.Event != LogAnyEvent && !slices.Contains(logEvents[message.Key], f.Event) {
+		return false
+	}
+	return f.Search == "" || strings.Contains(strings.ToLower(ansi.Strip(line)), strings.ToLower(f.Search))
+}
+
+// logEntries writes the entries of the action log the viewport shows,
+// newest first. Until the log is focused that is all of them.
+func (g *Game) logEntries(v Viewport) []string {
+	var lines []string
+	for i := len(g.Log) - 1; i >= 0; i-- {
+		line := g.logLine(v.Locale, g.Log[i])
+		if !v.LogFocused || g.logMatches(g.Log[i], line, v.LogFilter) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// LogLength counts the entries of the action log the viewport's filter lets
+// through, which is as far as it can be scrolled.
+func (g *Game) LogLength(v Viewport) int {
+	v.LogFocused = true
+	return len(g.logEntries(v))
+}
+
+// buildLog renders the inner log pane. Focused, it tells what the filter
+// shows above the entries and what is searched for below them.
+func (g *Game) buildLog(v Viewport, width, height int) string {
+	if !v.LogFocused {
+		var lines []string
+		for i := len(g.Log) - 1; i >= 0 && len(lines) < height; i-- {
+			lines = append(lines, g.logLine(v.Locale, g.Log[i]))
+		}
+		return strings.Join(lines, "\n")
+	}
+	l := v.Locale
+	faint := lipgloss.NewStyle().Faint(true)
+	entries := g.logEntries(v)
+	scroll := max(min(v.LogScroll, len(entries)-1), 0)
+
+	player, event := l.T("log.filter_all"), l.T("log.filter_all")
+	if v.LogFilter.Player != "" {
+		player = v.LogFilter.Player
+	}
+	if v.LogFilter.Event != LogAnyEvent {
+		event = l.T("log.event_" + string(v.LogFilter.Event))
+	}
+	header := l.T("log.filter", player, event)
+	if len(entries) > 0 {
+		header += "  " + fmt.Sprintf("%d/%d", scroll+1, len(entries))
+	}
+
+	var search string
+	switch {
+	case v.LogSearching:
+		search = "/" + v.LogFilter.Search + "█"
+	case v.LogFilter.Search != "":
+		search = faint.Render("/" + v.LogFilter.Search)
+	default:
+		search = faint.Render(l.T("log.search_placeholder"))
+	}
+
+	room := max(height-2, 0)
+	var lines []string
+	if len(entries) == 0 {
+		lines = []string{faint.Render(l.T("log.no_entries"))}
+	} else {
+		// keep the entries that fit after wrapping
+		shown := entries[scroll:min(scroll+room, len(entries))]
+		wrapped := lipgloss.NewStyle().Width(width).Render(strings.Join(shown, "\n"))
+		lines = strings.Split(wrapped, "\n")
+	}
+	lines = lines[:min(len(lines), room)]
+	for len(lines) < room {
+		lines = append(lines, "")
+	}
+	lines = append([]string{lipgloss.NewStyle().Bold(true).MaxWidth(width).Render(header)}, lines...)
+	lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(search))
+	return strings.Join(lines, "\n")
+}
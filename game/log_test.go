@@ -0,0 +1,67 @@
+package game
+
+import (
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestLogFilters(t *testing.T) {
+	game := &Game{}
+	game.Start([]string{"Ana", "Bob", "Cleo"})
+	game.LogAction("log.rolled", playerName(0), countResources([]board.ResourceType{board.ResourceBrick}), 6)
+	game.LogAction("log.road", playerName(1))
+	game.LogAction("log.steal", playerName(1), playerName(0))
+	game.LogAction("log.rolled", playerName(2), countResources([]board.ResourceType{board.ResourceWood}), 8)
+	game.LogAction("log.turn", playerName(2))
+	first, second, third := game.Players[0].Name, game.Players[1].Name, game.Players[2].Name
+
+	for _, test := range []struct {
+		filter LogFilter
+		want   int
+	}{
+		{LogFilter{}, 5},
+		{LogFilter{Player: strings.ToLower(first)}, 2},
+		{LogFilter{Player: second, Event: LogRobber}, 1},
+		{LogFilter{Event: LogProduction}, 2},
+		{LogFilter{Search: "BRICK"}, 1},
+		{LogFilter{Player: third, Event: LogBuilds}, 0},
+	} {
+		if got := game.LogLength(Viewport{LogFilter: test.filter}); got != test.want {
+			t.Errorf("Expected %+v to let %d entries through, got %d", test.filter, test.want, got)
+		}
+	}
+	spanish := Viewport{Locale: i18n.Spanish, LogFilter: LogFilter{Search: "arcilla"}}
+	if got := game.LogLength(spanish); got != 1 {
+		t.Errorf("Expected the search in the viewer's language, got %d entries", got)
+	}
+}
+
+func TestFocusedLogScrollsThroughEverything(t *testing.T) {
+	game := &Game{}
+	game.Start([]string{"Ana", "Bob", "Cleo"})
+	for i := range 40 {
+		game.LogAction("log.discard", playerName(i%3), i)
+	}
+
+	first, second := game.Players[0].Name, game.Players[1].Name
+
+	unfocused := ansi.Strip(game.Render(Viewport{Width: 160, Height: 50}))
+	if strings.Contains(unfocused, "discarded 0 cards") {
+		t.Errorf("Expected the oldest entries out of the unfocused pane")
+	}
+
+	v := Viewport{Width: 160, Height: 50, LogFocused: true, LogScroll: 100, LogFilter: LogFilter{Player: first}}
+	focused := ansi.Strip(game.Render(v))
+	for _, want := range []string{"Player: " + first + "  Event: all  14/14", first + " discarded 0 cards", "esc back to the game"} {
+		if !strings.Contains(focused, want) {
+			t.Errorf("Expected %q scrolled to the end, got\n%s", want, focused)
+		}
+	}
+	if strings.Contains(focused, second+" discarded") {
+		t.Errorf("Expected only %s's entries, got\n%s", first, focused)
+	}
+}
@@ -0,0 +1,285 @@
+package game
+
+import (
+	"el_poblador/board"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// MCTSBot searches the legal actions with Monte Carlo tree search. Every
+// playout deals the cards it can't see anew (see DeterminizedClone) and plays
+// on with Normal HeuristicBots for about a round, then scores the players by
+// their points and production. It keeps to what a Hard HeuristicBot would do
+// unless the search finds something clearly better.
+type MCTSBot struct {
+	*HeuristicBot
+	// Playouts caps the playouts per decision, 0 for no cap
+	Playouts int
+	// Budget caps the time per decision, 0 for no cap
+	Budget time.Duration
+}
+
+// NewMCTSBot searches for up to budget per decision
+func NewMCTSBot(budget time.Duration) *MCTSBot {
+	return &MCTSBot{HeuristicBot: NewHeuristicBot(Hard), Budget: budget}
+}
+
+// NewMCTSBotSeeded searches like NewMCTSBot, drawing the hidden cards and the
+// playouts' random choices from the seed. With no budget it takes the same
+// decisions in the same games.
+func NewMCTSBotSeeded(budget time.Duration, seed uint64) *MCTSBot {
+	return &MCTSBot{HeuristicBot: NewHeuristicBotSeeded(Hard, seed), Budget: budget}
+}
+
+const (
+	// defaultPlayouts is the cap when neither Playouts nor Budget is set
+	defaultPlayouts = 200
+	// rolloutSteps is how long a playout goes on before scoring
+	rolloutSteps = 40
+	// exploration is the UCT constant
+	exploration = 0.2
+	// maxCandidates is how many places to build or rob the search considers,
+	// the best by the heuristics
+	maxCandidates = 6
+	// planMargin is how much better than the heuristics' plan an action has
+	// to score for the bot to stray from it
+	planMargin = 0.05
+)
+
+// mctsNode is an action in the search tree, with the results of the playouts
+// that went through it. The same node stands for the action in every dealing
+// of the hidden cards.
+type mctsNode struct {
+	action   Action
+	player   int // who took the action
+	children map[string]*mctsNode
+	visits   int
+	// available counts the visits to the parent where the action was legal
+	available int
+	score     float64
+}
+
+func actionKey(action Action) string {
+	return fmt.Sprintf("%T%v", action, action)
+}
+
+func sameAction(action Action) func(Action) bool {
+	key := actionKey(action)
+	return func(other Action) bool {
+		return actionKey(other) == key
+	}
+}
+
+func (b *MCTSBot) Act(view BotView, actions []Action) Action {
+	actions = b.candidates(view, actions)
+	planned := askBot(b.HeuristicBot, view)
+	if planned != nil && !slices.ContainsFunc(actions, sameAction(planned)) {
+		if slices.ContainsFunc(view.game.LegalActions(view.Me), sameAction(planned)) {
+			actions = append(actions, planned)
+		} else {
+			planned = nil
+		}
+	}
+	if len(actions) == 1 {
+		return actions[0]
+	}
+	root := &mctsNode{player: view.Me, children: make(map[string]*mctsNode)}
+	playouts := b.Playouts
+	if playouts == 0 && b.Budget == 0 {
+		playouts = defaultPlayouts
+	}
+	start := time.Now()
+	for i := 0; playouts == 0 || i < playouts; i++ {
+		if b.Budget > 0 && time.Since(start) >= b.Budget {
+			break
+		}
+		b.playout(view.game.DeterminizedClone(view.Me, b.rng.Uint64()), root)
+	}
+
+	bestAction, bestVisits := actions[0], -1
+	for _, action := range actions {
+		if child := root.children[actionKey(action)]; child != nil && child.visits > bestVisits {
+			bestAction, bestVisits = action, child.visits
+		}
+	}
+	// the heuristics plan further ahead than the playouts can see, so keep to
+	// them unless the search clearly found better
+	if planned != nil {
+		best, child := root.children[actionKey(bestAction)], root.children[actionKey(planned)]
+		if child != nil && child.visits > 0 && child.mean() >= best.mean()-planMargin {
+			return planned
+		}
+	}
+	return bestAction
+}
+
+func (n *mctsNode) mean() float64 {
+	return n.score / float64(n.visits)
+}
+
+// candidates narrows down where to build and rob, and what to discard, to
+// the choices the heuristics like best, and the bank trades to the one they
+// would make, so the playouts aren't spread too thin
+func (b *MCTSBot) candidates(view BotView, actions []Action) []Action {
+	var points []Action
+	for _, action := range actions {
+		switch action.(type) {
+		case BuildSettlement, BuildCity:
+			points = append(points, action)
+		}
+	}
+	if len(points) > 0 {
+		// a point now beats anything the playouts could find
+		actions = points
+	}
+
+	limit := maxCandidates
+	switch view.game.phase.(type) {
+	case *phaseInitialSettlements, *phaseInitialRoad:
+		// the playouts are too short to judge where to start
+		limit = 1
+	}
+	robber := b.robberScore(view)
+	var discard map[board.ResourceType]int
+	var places, others []Action
+	for _, action := range actions {
+		switch a := action.(type) {
+		case BuildSettlement, BuildRoad, BuildCity, MoveRobber:
+			places = append(places, action)
+		case Trade:
+			// only the trade the plan asks for
+			give, get, ok := b.bankTrade(view, b.goal(view))
+			if ok && a.Offer[give] == 4 && a.Request[get] == 1 {
+				others = append(others, action)
+			}
+		case Discard:
+			if discard == nil {
+				discard = b.HeuristicBot.Discard(view, view.HandSize(view.Me)/2)
+				others = append(others, Discard{Resources: discard})
+			}
+		default:
+			others = append(others, action)
+		}
+	}
+	if len(places) <= limit {
+		return append(places, others...)
+	}
+	prior := func(action Action) int {
+		switch a := action.(type) {
+		case BuildSettlement:
+			return b.settlementScore(view, a.At)
+		case BuildRoad:
+			return b.reach(view, a.Road.To, a.Road.From)
+		case BuildCity:
+			return view.Production(a.At)
+		case MoveRobber:
+			return robber(a.To)
+		}
+		return 0
+	}
+	slices.SortStableFunc(places, func(x, y Action) int {
+		return prior(y) - prior(x)
+	})
+	return append(places[:limit], others...)
+}
+
+// playout walks down the tree, adds one action to it, plays on from there and
+// credits every node on the way with the result
+func (b *MCTSBot) playout(game *Game, node *mctsNode) {
+	path := []*mctsNode{node}
+	for !game.isOver() {
+		waiting := game.WaitingOn()
+		if len(waiting) == 0 {
+			break
+		}
+		player := waiting[0]
+		actions := game.LegalActions(player)
+		if len(actions) == 0 {
+			break
+		}
+		child, expanded := b.choose(node, player, actions)
+		if game.Apply(player, child.action) != nil {
+			break
+		}
+		node = child
+		path = append(path, node)
+		if expanded {
+			break
+		}
+	}
+
+	for player := range game.Players {
+		game.SetBot(player, NewHeuristicBotSeeded(Normal, b.rng.Uint64()))
+	}
+	game.playBots(rolloutSteps)
+	scores := playoutScores(game)
+	for _, node := range path {
+		node.visits++
+		node.score += scores[node.player]
+	}
+}
+
+// choose returns a new child for an action never tried from the node, or the
+// most promising child by UCT
+func (b *MCTSBot) choose(node *mctsNode, player int, actions []Action) (*mctsNode, bool) {
+	var untried []Action
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	for _, action := range actions {
+		child := node.children[actionKey(action)]
+		if child == nil {
+			untried = append(untried, action)
+			continue
+		}
+		child.available++
+		value := child.mean() +
+			exploration*math.Sqrt(math.Log(float64(child.available))/float64(child.visits))
+		if value > bestValue {
+			best, bestValue = child, value
+		}
+	}
+	if len(untried) > 0 {
+		action := untried[b.rng.IntN(len(untried))]
+		child := &mctsNode{action: action, player: player, children: make(map[string]*mctsNode), available: 1}
+		node.children[actionKey(action)] = child
+		return child, true
+	}
+	return best, false
+}
+
+// playoutScores rates each player between 0 and 1: 1 for the winner and 0
+// for the others, and before that by how far ahead of the best of the others
+// they are, in points and in how much their buildings produce
+func playoutScores(game *Game) []float64 {
+	scores := make([]float64, len(game.Players))
+	if winner := game.CheckGameEnd(); winner != nil {
+		scores[game.getPlayerID(winner)] = 1
+		return scores
+	}
+	strength := make([]float64, len(game.Players))
+	for cross, owner := range game.Board.Settlements {
+		ways := BotView{game: game, Me: owner}.Production(cross)
+		if _, city := game.Board.CityUpgrades[cross]; city {
+			ways *= 2
+		}
+		strength[owner] += float64(ways) / 36
+	}
+	for i := range game.Players {
+		player := &game.Players[i]
+		// cards in hand are worth a little, about a point for a settlement's worth
+		strength[i] += float64(player.VictoryPoints(game)) +
+			float64(player.TotalResources())/8 + float64(len(player.HiddenDevCards))/8
+	}
+	for i := range game.Players {
+		best := 0.0
+		for j := range game.Players {
+			if j != i {
+				best = max(best, strength[j])
+			}
+		}
+		scores[i] = min(max(0.5+(strength[i]-best)/10, 0.05), 0.95)
+	}
+	return scores
+}
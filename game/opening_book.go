@@ -0,0 +1,317 @@
+package game
+
+import (
+	"bytes"
+	"compress/gzip"
+	"el_poblador/board"
+	_ "embed"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:generate go run ../cmd/openingbook -o opening_book.gz
+
+// The opening book ranks the crossings to settle first by how often players
+// who settled on one like them went on to win, in games simulated between
+// Normal bots. Crossings are alike when they touch the same resources for the
+// same pips, so the book reads any board from the same generator, and it
+// keeps the counts by how many settlements were placed before, which is the
+// seat order in the first round and the reverse in the second.
+//
+// Regenerate it with `go generate ./game` after changing how boards are dealt.
+
+//go:embed opening_book.gz
+var openingBookData []byte
+
+const (
+	// minBookGames is how many games a spot needs in the book before it is
+	// trusted
+	minBookGames = 30
+	// anyPick counts a spot whatever the order it was settled in
+	anyPick = -1
+	// bookWeight turns a win rate into points of a bot's settlement score,
+	// where a pip is one point; 25 was best in the arena
+	bookWeight = 25
+	// explorePicks is how many of the best spots the simulated games choose
+	// from at random, so the book sees more than the obvious choices
+	explorePicks = 8
+)
+
+// OpeningBook holds the simulated results of initial settlement spots
+type OpeningBook struct {
+	entries map[openingKey]OpeningStats
+}
+
+type openingKey struct {
+	Players int
+	// Pick is how many settlements were on the board, or anyPick
+	Pick int
+	Spot string
+}
+
+// OpeningStats are how many of the simulated games that settled a spot were
+// won by the player who did
+type OpeningStats struct {
+	Games int
+	Wins  int
+}
+
+// WinRate is the share of games won
+func (s OpeningStats) WinRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Games)
+}
+
+// openingEntry is how the book is stored
+type openingEntry struct {
+	Key   openingKey
+	Stats OpeningStats
+}
+
+var (
+	defaultBook     *OpeningBook
+	defaultBookOnce sync.Once
+)
+
+// DefaultOpeningBook returns the book built into the game, or an empty one if
+// it can't be read
+func DefaultOpeningBook() *OpeningBook {
+	defaultBookOnce.Do(func() {
+		book, err := ReadOpeningBook(bytes.NewReader(openingBookData))
+		if err != nil {
+			book = &OpeningBook{entries: make(map[openingKey]OpeningStats)}
+		}
+		defaultBook = book
+	})
+	return defaultBook
+}
+
+// ReadOpeningBook reads a book written by WriteTo
+func ReadOpeningBook(r io.Reader) (*OpeningBook, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read opening book: %w", err)
+	}
+	defer zr.Close()
+	var entries []openingEntry
+	if err := gob.NewDecoder(zr).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("read opening book: %w", err)
+	}
+	book := &OpeningBook{entries: make(map[openingKey]OpeningStats, len(entries))}
+	for _, entry := range entries {
+		book.entries[entry.Key] = entry.Stats
+	}
+	return book, nil
+}
+
+// WriteTo writes the book compressed, in the same order every time
+func (b *OpeningBook) WriteTo(w io.Writer) (int64, error) {
+	entries := make([]openingEntry, 0, len(b.entries))
+	for key, stats := range b.entries {
+		entries = append(entries, openingEntry{Key: key, Stats: stats})
+	}
+	slices.SortFunc(entries, func(a, b openingEntry) int {
+		if a.Key.Players != b.Key.Players {
+			return a.Key.Players - b.Key.Players
+		}
+		if a.Key.Pick != b.Key.Pick {
+			return a.Key.Pick - b.Key.Pick
+		}
+		return strings.Compare(a.Key.Spot, b.Key.Spot)
+	})
+	counter := &countingWriter{w: w}
+	zw := gzip.NewWriter(counter)
+	if err := gob.NewEncoder(zw).Encode(entries); err != nil {
+		return counter.n, err
+	}
+	err := zw.Close()
+	return counter.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Len is how many spots the book has counts for
+func (b *OpeningBook) Len() int {
+	return len(b.entries)
+}
+
+// Lookup returns the counts for settling the crossing now, from the games
+// settled in the same order if there are enough of them, or else from all
+func (b *OpeningBook) Lookup(view BotView, cross board.CrossCoord) (OpeningStats, bool) {
+	key := openingKey{
+		Players: len(view.game.Players),
+		Pick:    len(view.game.Board.Settlements),
+		Spot:    spotSignature(view, cross),
+	}
+	if stats := b.entries[key]; stats.Games >= minBookGames {
+		return stats, true
+	}
+	key.Pick = anyPick
+	stats := b.entries[key]
+	return stats, stats.Games >= minBookGames
+}
+
+// Rank lists the crossings the player can settle that the book knows, best
+// first
+func (b *OpeningBook) Rank(view BotView) []board.CrossCoord {
+	rates := make(map[board.CrossCoord]float64)
+	var spots []board.CrossCoord
+	for _, cross := range view.SettlementSpots() {
+		if stats, ok := b.Lookup(view, cross); ok {
+			rates[cross] = stats.WinRate()
+			spots = append(spots, cross)
+		}
+	}
+	slices.SortStableFunc(spots, func(a, b board.CrossCoord) int {
+		switch {
+		case rates[a] > rates[b]:
+			return -1
+		case rates[a] < rates[b]:
+			return 1
+		}
+		return 0
+	})
+	return spots
+}
+
+// bonus nudges a bot's score for a crossing by how much better than an even
+// share the book says it does. The counts are too thin to trust on their own,
+// so the bot's own judgement still decides between spots far apart.
+func (b *OpeningBook) bonus(view BotView, cross board.CrossCoord) int {
+	stats, ok := b.Lookup(view, cross)
+	if !ok {
+		return 0
+	}
+	return int(bookWeight * (stats.WinRate() - 1/float64(len(view.game.Players))))
+}
+
+// spotSignature describes a crossing by its pips and the resources around
+// it, like "11:023"
+func spotSignature(view BotView, cross board.CrossCoord) string {
+	var resources []int
+	for _, resource := range view.ResourcesAt(cross) {
+		resources = append(resources, slices.Index(board.RESOURCE_TYPES, resource))
+	}
+	slices.Sort(resources)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d:", view.Production(cross))
+	for _, resource := range resources {
+		fmt.Fprintf(&sb, "%d", resource)
+	}
+	return sb.String()
+}
+
+// BookConfig says which games to simulate for a book
+type BookConfig struct {
+	// Players are the table sizes to simulate, the same number of games each
+	Players []int
+	Games   int
+	// Seed deals the first game, the others follow from it
+	Seed uint64
+	// MaxTurns stops games that go on without a winner, which then count as
+	// lost by everyone
+	MaxTurns int
+	// Progress is called after every game, if set
+	Progress func(played, total int)
+}
+
+// BuildOpeningBook simulates games between Normal bots that settle at random
+// among their favorite spots, and counts who won from where
+func BuildOpeningBook(config BookConfig) *OpeningBook {
+	book := &OpeningBook{entries: make(map[openingKey]OpeningStats)}
+	total := config.Games * len(config.Players)
+	played := 0
+	for _, players := range config.Players {
+		for i := 0; i < config.Games; i++ {
+			book.simulate(players, config.Seed+uint64(played), config.MaxTurns)
+			played++
+			if config.Progress != nil {
+				config.Progress(played, total)
+			}
+		}
+	}
+	return book
+}
+
+// simulate plays one game and adds its settlements to the book
+func (b *OpeningBook) simulate(players int, seed uint64, maxTurns int) {
+	seats := make([]Seat, players)
+	for i := range seats {
+		seats[i] = Seat{Name: fmt.Sprintf("Bot %d", i+1)}
+	}
+	g := &Game{}
+	g.Seed(seed)
+	g.StartSeated(seats)
+	var picks []openingPick
+	rng := rand.New(rand.NewPCG(seed, 0))
+	for player := range g.Players {
+		bot := NewHeuristicBotSeeded(Normal, seed*uint64(players)+uint64(player))
+		g.SetBot(player, &openingExplorer{HeuristicBot: bot, rng: rng, picks: &picks})
+	}
+	for !g.isOver() && (maxTurns == 0 || g.TurnsPlayed < maxTurns) {
+		if player, _ := g.waitingBot(); player < 0 {
+			break
+		}
+		g.playBots(100)
+	}
+	winner := -1
+	if w := g.CheckGameEnd(); w != nil {
+		winner = g.getPlayerID(w)
+	}
+	for _, pick := range picks {
+		for _, key := range []openingKey{
+			{Players: players, Pick: pick.order, Spot: pick.spot},
+			{Players: players, Pick: anyPick, Spot: pick.spot},
+		} {
+			stats := b.entries[key]
+			stats.Games++
+			if pick.player == winner {
+				stats.Wins++
+			}
+			b.entries[key] = stats
+		}
+	}
+}
+
+type openingPick struct {
+	player int
+	order  int
+	spot   string
+}
+
+// openingExplorer settles at random among the best spots and notes where
+type openingExplorer struct {
+	*HeuristicBot
+	rng   *rand.Rand
+	picks *[]openingPick
+}
+
+func (e *openingExplorer) InitialSettlement(view BotView) board.CrossCoord {
+	spots := slices.Clone(view.SettlementSpots())
+	slices.SortStableFunc(spots, func(a, b board.CrossCoord) int {
+		return e.settlementScore(view, b) - e.settlementScore(view, a)
+	})
+	cross := spots[e.rng.IntN(min(explorePicks, len(spots)))]
+	*e.picks = append(*e.picks, openingPick{
+		player: view.Me,
+		order:  len(view.game.Board.Settlements),
+		spot:   spotSignature(view, cross),
+	})
+	return cross
+}
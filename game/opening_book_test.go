@@ -0,0 +1,81 @@
+package game
+
+import (
+	"bytes"
+	"el_poblador/i18n"
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestOpeningBookRoundTrip(t *testing.T) {
+	book := BuildOpeningBook(BookConfig{Players: []int{3}, Games: 4, Seed: 1})
+	if book.Len() == 0 {
+		t.Fatalf("Expected the simulated settlements in the book")
+	}
+	games := 0
+	for key, stats := range book.entries {
+		if key.Pick == anyPick {
+			games += stats.Games
+		}
+	}
+	if games != 4*6 {
+		t.Errorf("Expected 6 settlements a game, got %d in 4 games", games)
+	}
+
+	var buf bytes.Buffer
+	if _, err := book.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	read, err := ReadOpeningBook(&buf)
+	if err != nil {
+		t.Fatalf("ReadOpeningBook failed: %v", err)
+	}
+	if read.Len() != book.Len() {
+		t.Errorf("Expected %d spots read back, got %d", book.Len(), read.Len())
+	}
+	for key, stats := range book.entries {
+		if read.entries[key] != stats {
+			t.Errorf("Expected %v for %v, got %v", stats, key, read.entries[key])
+		}
+	}
+}
+
+func TestOpeningBookIsRepeatable(t *testing.T) {
+	config := BookConfig{Players: []int{3}, Games: 4, Seed: 1}
+	first, second := BuildOpeningBook(config), BuildOpeningBook(config)
+	if !maps.Equal(first.entries, second.entries) {
+		t.Errorf("Expected the same seed to build the same book")
+	}
+}
+
+func TestOpeningBookRanksTheSpots(t *testing.T) {
+	book := DefaultOpeningBook()
+	if book.Len() == 0 {
+		t.Fatalf("Expected the built-in book to be read")
+	}
+	game := &Game{}
+	game.Seed(9)
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}, {Name: "p4"}})
+	view := BotView{game: game, Me: 0}
+
+	ranked := book.Rank(view)
+	if len(ranked) == 0 {
+		t.Fatalf("Expected the book to know some of the spots")
+	}
+	for i := 1; i < len(ranked); i++ {
+		before, _ := book.Lookup(view, ranked[i-1])
+		after, _ := book.Lookup(view, ranked[i])
+		if before.WinRate() < after.WinRate() {
+			t.Errorf("Expected the spots best first, got %v before %v", before, after)
+		}
+	}
+
+	hint, ok := game.Hint(0)
+	if !ok {
+		t.Fatalf("Expected a hint for the first settlement")
+	}
+	if _, known := book.Lookup(view, hint.Action.(BuildSettlement).At); known && !strings.Contains(hint.Reason.Localize(i18n.English), "simulated games") {
+		t.Errorf("Expected the book's record in the hint, got %q", hint.Reason)
+	}
+}
@@ -0,0 +1,38 @@
+package game
+
+import (
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"strconv"
+	"strings"
+)
+
+// overlayLegend explains the overlay drawn over the board, empty without one
+func (g *Game) overlayLegend(l i18n.Locale, overlay board.Overlay) string {
+	switch overlay {
+	case board.OverlayPips:
+		return l.T("overlay.pips")
+	case board.OverlayProduction:
+		return l.T("overlay.production")
+	case board.OverlayNetworks:
+		var networks i18n.List
+		for i, player := range g.Players {
+			var lengths []string
+			for _, network := range g.Board.RoadNetworks(i) {
+				lengths = append(lengths, strconv.Itoa(len(network)))
+			}
+			switch {
+			case len(lengths) == 1 && lengths[0] == "1":
+				networks = append(networks, player.Render(l.T("overlay.one_road", player.Name)))
+			case len(lengths) > 0:
+				networks = append(networks, player.Render(l.T("overlay.roads", player.Name, strings.Join(lengths, "+"))))
+			}
+		}
+		var roads i18n.Localizer = networks
+		if len(networks) == 0 {
+			roads = i18n.M("overlay.no_roads")
+		}
+		return l.T("overlay.networks", roads)
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+package game
+
+import (
+	"el_poblador/board"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestOverlayLegendFitsEveryLayout(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.Board.SetRoad(board.NewPathCoord(board.CrossCoord{X: 2, Y: 4}, board.CrossCoord{X: 3, Y: 4}), 1)
+	for _, v := range []Viewport{{Width: 160, Height: 50}, {Width: 100, Height: 45}, {Width: 70, Height: 45, OneColumnCycle: 1}} {
+		v.Overlay = board.OverlayNetworks
+		view := ansi.Strip(game.Render(v))
+		if !strings.Contains(view, "p2 1 road") {
+			t.Errorf("%dx%d: expected the legend to count p2's roads", v.Width, v.Height)
+		}
+		for i, line := range strings.Split(view, "\n") {
+			if ansi.StringWidth(line) > v.Width {
+				t.Errorf("%dx%d: line %d is %d wide", v.Width, v.Height, i, ansi.StringWidth(line))
+			}
+		}
+	}
+}
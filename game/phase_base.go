@@ -2,15 +2,19 @@ package game
 
 import (
 	"el_poblador/board"
+	"el_poblador/i18n"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
 type phaseWithOptions struct {
-	game     *Game
-	options  []string
+	game    *Game
+	options []i18n.Localizer
+	// disabled options are struck through
+	disabled []bool
 	selected int
 }
 
@@ -18,10 +22,14 @@ func (p *phaseWithOptions) BoardCursor() interface{} {
 	return nil
 }
 
-func (p *phaseWithOptions) Menu() string {
+func (p *phaseWithOptions) Menu(l i18n.Locale) string {
 	paddedOptions := make([]string, len(p.options))
 	player := &p.game.Players[p.game.PlayerTurn]
-	for i, option := range p.options {
+	for i, localizer := range p.options {
+		option := localizer.Localize(l)
+		if i < len(p.disabled) && p.disabled[i] {
+			option = strikethroughStyle().Render(option)
+		}
 		if i == p.selected {
 			paddedOptions[i] = player.Render("> ") + option
 		} else {
@@ -41,6 +49,20 @@ func (p *phaseWithOptions) MoveCursor(direction string) {
 	p.selected = (p.selected + len(p.options)) % len(p.options)
 }
 
+// option returns the index of the option, 0 if there is none like it
+func (p *phaseWithOptions) option(option i18n.Localizer) int {
+	return max(slices.Index(p.options, option), 0)
+}
+
+// menuOptions makes a menu of messages
+func menuOptions(keys ...string) []i18n.Localizer {
+	options := make([]i18n.Localizer, len(keys))
+	for i, key := range keys {
+		options[i] = i18n.M(key)
+	}
+	return options
+}
+
 func moveTileCursor(tileCoord board.TileCoord, direction string) (board.TileCoord, bool) {
 	switch direction {
 	case "up":
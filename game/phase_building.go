@@ -2,7 +2,7 @@ package game
 
 import (
 	"el_poblador/board"
-	"fmt"
+	"el_poblador/i18n"
 )
 
 type phaseBuilding struct {
@@ -13,40 +13,22 @@ type phaseBuilding struct {
 func PhaseBuilding(game *Game, previousPhase Phase) Phase {
 	player := &game.Players[game.PlayerTurn]
 
-	// Build the list of available building options
-	var options []string
-	strikethrough := strikethroughStyle()
-
-	if player.CanBuildRoad() {
-		options = append(options, "Road")
-	} else {
-		options = append(options, strikethrough.Render("Road"))
-	}
-
-	if player.CanBuildSettlement() {
-		options = append(options, "Settlement")
-	} else {
-		options = append(options, strikethrough.Render("Settlement"))
-	}
-
-	if player.CanBuildCity() {
-		options = append(options, "City")
-	} else {
-		options = append(options, strikethrough.Render("City"))
+	// Build the list of available building options, striking out those the
+	// player can't afford
+	options := menuOptions("menu.road", "menu.settlement", "menu.city", "menu.dev_card", "menu.cancel_esc")
+	disabled := []bool{
+		!player.CanBuildRoad(),
+		!player.CanBuildSettlement(),
+		!player.CanBuildCity(),
+		!player.CanBuyDevelopmentCard(),
+		false,
 	}
 
-	if player.CanBuyDevelopmentCard() {
-		options = append(options, "Development Card")
-	} else {
-		options = append(options, strikethrough.Render("Development Card"))
-	}
-
-	options = append(options, "Cancel (or 'esc')")
-
 	return &phaseBuilding{
 		phaseWithOptions: phaseWithOptions{
-			game:    game,
-			options: options,
+			game:     game,
+			options:  options,
+			disabled: disabled,
 		},
 		previousPhase: previousPhase,
 	}
@@ -72,23 +54,11 @@ func (p *phaseBuilding) Confirm() Phase {
 		}
 		return p
 	case 3: // Development Card
-		if player.CanBuyDevelopmentCard() {
-			if player.BuyDevelopmentCard() {
-				if card := p.game.DrawDevelopmentCard(); card != nil {
-					player.HiddenDevCards = append(player.HiddenDevCards, *card)
-
-					p.game.LogAction(fmt.Sprintf("%s bought a development card", player.RenderName()))
-
-					// Check for game end after buying development card (in case it's a victory point card)
-					if winner := p.game.CheckGameEnd(); winner != nil {
-						return PhaseGameEnd(p.game, winner)
-					}
-
-					return p.previousPhase
-				}
-			}
+		next, err := p.game.applyIn(p, BuyDevCard{})
+		if err != nil {
+			return p
 		}
-		return p
+		return next
 	case 4: // Cancel
 		return p.previousPhase
 	default:
@@ -100,15 +70,15 @@ func (p *phaseBuilding) Cancel() Phase {
 	return p.previousPhase
 }
 
-func (p *phaseBuilding) HelpText() string {
-	return "Choose what to build"
+func (p *phaseBuilding) HelpText(l i18n.Locale) string {
+	return l.T("help.build")
 }
 
 type phaseSettlementPlacement struct {
 	game          *Game
 	cursorCross   board.CrossCoord
 	previousPhase Phase
-	invalid       string
+	invalid       i18n.Localizer
 }
 
 func PhaseSettlementPlacement(game *Game, previousPhase Phase) Phase {
@@ -121,40 +91,23 @@ func PhaseSettlementPlacement(game *Game, previousPhase Phase) Phase {
 }
 
 func (p *phaseSettlementPlacement) Confirm() Phase {
-	player := &p.game.Players[p.game.PlayerTurn]
-	playerId := p.game.PlayerTurn
-
-	if !p.game.Board.CanPlaceSettlementForPlayer(p.cursorCross, playerId) {
-		p.invalid = "Can't build settlement here"
+	next, err := p.game.applyIn(p, BuildSettlement{At: p.cursorCross})
+	if err != nil {
+		p.invalid = i18n.Explain(err)
 		return p
 	}
-
-	if !player.BuildSettlement() {
-		p.invalid = "Not enough resources"
-		return p
-	}
-
-	p.game.Board.SetSettlement(p.cursorCross, playerId)
-
-	p.game.LogAction(fmt.Sprintf("%s built a settlement", player.RenderName()))
-
-	// Check for game end after building settlement
-	if winner := p.game.CheckGameEnd(); winner != nil {
-		return PhaseGameEnd(p.game, winner)
-	}
-
-	return PhaseIdleWithNotification(p.game, "Settlement built!")
+	return next
 }
 
 func (p *phaseSettlementPlacement) Cancel() Phase {
 	return p.previousPhase
 }
 
-func (p *phaseSettlementPlacement) HelpText() string {
-	if p.invalid != "" {
-		return p.invalid
+func (p *phaseSettlementPlacement) HelpText(l i18n.Locale) string {
+	if p.invalid != nil {
+		return p.invalid.Localize(l)
 	}
-	return "Select where to place your settlement"
+	return l.T("help.place_settlement")
 }
 
 func (p *phaseSettlementPlacement) BoardCursor() interface{} {
@@ -162,6 +115,10 @@ func (p *phaseSettlementPlacement) BoardCursor() interface{} {
 }
 
 func (p *phaseSettlementPlacement) MoveCursor(direction string) {
+	if direction == "next" {
+		p.cursorCross = nextTarget(p.cursorCross, p.targets())
+		return
+	}
 	dest, ok := moveCrossCursor(p.cursorCross, direction)
 	if !ok {
 		return
@@ -173,7 +130,7 @@ type phaseCityPlacement struct {
 	game          *Game
 	cursorCross   board.CrossCoord
 	previousPhase Phase
-	invalid       string
+	invalid       i18n.Localizer
 }
 
 func PhaseCityPlacement(game *Game, previousPhase Phase) Phase {
@@ -186,40 +143,23 @@ func PhaseCityPlacement(game *Game, previousPhase Phase) Phase {
 }
 
 func (p *phaseCityPlacement) Confirm() Phase {
-	player := &p.game.Players[p.game.PlayerTurn]
-	playerId := p.game.PlayerTurn
-
-	if !p.game.Board.CanUpgradeToCity(p.cursorCross, playerId) {
-		p.invalid = "Can't upgrade to city here"
-		return p
-	}
-
-	if !player.BuildCity() {
-		p.invalid = "Not enough resources"
+	next, err := p.game.applyIn(p, BuildCity{At: p.cursorCross})
+	if err != nil {
+		p.invalid = i18n.Explain(err)
 		return p
 	}
-
-	p.game.Board.UpgradeToCity(p.cursorCross, playerId)
-
-	p.game.LogAction(fmt.Sprintf("%s upgraded to a city", player.RenderName()))
-
-	// Check for game end after building city
-	if winner := p.game.CheckGameEnd(); winner != nil {
-		return PhaseGameEnd(p.game, winner)
-	}
-
-	return PhaseIdleWithNotification(p.game, "City built!")
+	return next
 }
 
 func (p *phaseCityPlacement) Cancel() Phase {
 	return p.previousPhase
 }
 
-func (p *phaseCityPlacement) HelpText() string {
-	if p.invalid != "" {
-		return p.invalid
+func (p *phaseCityPlacement) HelpText(l i18n.Locale) string {
+	if p.invalid != nil {
+		return p.invalid.Localize(l)
 	}
-	return "Select a settlement to upgrade to a city"
+	return l.T("help.place_city")
 }
 
 func (p *phaseCityPlacement) BoardCursor() interface{} {
@@ -227,6 +167,10 @@ func (p *phaseCityPlacement) BoardCursor() interface{} {
 }
 
 func (p *phaseCityPlacement) MoveCursor(direction string) {
+	if direction == "next" {
+		p.cursorCross = nextTarget(p.cursorCross, p.targets())
+		return
+	}
 	dest, ok := moveCrossCursor(p.cursorCross, direction)
 	if !ok {
 		return
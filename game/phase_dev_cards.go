@@ -2,7 +2,8 @@ package game
 
 import (
 	"el_poblador/board"
-	"fmt"
+	"el_poblador/i18n"
+	"slices"
 )
 
 type phasePlayDevelopmentCard struct {
@@ -14,13 +15,13 @@ func PhasePlayDevelopmentCard(game *Game, previousPhase Phase) Phase {
 	player := &game.Players[game.PlayerTurn]
 
 	// Build options based on available development cards
-	var options []string
+	var options []i18n.Localizer
 
 	for _, card := range player.HiddenDevCards {
-		options = append(options, card.String())
+		options = append(options, card)
 	}
 
-	options = append(options, "Cancel")
+	options = append(options, i18n.M("menu.cancel"))
 
 	return &phasePlayDevelopmentCard{
 		phaseWithOptions: phaseWithOptions{
@@ -37,38 +38,20 @@ func (p *phasePlayDevelopmentCard) Confirm() Phase {
 	if p.selected == numCards {
 		return p.previousPhase
 	}
-	card := player.HiddenDevCards[p.selected]
-
-	switch card {
-	case DevCardKnight:
-		player.PlayDevCard(card)
-		p.game.LogAction(fmt.Sprintf("%s played Knight", player.RenderName()))
-		return PhasePlaceRobber(p.game, PhaseIdle(p.game))
-	case DevCardRoadBuilding:
-		player.PlayDevCard(card)
-		p.game.LogAction(fmt.Sprintf("%s played Road Building", player.RenderName()))
-		return PhaseRoadBuilding(p.game)
-	case DevCardMonopoly:
-		player.PlayDevCard(card)
-		p.game.LogAction(fmt.Sprintf("%s played Monopoly", player.RenderName()))
-		return PhaseMonopoly(p.game, PhaseIdle(p.game))
-	case DevCardYearOfPlenty:
-		player.PlayDevCard(card)
-		p.game.LogAction(fmt.Sprintf("%s played Year of Plenty", player.RenderName()))
-		return PhaseYearOfPlenty(p.game, PhaseIdle(p.game))
-	case DevCardVictoryPoint:
+	next, err := p.game.applyIn(p, PlayDevCard{Card: player.HiddenDevCards[p.selected]})
+	if err != nil {
+		// victory point cards aren't played
 		return p.previousPhase
-	default:
-		panic("This card does not exist")
 	}
+	return next
 }
 
 func (p *phasePlayDevelopmentCard) Cancel() Phase {
 	return p.previousPhase
 }
 
-func (p *phasePlayDevelopmentCard) HelpText() string {
-	return "Choose a development card to play"
+func (p *phasePlayDevelopmentCard) HelpText(l i18n.Locale) string {
+	return l.T("help.play_card")
 }
 
 type phaseMonopoly struct {
@@ -77,11 +60,7 @@ type phaseMonopoly struct {
 }
 
 func PhaseMonopoly(game *Game, previousPhase Phase) Phase {
-	resourceOptions := make([]string, len(board.RESOURCE_TYPES))
-	for i, resourceType := range board.RESOURCE_TYPES {
-		resourceOptions[i] = resourceType.String()
-	}
-	resourceOptions = append(resourceOptions, "Cancel")
+	resourceOptions := resourceMenu()
 
 	return &phaseMonopoly{
 		phaseWithOptions: phaseWithOptions{
@@ -96,8 +75,14 @@ func (p *phaseMonopoly) Confirm() Phase {
 	if p.selected == len(board.RESOURCE_TYPES) {
 		return p.previousPhase
 	}
+	next, _ := p.game.applyIn(p, PickResource{Resource: board.RESOURCE_TYPES[p.selected]})
+	return next
+}
 
-	selectedResource := board.RESOURCE_TYPES[p.selected]
+func (p *phaseMonopoly) pick(selectedResource board.ResourceType) (Phase, error) {
+	if !slices.Contains(board.RESOURCE_TYPES, selectedResource) {
+		return nil, i18n.Errorf("err.no_such_resource")
+	}
 	currentPlayer := p.game.Players[p.game.PlayerTurn]
 
 	totalCollected := 0
@@ -113,16 +98,16 @@ func (p *phaseMonopoly) Confirm() Phase {
 
 	if totalCollected > 0 {
 		currentPlayer.Resources[selectedResource] += totalCollected
-		p.game.LogAction(fmt.Sprintf("%s collected %d %s from all players", currentPlayer.RenderName(), totalCollected, selectedResource))
-		return PhaseIdleWithNotification(p.game, fmt.Sprintf("Collected %d %s from other players!", totalCollected, selectedResource))
+		p.game.LogAction("log.monopoly", playerName(p.game.PlayerTurn), totalCollected, selectedResource)
+		return PhaseIdleWithNotification(p.game, i18n.M("notice.monopoly", totalCollected, selectedResource)), nil
 	} else {
-		p.game.LogAction(fmt.Sprintf("%s monopolized %s but collected nothing", currentPlayer.RenderName(), selectedResource))
-		return PhaseIdleWithNotification(p.game, "No resources collected - nobody had any!")
+		p.game.LogAction("log.monopoly_nothing", playerName(p.game.PlayerTurn), selectedResource)
+		return PhaseIdleWithNotification(p.game, i18n.M("notice.monopoly_nothing")), nil
 	}
 }
 
-func (p *phaseMonopoly) HelpText() string {
-	return "Select a resource type to collect from all players"
+func (p *phaseMonopoly) HelpText(l i18n.Locale) string {
+	return l.T("help.monopoly")
 }
 
 type phaseYearOfPlenty struct {
@@ -133,11 +118,7 @@ type phaseYearOfPlenty struct {
 }
 
 func PhaseYearOfPlenty(game *Game, previousPhase Phase) Phase {
-	resourceOptions := make([]string, len(board.RESOURCE_TYPES))
-	for i, resourceType := range board.RESOURCE_TYPES {
-		resourceOptions[i] = resourceType.String()
-	}
-	resourceOptions = append(resourceOptions, "Cancel")
+	resourceOptions := resourceMenu()
 
 	return &phaseYearOfPlenty{
 		phaseWithOptions: phaseWithOptions{
@@ -153,14 +134,20 @@ func (p *phaseYearOfPlenty) Confirm() Phase {
 	if p.selected == len(board.RESOURCE_TYPES) {
 		return p.previousPhase
 	}
+	next, _ := p.game.applyIn(p, PickResource{Resource: board.RESOURCE_TYPES[p.selected]})
+	return next
+}
 
-	selectedResource := board.RESOURCE_TYPES[p.selected]
+func (p *phaseYearOfPlenty) pick(selectedResource board.ResourceType) (Phase, error) {
+	if !slices.Contains(board.RESOURCE_TYPES, selectedResource) {
+		return nil, i18n.Errorf("err.no_such_resource")
+	}
 	p.selectedResources[p.selectedCount] = selectedResource
 	p.selectedCount++
 
 	if p.selectedCount < 2 {
 		// Still need to select more resources
-		return p
+		return p, nil
 	}
 
 	// Both resources selected, give them to the player
@@ -169,14 +156,23 @@ func (p *phaseYearOfPlenty) Confirm() Phase {
 		currentPlayer.AddResource(resource)
 	}
 
-	p.game.LogAction(fmt.Sprintf("%s gained %s and %s from the bank", currentPlayer.RenderName(), p.selectedResources[0], p.selectedResources[1]))
+	p.game.LogAction("log.year_of_plenty", playerName(p.game.PlayerTurn), p.selectedResources[0], p.selectedResources[1])
 
-	return PhaseIdleWithNotification(p.game, fmt.Sprintf("Gained %s and %s from the bank!", p.selectedResources[0], p.selectedResources[1]))
+	return PhaseIdleWithNotification(p.game, i18n.M("notice.year_of_plenty", p.selectedResources[0], p.selectedResources[1])), nil
 }
 
-func (p *phaseYearOfPlenty) HelpText() string {
+func (p *phaseYearOfPlenty) HelpText(l i18n.Locale) string {
 	if p.selectedCount == 0 {
-		return "Select first resource to gain from the bank"
+		return l.T("help.year_of_plenty_first")
+	}
+	return l.T("help.year_of_plenty_second", p.selectedResources[0])
+}
+
+// resourceMenu offers every resource, then to cancel
+func resourceMenu() []i18n.Localizer {
+	var options []i18n.Localizer
+	for _, resource := range board.RESOURCE_TYPES {
+		options = append(options, resource)
 	}
-	return fmt.Sprintf("Selected: %s. Select second resource to gain from the bank", p.selectedResources[0])
+	return append(options, i18n.M("menu.cancel"))
 }
@@ -1,7 +1,7 @@
 package game
 
 import (
-	"fmt"
+	"el_poblador/i18n"
 	"strings"
 )
 
@@ -30,13 +30,13 @@ func (p *phaseGameEnd) BoardCursor() interface{} {
 	return nil
 }
 
-func (p *phaseGameEnd) Menu() string {
+func (p *phaseGameEnd) Menu(l i18n.Locale) string {
 	lines := []string{
-		fmt.Sprintf("🎉 GAME OVER! 🎉"),
+		l.T("end.game_over"),
 		"",
-		fmt.Sprintf("%s WINS!", p.winner.Render(p.winner.Name)),
+		l.T("end.wins", p.winner.Render(p.winner.Name)),
 		"",
-		"Final Scores:",
+		l.T("end.scores"),
 	}
 
 	for _, player := range p.game.Players {
@@ -45,12 +45,15 @@ func (p *phaseGameEnd) Menu() string {
 		if &player == p.winner {
 			marker = "🏆"
 		}
-		lines = append(lines, fmt.Sprintf("%s %s: %d points", marker, player.Render(player.Name), points))
+		lines = append(lines, l.T("end.score", marker, player.Render(player.Name), points))
+	}
+	if graph := p.game.winChancesGraph(l, 24); graph != "" {
+		lines = append(lines, "", graph)
 	}
 
 	return strings.Join(lines, "\n")
 }
 
-func (p *phaseGameEnd) HelpText() string {
-	return fmt.Sprintf("Game complete! %s reached 10 victory points!", p.winner.Render(p.winner.Name))
+func (p *phaseGameEnd) HelpText(l i18n.Locale) string {
+	return l.T("help.game_over", p.winner.Render(p.winner.Name))
 }
@@ -2,8 +2,7 @@ package game
 
 import (
 	"el_poblador/board"
-	"fmt"
-	"strings"
+	"el_poblador/i18n"
 )
 
 func nextInitialPhase(game *Game, isFirstPair bool) Phase {
@@ -38,12 +37,20 @@ func PhaseInitialSettlements(game *Game, isFirstPair bool) Phase {
 }
 
 func (p *phaseInitialSettlements) Confirm() Phase {
-	if !p.game.Board.SetSettlement(p.cursorCross, p.game.PlayerTurn) {
+	next, err := p.game.applyIn(p, BuildSettlement{At: p.cursorCross})
+	if err != nil {
 		return p
 	}
+	return next
+}
+
+func (p *phaseInitialSettlements) place(at board.CrossCoord) (Phase, error) {
+	if !at.IsInBounds() || !p.game.Board.SetSettlement(at, p.game.PlayerTurn) {
+		return nil, i18n.Errorf("err.settlement_here")
+	}
 	if !p.isFirstPair {
 		player := &p.game.Players[p.game.PlayerTurn]
-		adjacentTiles := p.game.Board.AdjacentTiles(p.cursorCross)
+		adjacentTiles := p.game.Board.AdjacentTiles(at)
 
 		// Collect resources and track what was gained
 		var resourcesGained []board.ResourceType
@@ -57,36 +64,23 @@ func (p *phaseInitialSettlements) Confirm() Phase {
 
 		// Log the initial resources gained
 		if len(resourcesGained) > 0 {
-			resourceCounts := make(map[board.ResourceType]int)
-			for _, resource := range resourcesGained {
-				resourceCounts[resource]++
-			}
-
-			var resourceParts []string
-			for resourceType, count := range resourceCounts {
-				resourceParts = append(resourceParts, fmt.Sprintf("%d %s", count, resourceType))
-			}
-
-			p.game.LogAction(fmt.Sprintf("%s gained %s from initial settlement", player.RenderName(), strings.Join(resourceParts, ", ")))
+			p.game.LogAction("log.initial_resources", playerName(p.game.PlayerTurn), countResources(resourcesGained))
 		}
 	}
 
 	// Check for game end after building initial settlement (unlikely but for completeness)
 	if winner := p.game.CheckGameEnd(); winner != nil {
-		return PhaseGameEnd(p.game, winner)
+		return PhaseGameEnd(p.game, winner), nil
 	}
 
-	return PhaseInitialRoad(p.game, p.cursorCross, p.isFirstPair)
+	return PhaseInitialRoad(p.game, at, p.isFirstPair), nil
 }
 
-func (p *phaseInitialSettlements) HelpText() string {
-	var num string
+func (p *phaseInitialSettlements) HelpText(l i18n.Locale) string {
 	if p.isFirstPair {
-		num = "first"
-	} else {
-		num = "second"
+		return l.T("help.first_settlement")
 	}
-	return fmt.Sprintf("Place your %s settlement on the board with 'enter'.", num)
+	return l.T("help.second_settlement")
 }
 
 func (p *phaseInitialSettlements) BoardCursor() interface{} {
@@ -94,6 +88,10 @@ func (p *phaseInitialSettlements) BoardCursor() interface{} {
 }
 
 func (p *phaseInitialSettlements) MoveCursor(direction string) {
+	if direction == "next" {
+		p.cursorCross = nextTarget(p.cursorCross, p.targets())
+		return
+	}
 	dest, ok := moveCrossCursor(p.cursorCross, direction)
 	if !ok {
 		return
@@ -118,17 +116,28 @@ func PhaseInitialRoad(game *Game, sourceCross board.CrossCoord, isFirstPair bool
 }
 
 func (p *phaseInitialRoad) Confirm() Phase {
-	roadCoord := board.NewPathCoord(p.sourceCross, p.cursorCross)
-	p.game.Board.SetRoad(roadCoord, p.game.PlayerTurn)
-	return nextInitialPhase(p.game, p.isFirstPair)
+	next, err := p.game.applyIn(p, BuildRoad{Road: board.PathCoord{From: p.sourceCross, To: p.cursorCross}})
+	if err != nil {
+		return p
+	}
+	return next
+}
+
+// place builds the road, which has to leave the new settlement
+func (p *phaseInitialRoad) place(road board.PathCoord) (Phase, error) {
+	if !p.game.canBuildInitialRoad(road, p.sourceCross) {
+		return nil, i18n.Errorf("err.initial_road")
+	}
+	p.game.Board.SetRoad(board.NewPathCoord(road.From, road.To), p.game.PlayerTurn)
+	return nextInitialPhase(p.game, p.isFirstPair), nil
 }
 
 func (p *phaseInitialRoad) Cancel() Phase {
 	return PhaseInitialSettlements(p.game, p.isFirstPair)
 }
 
-func (p *phaseInitialRoad) HelpText() string {
-	return "Place a road connected to the settlement by selecting its direction."
+func (p *phaseInitialRoad) HelpText(l i18n.Locale) string {
+	return l.T("help.initial_road")
 }
 
 func (p *phaseInitialRoad) BoardCursor() interface{} {
@@ -148,6 +157,8 @@ func (p *phaseInitialRoad) MoveCursor(direction string) {
 		dest, ok = p.sourceCross.Left()
 	case "right":
 		dest, ok = p.sourceCross.Right()
+	case "next":
+		dest, ok = nextTarget(p.cursorCross, p.targets()), true
 	default:
 		return
 	}
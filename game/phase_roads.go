@@ -2,35 +2,34 @@ package game
 
 import (
 	"el_poblador/board"
-	"fmt"
-	"strings"
+	"el_poblador/i18n"
 )
 
 type phaseRoadStart struct {
 	game          *Game
 	cursorCross   board.CrossCoord
 	previousPhase Phase
-	invalid       string
+	invalid       i18n.Localizer
 	isFree        bool
 	continuation  Phase
-	helpPrefix    string
+	freeRoad      int // which of Road Building's roads this is, 1 or 2, 0 if paid for
 }
 
 // Phase for building a road by paying for it
 func PhaseRoadStart(game *Game, previousPhase Phase) Phase {
-	end := PhaseIdleWithNotification(game, "Road Built!")
-	return newPhaseRoadStart(game, previousPhase, false, end, "")
+	end := PhaseIdleWithNotification(game, i18n.M("notice.road"))
+	return newPhaseRoadStart(game, previousPhase, false, end, 0)
 }
 
 // Phase for building two roads by using a development card
 func PhaseRoadBuilding(game *Game) Phase {
 	// First free road - continuation will be second free road
-	end := PhaseIdleWithNotification(game, "Two free roads built!")
-	second := newPhaseRoadStart(game, PhaseIdle(game), true, end, "second free")
-	return newPhaseRoadStart(game, PhaseIdle(game), true, second, "first free")
+	end := PhaseIdleWithNotification(game, i18n.M("notice.free_roads"))
+	second := newPhaseRoadStart(game, PhaseIdle(game), true, end, 2)
+	return newPhaseRoadStart(game, PhaseIdle(game), true, second, 1)
 }
 
-func newPhaseRoadStart(game *Game, previousPhase Phase, isFree bool, continuation Phase, helpPrefix string) Phase {
+func newPhaseRoadStart(game *Game, previousPhase Phase, isFree bool, continuation Phase, freeRoad int) Phase {
 	cursorCross := game.Board.ValidCrossCoord()
 	return &phaseRoadStart{
 		game:          game,
@@ -38,7 +37,7 @@ func newPhaseRoadStart(game *Game, previousPhase Phase, isFree bool, continuatio
 		previousPhase: previousPhase,
 		isFree:        isFree,
 		continuation:  continuation,
-		helpPrefix:    helpPrefix,
+		freeRoad:      freeRoad,
 	}
 }
 
@@ -48,11 +47,11 @@ func (p *phaseRoadStart) Confirm() Phase {
 	if !p.game.Board.HasRoadConnected(p.cursorCross, playerId) {
 		// Check if player has a settlement at this crossing
 		if !p.game.Board.HasSettlementAt(p.cursorCross, playerId) {
-			p.invalid = "You must have a road or settlement connected to this crossing"
+			p.invalid = i18n.M("help.road_unconnected")
 			return p // Invalid selection, stay in same phase
 		}
 	}
-	return newPhaseRoadEnd(p.game, p.cursorCross, p.previousPhase, p.isFree, p.continuation, p.helpPrefix)
+	return newPhaseRoadEnd(p.game, p.cursorCross, p.previousPhase, p.isFree, p.continuation, p.freeRoad)
 }
 
 func (p *phaseRoadStart) Cancel() Phase {
@@ -63,14 +62,17 @@ func (p *phaseRoadStart) Cancel() Phase {
 	return p.previousPhase
 }
 
-func (p *phaseRoadStart) HelpText() string {
-	if p.invalid != "" {
-		return p.invalid
+func (p *phaseRoadStart) HelpText(l i18n.Locale) string {
+	if p.invalid != nil {
+		return p.invalid.Localize(l)
 	}
-	if p.helpPrefix != "" {
-		return fmt.Sprintf("Select the starting point for your %s road", p.helpPrefix)
+	switch p.freeRoad {
+	case 1:
+		return l.T("help.road_start_first_free")
+	case 2:
+		return l.T("help.road_start_second_free")
 	}
-	return "Select the starting point for your road"
+	return l.T("help.road_start")
 }
 
 func (p *phaseRoadStart) BoardCursor() interface{} {
@@ -78,6 +80,10 @@ func (p *phaseRoadStart) BoardCursor() interface{} {
 }
 
 func (p *phaseRoadStart) MoveCursor(direction string) {
+	if direction == "next" {
+		p.cursorCross = nextTarget(p.cursorCross, p.targets())
+		return
+	}
 	dest, ok := moveCrossCursor(p.cursorCross, direction)
 	if !ok {
 		return
@@ -90,17 +96,17 @@ type phaseRoadEnd struct {
 	startCross    board.CrossCoord
 	cursorCross   board.CrossCoord
 	previousPhase Phase
-	invalid       string
+	invalid       i18n.Localizer
 	isFree        bool
 	continuation  Phase
-	helpPrefix    string
+	freeRoad      int // which of Road Building's roads this is, 1 or 2, 0 if paid for
 }
 
 func PhaseRoadEnd(game *Game, startCross board.CrossCoord, previousPhase Phase) Phase {
-	return newPhaseRoadEnd(game, startCross, previousPhase, false, nil, "")
+	return newPhaseRoadEnd(game, startCross, previousPhase, false, nil, 0)
 }
 
-func newPhaseRoadEnd(game *Game, startCross board.CrossCoord, previousPhase Phase, isFree bool, continuation Phase, helpPrefix string) Phase {
+func newPhaseRoadEnd(game *Game, startCross board.CrossCoord, previousPhase Phase, isFree bool, continuation Phase, freeRoad int) Phase {
 	// Start with the first neighbor of the start cross
 	neighbors := startCross.Neighbors()
 	if len(neighbors) == 0 {
@@ -120,49 +126,17 @@ func newPhaseRoadEnd(game *Game, startCross board.CrossCoord, previousPhase Phas
 		previousPhase: previousPhase,
 		isFree:        isFree,
 		continuation:  finalContinuation,
-		helpPrefix:    helpPrefix,
+		freeRoad:      freeRoad,
 	}
 }
 
 func (p *phaseRoadEnd) Confirm() Phase {
-	player := &p.game.Players[p.game.PlayerTurn]
-	playerId := p.game.PlayerTurn
-	pathCoord := board.NewPathCoord(p.startCross, p.cursorCross)
-
-	if !p.game.Board.CanPlaceRoad(pathCoord, playerId) {
-		p.invalid = "Can't build road here"
+	next, err := p.game.applyIn(p, BuildRoad{Road: board.PathCoord{From: p.startCross, To: p.cursorCross}})
+	if err != nil {
+		p.invalid = i18n.Explain(err)
 		return p
 	}
-
-	if !p.isFree {
-		if !player.BuildRoad() {
-			p.invalid = "Not enough resources"
-			return p
-		}
-	}
-
-	p.game.Board.SetRoad(pathCoord, playerId)
-
-	if p.isFree {
-		p.game.LogAction(fmt.Sprintf("%s built a free road", player.RenderName()))
-	} else {
-		p.game.LogAction(fmt.Sprintf("%s built a road", player.RenderName()))
-	}
-
-	message := "Road built!"
-	if p.isFree {
-		if p.helpPrefix != "" {
-			message = fmt.Sprintf("%s road built!", strings.Title(p.helpPrefix))
-		} else {
-			message = "Free road built!"
-		}
-	}
-
-	if p.continuation == nil {
-		return PhaseIdleWithNotification(p.game, message)
-	} else {
-		return p.continuation
-	}
+	return next
 }
 
 func (p *phaseRoadEnd) Cancel() Phase {
@@ -170,17 +144,20 @@ func (p *phaseRoadEnd) Cancel() Phase {
 	if p.isFree {
 		return p
 	}
-	return newPhaseRoadStart(p.game, p.previousPhase, p.isFree, p.continuation, p.helpPrefix)
+	return newPhaseRoadStart(p.game, p.previousPhase, p.isFree, p.continuation, p.freeRoad)
 }
 
-func (p *phaseRoadEnd) HelpText() string {
-	if p.invalid != "" {
-		return p.invalid
+func (p *phaseRoadEnd) HelpText(l i18n.Locale) string {
+	if p.invalid != nil {
+		return p.invalid.Localize(l)
 	}
-	if p.helpPrefix != "" {
-		return fmt.Sprintf("Select the ending point for your %s road", p.helpPrefix)
+	switch p.freeRoad {
+	case 1:
+		return l.T("help.road_end_first_free")
+	case 2:
+		return l.T("help.road_end_second_free")
 	}
-	return "Select the ending point for your road"
+	return l.T("help.road_end")
 }
 
 func (p *phaseRoadEnd) BoardCursor() interface{} {
@@ -200,6 +177,8 @@ func (p *phaseRoadEnd) MoveCursor(direction string) {
 		dest, ok = p.startCross.Left()
 	case "right":
 		dest, ok = p.startCross.Right()
+	case "next":
+		dest, ok = nextTarget(p.cursorCross, p.targets()), true
 	default:
 		return
 	}
@@ -2,8 +2,8 @@ package game
 
 import (
 	"el_poblador/board"
-	"fmt"
-	"math/rand/v2"
+	"el_poblador/i18n"
+	"slices"
 	"strings"
 )
 
@@ -11,7 +11,7 @@ type phasePlaceRobber struct {
 	game         *Game
 	tileCoord    board.TileCoord
 	continuation Phase
-	invalid      string
+	invalid      i18n.Localizer
 }
 
 func PhasePlaceRobber(game *Game, continuation Phase) Phase {
@@ -34,46 +34,60 @@ func (p *phasePlaceRobber) MoveCursor(direction string) {
 	p.tileCoord = dest
 }
 
-func (p *phasePlaceRobber) HelpText() string {
-	if p.invalid != "" {
-		return p.invalid
+func (p *phasePlaceRobber) HelpText(l i18n.Locale) string {
+	if p.invalid != nil {
+		return p.invalid.Localize(l)
 	}
-	return "Select a tile to place the robber on"
+	return l.T("help.robber")
 }
 
 func (p *phasePlaceRobber) Confirm() Phase {
-	// Check if trying to place robber on the same tile it's already on
-	if p.tileCoord == p.game.Board.GetRobber() {
-		p.invalid = "Robber cannot be moved to the same tile it's already on"
+	next, err := p.game.applyIn(p, MoveRobber{To: p.tileCoord})
+	if err != nil {
+		p.invalid = i18n.Explain(err)
 		return p
 	}
+	return next
+}
+
+func (p *phasePlaceRobber) place(tile board.TileCoord) (Phase, error) {
+	if _, ok := p.game.Board.Tiles[tile]; !ok {
+		return nil, i18n.Errorf("err.no_such_tile")
+	}
+	// Check if trying to place robber on the same tile it's already on
+	if tile == p.game.Board.GetRobber() {
+		return nil, i18n.Errorf("err.robber_stays")
+	}
 
-	playerIds := p.game.Board.PlaceRobber(p.tileCoord)
+	playerIds := p.game.Board.PlaceRobber(tile)
 
-	currentPlayer := &p.game.Players[p.game.PlayerTurn]
-	p.game.LogAction(fmt.Sprintf("%s moved the robber", currentPlayer.RenderName()))
+	p.game.LogAction("log.robber", playerName(p.game.PlayerTurn))
 
 	var stealablePlayers []Player
+	var victims []int
 	for _, playerId := range playerIds {
 		p := p.game.Players[playerId]
-		if p.TotalResources() > 0 {
+		if p.TotalResources() > 0 && !slices.Contains(victims, playerId) {
 			stealablePlayers = append(stealablePlayers, p)
+			victims = append(victims, playerId)
 		}
 	}
 	if len(stealablePlayers) == 0 { // no one to steal from? skip
-		return p.continuation
+		return p.continuation, nil
 	}
 	return &phaseStealCard{
 		game:             p.game,
 		continuation:     p.continuation,
 		stealablePlayers: stealablePlayers,
-	}
+		victims:          victims,
+	}, nil
 }
 
 type phaseStealCard struct {
 	game             *Game
 	continuation     Phase
 	stealablePlayers []Player
+	victims          []int // player ids of stealablePlayers
 	selected         int
 }
 
@@ -91,30 +105,38 @@ func (p *phaseStealCard) MoveCursor(direction string) {
 	p.selected = (p.selected + len(p.stealablePlayers)) % len(p.stealablePlayers)
 }
 
-func (p *phaseStealCard) HelpText() string {
-	return "Select a player to steal from"
+func (p *phaseStealCard) HelpText(l i18n.Locale) string {
+	return l.T("help.steal")
 }
 
 func (p *phaseStealCard) Confirm() Phase {
-	player := p.stealablePlayers[p.selected]
+	next, _ := p.game.applyIn(p, Steal{From: p.victims[p.selected]})
+	return next
+}
+
+func (p *phaseStealCard) steal(from int) (Phase, error) {
+	i := slices.Index(p.victims, from)
+	if i < 0 {
+		return nil, i18n.Errorf("err.steal")
+	}
+	player := p.stealablePlayers[i]
 	var resourcePool []board.ResourceType
-	for resType, count := range player.Resources {
-		for i := 0; i < count; i++ {
+	for _, resType := range board.RESOURCE_TYPES {
+		for i := 0; i < player.Resources[resType]; i++ {
 			resourcePool = append(resourcePool, resType)
 		}
 	}
 	if len(resourcePool) > 0 {
-		selectedResource := resourcePool[rand.IntN(len(resourcePool))]
+		selectedResource := resourcePool[p.game.random().IntN(len(resourcePool))]
 		player.Resources[selectedResource] -= 1
 		p.game.Players[p.game.PlayerTurn].AddResource(selectedResource)
 
-		currentPlayer := &p.game.Players[p.game.PlayerTurn]
-		p.game.LogAction(fmt.Sprintf("%s stole a card from %s", currentPlayer.RenderName(), player.RenderName()))
+		p.game.LogAction("log.steal", playerName(p.game.PlayerTurn), playerName(from))
 	}
-	return p.continuation
+	return p.continuation, nil
 }
 
-func (p *phaseStealCard) Menu() string {
+func (p *phaseStealCard) Menu(l i18n.Locale) string {
 	var paddedOptions []string
 	for i, player := range p.stealablePlayers {
 		if i == p.selected {
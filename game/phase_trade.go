@@ -2,7 +2,7 @@ package game
 
 import (
 	"el_poblador/board"
-	"fmt"
+	"el_poblador/i18n"
 	"strings"
 )
 
@@ -29,7 +29,7 @@ import (
 //
 // Adding New Trade Types:
 //   1. Add detection function (e.g., isHarborTrade) similar to isBankTrade
-//   2. Add execution logic in validateAndExecuteTrade
+//   2. Add execution logic in Game.trade
 //   3. For player trades, add new phases for partner selection and negotiation
 //
 // Design Notes:
@@ -37,6 +37,7 @@ import (
 //   - phaseTradeSelectReceive keeps reference to offer phase for cancel preservation
 //   - Validation is deferred until complete trade is known (offer + request)
 //   - Trade type detection happens in isBankTrade, isHarborTrade (future), etc.
+//   - The phases only build the Trade action, which Game.trade carries out
 //   - Both phases implement PhaseCancelable for Esc key handling
 
 type phaseTradeOffer struct {
@@ -106,18 +107,18 @@ func (p *phaseTradeOffer) BoardCursor() interface{} {
 	return nil
 }
 
-func (p *phaseTradeOffer) Menu() string {
+func (p *phaseTradeOffer) Menu(l i18n.Locale) string {
 	var lines []string
 	player := &p.game.Players[p.game.PlayerTurn]
 
-	lines = append(lines, "What do you want to offer?")
+	lines = append(lines, l.T("menu.offer"))
 	lines = append(lines, "")
 
 	for i, resourceType := range board.RESOURCE_TYPES {
 		amount := p.offer[resourceType]
 		maxAvailable := player.Resources[resourceType]
 
-		line := fmt.Sprintf("%s:  %d / %d", resourceType, amount, maxAvailable)
+		line := l.T("menu.amount_of", resourceType, amount, maxAvailable)
 		if i == p.selected {
 			line = player.Render("> ") + line
 		} else {
@@ -129,8 +130,8 @@ func (p *phaseTradeOffer) Menu() string {
 	return strings.Join(lines, "\n")
 }
 
-func (p *phaseTradeOffer) HelpText() string {
-	return "Use ←/→ to adjust, ↑/↓ to move, Enter to confirm, Esc to cancel"
+func (p *phaseTradeOffer) HelpText(l i18n.Locale) string {
+	return l.T("help.trade")
 }
 
 type phaseTradeSelectReceive struct {
@@ -201,17 +202,17 @@ func (p *phaseTradeSelectReceive) BoardCursor() interface{} {
 	return nil
 }
 
-func (p *phaseTradeSelectReceive) Menu() string {
+func (p *phaseTradeSelectReceive) Menu(l i18n.Locale) string {
 	var lines []string
 	player := &p.game.Players[p.game.PlayerTurn]
 
-	lines = append(lines, "What do you want to receive?")
+	lines = append(lines, l.T("menu.receive"))
 	lines = append(lines, "")
 
 	for i, resourceType := range board.RESOURCE_TYPES {
 		amount := p.request[resourceType]
 
-		line := fmt.Sprintf("%s:  %d", resourceType, amount)
+		line := l.T("menu.amount", resourceType, amount)
 		if i == p.selected {
 			line = player.Render("> ") + line
 		} else {
@@ -223,36 +224,23 @@ func (p *phaseTradeSelectReceive) Menu() string {
 	return strings.Join(lines, "\n")
 }
 
-func (p *phaseTradeSelectReceive) HelpText() string {
-	return "Use ←/→ to adjust, ↑/↓ to move, Enter to confirm, Esc to cancel"
+func (p *phaseTradeSelectReceive) HelpText(l i18n.Locale) string {
+	return l.T("help.trade")
 }
 
 func (p *phaseTradeSelectReceive) validateAndExecuteTrade() Phase {
-	player := &p.game.Players[p.game.PlayerTurn]
-
-	if tradeType, offeredResource, requestedResource := p.isBankTrade(); tradeType == "bank" {
-		if player.Resources[offeredResource] < 4 {
-			return PhaseIdleWithNotification(p.game, "Not enough resources for bank trade!")
-		}
-
-		player.Resources[offeredResource] -= 4
-		player.Resources[requestedResource]++
-
-		p.game.LogAction(fmt.Sprintf("%s traded 4 %s for 1 %s with the bank",
-			player.RenderName(), offeredResource, requestedResource))
-
-		return PhaseIdleWithNotification(p.game,
-			fmt.Sprintf("Traded 4 %s for 1 %s!", offeredResource, requestedResource))
+	next, err := p.game.applyIn(p, Trade{Offer: p.offer, Request: p.request})
+	if err != nil {
+		return PhaseIdleWithNotification(p.game, i18n.Explain(err))
 	}
-
-	return PhaseIdleWithNotification(p.game, "Trade type not yet implemented")
+	return next
 }
 
-func (p *phaseTradeSelectReceive) isBankTrade() (string, board.ResourceType, board.ResourceType) {
+func isBankTrade(offer, request map[board.ResourceType]int) (string, board.ResourceType, board.ResourceType) {
 	totalOffered := 0
 	offeredTypes := 0
 	var offeredResource board.ResourceType
-	for resourceType, amount := range p.offer {
+	for resourceType, amount := range offer {
 		if amount > 0 {
 			offeredTypes++
 			offeredResource = resourceType
@@ -263,7 +251,7 @@ func (p *phaseTradeSelectReceive) isBankTrade() (string, board.ResourceType, boa
 	totalRequested := 0
 	requestedTypes := 0
 	var requestedResource board.ResourceType
-	for resourceType, amount := range p.request {
+	for resourceType, amount := range request {
 		if amount > 0 {
 			requestedTypes++
 			requestedResource = resourceType
@@ -2,25 +2,27 @@ package game
 
 import (
 	"bytes"
-	"el_poblador/board"
+	"el_poblador/i18n"
 	"encoding/gob"
 	"fmt"
-	"math/rand/v2"
 	"os"
-	"strings"
 	"time"
 )
 
 type phaseDiceRoll struct {
 	phaseWithOptions
-	invalid string
+	invalid i18n.Localizer
 }
 
 func PhaseDiceRoll(game *Game) Phase {
+	options := menuOptions("menu.roll", "menu.play_knight", "menu.save_quit")
+	if game.noSaving {
+		options = options[:2]
+	}
 	return &phaseDiceRoll{
 		phaseWithOptions: phaseWithOptions{
 			game:    game,
-			options: []string{"Roll", "Play Knight", "Save & Quit"},
+			options: options,
 		},
 	}
 }
@@ -28,19 +30,19 @@ func PhaseDiceRoll(game *Game) Phase {
 func (p *phaseDiceRoll) Confirm() Phase {
 	switch p.selected {
 	case 0:
-		return rollDice(p.game)
+		next, _ := p.game.applyIn(p, RollDice{})
+		return next
 	case 1:
-		// Play Knight card
-		player := &p.game.Players[p.game.PlayerTurn]
-		if player.PlayDevCard(DevCardKnight) {
-			return PhasePlaceRobber(p.game, p)
+		next, err := p.game.applyIn(p, PlayDevCard{Card: DevCardKnight})
+		if err != nil {
+			p.invalid = i18n.Explain(err)
+			return p
 		}
-		p.invalid = "You don't have a Knight card"
-		return p
+		return next
 	case 2:
 		// Save & Quit
 		if err := saveGameState(p.game); err != nil {
-			p.invalid = fmt.Sprintf("Save failed: %v", err)
+			p.invalid = i18n.M("help.save_failed", err)
 			return p
 		}
 		p.game.shouldQuit = true
@@ -50,19 +52,19 @@ func (p *phaseDiceRoll) Confirm() Phase {
 	}
 }
 
-func (p *phaseDiceRoll) HelpText() string {
-	if p.invalid != "" {
-		return p.invalid
+func (p *phaseDiceRoll) HelpText(l i18n.Locale) string {
+	if p.invalid != nil {
+		return p.invalid.Localize(l)
 	}
-	return "Time to roll the dice"
+	return l.T("help.roll")
 }
 
 func rollDice(game *Game) Phase {
-	game.LastDice = [2]int{rand.IntN(6) + 1, rand.IntN(6) + 1}
+	dice := game.random()
+	game.LastDice = [2]int{dice.IntN(6) + 1, dice.IntN(6) + 1}
 	sum := game.LastDice[0] + game.LastDice[1]
 	if sum == 7 {
-		// TODO: discarding of > 7 cards
-		return PhasePlaceRobber(game, PhaseIdle(game))
+		return sevenRolled(game)
 	}
 	generatedResources := game.Board.GenerateResources(sum)
 	for player, resources := range generatedResources {
@@ -74,41 +76,43 @@ func rollDice(game *Game) Phase {
 	// Log resource generation for each player if they received any
 	for playerId, resources := range generatedResources {
 		if len(resources) > 0 {
-			// Count resources by type
-			resourceCounts := make(map[board.ResourceType]int)
-			for _, resource := range resources {
-				resourceCounts[resource]++
-			}
-
-			// Build resource description
-			var resourceParts []string
-			for resourceType, count := range resourceCounts {
-				resourceParts = append(resourceParts, fmt.Sprintf("%d %s", count, resourceType))
-			}
-
-			game.LogAction(fmt.Sprintf("%s gained %s from dice roll (%d)",
-				game.Players[playerId].RenderName(),
-				strings.Join(resourceParts, ", "),
-				sum))
+			game.LogAction("log.rolled", playerName(playerId), countResources(resources), sum)
 		}
 	}
 	return PhaseIdle(game)
 }
 
+// sevenRolled makes everyone holding more than 7 cards discard half of them,
+// then lets the turn holder move the robber
+func sevenRolled(game *Game) Phase {
+	for player := range game.Players {
+		if game.Players[player].TotalResources() > 7 {
+			game.queueDecision(player, newDiscardDecision(game, player))
+		}
+	}
+	robber := PhasePlaceRobber(game, PhaseIdle(game))
+	if game.hasPendingDecisions() {
+		return PhaseAwaitDecisions(game, robber)
+	}
+	return robber
+}
+
 type phaseIdle struct {
 	phaseWithOptions
-	notification string
+	notification i18n.Localizer
 }
 
 func PhaseIdle(game *Game) Phase {
-	return PhaseIdleWithNotification(game, "")
+	return PhaseIdleWithNotification(game, nil)
 }
 
-func PhaseIdleWithNotification(game *Game, notification string) Phase {
+// PhaseIdleWithNotification tells the player what just happened, if the
+// notification isn't nil, and asks what's next
+func PhaseIdleWithNotification(game *Game, notification i18n.Localizer) Phase {
 	return &phaseIdle{
 		phaseWithOptions: phaseWithOptions{
 			game:    game,
-			options: []string{"Build", "Trade", "Play Development Card", "End Turn"},
+			options: menuOptions("menu.build", "menu.trade", "menu.play_card", "menu.end_turn"),
 		},
 		notification: notification,
 	}
@@ -123,21 +127,18 @@ func (p *phaseIdle) Confirm() Phase {
 	case 2: // Play Development Card
 		return PhasePlayDevelopmentCard(p.game, p)
 	case 3: // End Turn
-		p.game.PlayerTurn++
-		p.game.PlayerTurn %= len(p.game.Players)
-		nextPlayer := &p.game.Players[p.game.PlayerTurn]
-		p.game.LogAction(fmt.Sprintf("Turn passed to %s", nextPlayer.RenderName()))
-		return PhaseDiceRoll(p.game)
+		next, _ := p.game.applyIn(p, EndTurn{})
+		return next
 	default:
 		panic("Invalid option selected")
 	}
 }
 
-func (p *phaseIdle) HelpText() string {
-	if p.notification != "" {
-		return p.notification + " Anything else?"
+func (p *phaseIdle) HelpText(l i18n.Locale) string {
+	if p.notification != nil {
+		return l.T("help.anything_else", p.notification)
 	}
-	return "What do you want to do?"
+	return l.T("help.idle")
 }
 
 func saveGameState(g *Game) error {
@@ -12,6 +12,8 @@ type Player struct {
 	Resources      map[board.ResourceType]int
 	HiddenDevCards []DevCard
 	PlayedDevCards []DevCard
+	// Bot is set for seats the computer plays, see Game.SetBot
+	Bot bool
 }
 
 func (p *Player) TotalResources() int {
@@ -173,7 +175,23 @@ func (p *Player) VictoryPoints(game *Game) int {
 	return points
 }
 
+// ShownVictoryPoints are the points everyone can see: the player's victory
+// point cards count once they are played
+func (p *Player) ShownVictoryPoints(game *Game) int {
+	points := p.VictoryPoints(game)
+	for _, card := range p.HiddenDevCards {
+		if card == DevCardVictoryPoint {
+			points--
+		}
+	}
+	return points
+}
+
 func (p *Player) Render(s string) string {
+	if p.Color == (lipgloss.AdaptiveColor{}) {
+		// seats without a color, like in the arena, are written plainly
+		return s
+	}
 	style := lipgloss.NewStyle().Foreground(p.Color)
 	return style.Render(s)
 }
@@ -0,0 +1,203 @@
+package game
+
+import (
+	"el_poblador/board"
+	"slices"
+)
+
+// aimer is a phase whose cursor can be put on the board with the mouse. aim
+// moves the cursor to what is at a line and column of the board as printed,
+// and reports whether the phase can be confirmed there.
+type aimer interface {
+	aim(line, column int) bool
+}
+
+// picker is a menu whose options can be picked with the mouse. pick selects
+// the option on a line of the menu and reports whether there is one there,
+// and whether clicking it confirms it or rather counts one more of it.
+type picker interface {
+	pick(line int) (picked, confirms bool)
+}
+
+// Hover previews a click on a cell of the screen rendered for the viewport:
+// it moves the cursor to the crossing, path or tile under the mouse, or
+// selects the menu option.
+func (g *Game) Hover(v Viewport, x, y int) {
+	g.point(v, x, y, "")
+}
+
+// Click selects what is under the mouse and confirms it. In menus of amounts,
+// like discards and trades, the left button adds one of the resource clicked
+// and the right button takes one away.
+func (g *Game) Click(v Viewport, x, y int, button string) {
+	g.point(v, x, y, button)
+}
+
+// point routes the mouse like MoveCursor routes keys, to the player's first
+// pending decision or to the phase if it is waiting on them, and clicks if a
+// button is given
+func (g *Game) point(v Viewport, x, y int, button string) {
+	player := g.playerPerspective(v.Player)
+	decision := g.decisionFor(player)
+	var target any = decision
+	if decision == nil {
+		if !g.phaseWaitsOn(player) {
+			return
+		}
+		target = g.phase
+	}
+	s := g.layout(v)
+
+	if p, ok := target.(picker); ok && s.hasMenu && x >= s.menu[0] && x < s.menu[0]+sidebarWidth {
+		if picked, confirms := p.pick(y - s.menu[1]); picked {
+			switch {
+			case button == "":
+			case confirms:
+				g.ConfirmAction(v.Player)
+			case button == "right":
+				g.MoveCursor("left", v.Player)
+			default:
+				g.MoveCursor("right", v.Player)
+			}
+			return
+		}
+	}
+
+	a, ok := target.(aimer)
+	if !ok || !s.hasBoard {
+		return
+	}
+	line, column := y-s.board[1], x-s.board[0]
+	if !a.aim(line, column) || button == "" {
+		return
+	}
+	g.ConfirmAction(v.Player)
+	// a path names both ends of a road, so picking its start goes on to its end
+	if _, isPath := board.PathAt(line, column); isPath {
+		if end, ok := g.phase.(*phaseRoadEnd); ok && end.aim(line, column) {
+			g.ConfirmAction(v.Player)
+		}
+	}
+}
+
+// aimRoad returns the crossing a road from a crossing would lead to, if it
+// points at one: the far end of a path from it, or a neighbor
+func aimRoad(from board.CrossCoord, line, column int) (board.CrossCoord, bool) {
+	if path, ok := board.PathAt(line, column); ok {
+		switch from {
+		case path.From:
+			return path.To, true
+		case path.To:
+			return path.From, true
+		}
+	}
+	cross, ok := board.CrossAt(line, column)
+	if !ok || !slices.Contains(from.Neighbors(), cross) {
+		return board.CrossCoord{}, false
+	}
+	return cross, true
+}
+
+func (p *phaseInitialSettlements) aim(line, column int) bool {
+	cross, ok := board.CrossAt(line, column)
+	if ok {
+		p.cursorCross = cross
+	}
+	return ok
+}
+
+func (p *phaseInitialRoad) aim(line, column int) bool {
+	cross, ok := aimRoad(p.sourceCross, line, column)
+	if ok {
+		p.cursorCross = cross
+	}
+	return ok
+}
+
+func (p *phaseSettlementPlacement) aim(line, column int) bool {
+	cross, ok := board.CrossAt(line, column)
+	if ok {
+		p.cursorCross = cross
+	}
+	return ok
+}
+
+func (p *phaseCityPlacement) aim(line, column int) bool {
+	cross, ok := board.CrossAt(line, column)
+	if ok {
+		p.cursorCross = cross
+	}
+	return ok
+}
+
+func (p *phaseRoadStart) aim(line, column int) bool {
+	if path, ok := board.PathAt(line, column); ok {
+		// start from the end the player can build from
+		p.cursorCross = path.From
+		if player := p.game.PlayerTurn; !p.game.Board.HasRoadConnected(path.From, player) &&
+			!p.game.Board.HasSettlementAt(path.From, player) {
+			p.cursorCross = path.To
+		}
+		return true
+	}
+	cross, ok := board.CrossAt(line, column)
+	if ok {
+		p.cursorCross = cross
+	}
+	return ok
+}
+
+func (p *phaseRoadEnd) aim(line, column int) bool {
+	cross, ok := aimRoad(p.startCross, line, column)
+	if ok {
+		p.cursorCross = cross
+	}
+	return ok
+}
+
+func (p *phasePlaceRobber) aim(line, column int) bool {
+	tile, ok := board.TileAt(line, column)
+	if ok {
+		p.tileCoord = tile
+	}
+	return ok
+}
+
+func (p *phaseWithOptions) pick(line int) (bool, bool) {
+	if line < 0 || line >= len(p.options) {
+		return false, false
+	}
+	p.selected = line
+	return true, true
+}
+
+func (p *phaseStealCard) pick(line int) (bool, bool) {
+	if line < 0 || line >= len(p.stealablePlayers) {
+		return false, false
+	}
+	p.selected = line
+	return true, true
+}
+
+// the menus of amounts have a title and a blank line above the resources
+const amountsTop = 2
+
+func pickResource(selected *int, line int) (bool, bool) {
+	if line < amountsTop || line >= amountsTop+len(board.RESOURCE_TYPES) {
+		return false, false
+	}
+	*selected = line - amountsTop
+	return true, false
+}
+
+func (d *discardDecision) pick(line int) (bool, bool) {
+	return pickResource(&d.selected, line)
+}
+
+func (p *phaseTradeOffer) pick(line int) (bool, bool) {
+	return pickResource(&p.selected, line)
+}
+
+func (p *phaseTradeSelectReceive) pick(line int) (bool, bool) {
+	return pickResource(&p.selected, line)
+}
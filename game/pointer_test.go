@@ -0,0 +1,92 @@
+package game
+
+import (
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// find returns the screen cell of the first rune of text in the view
+func find(t *testing.T, view string, text string) (int, int) {
+	t.Helper()
+	for y, line := range strings.Split(ansi.Strip(view), "\n") {
+		if i := strings.Index(line, text); i >= 0 {
+			return ansi.StringWidth(line[:i]), y
+		}
+	}
+	t.Fatalf("Expected %q on screen", text)
+	return 0, 0
+}
+
+func TestClickPlacesTheInitialSettlement(t *testing.T) {
+	for _, v := range []Viewport{{Width: 160, Height: 50}, {Width: 100, Height: 40}, {Width: 80, Height: 40, OneColumnCycle: 1}} {
+		game := &Game{}
+		game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+		target := board.CrossCoord{X: 3, Y: 4}
+		phase := game.phase.(*phaseInitialSettlements)
+		phase.cursorCross = target
+		x, y := find(t, game.Render(v), "○")
+		phase.cursorCross = game.Board.ValidCrossCoord()
+
+		game.Hover(v, x, y)
+		if phase.cursorCross != target {
+			t.Errorf("%dx%d: expected hovering to move the cursor to %v, got %v", v.Width, v.Height, target, phase.cursorCross)
+		}
+		game.Click(v, x+1, y, "left")
+		if owner, ok := game.Board.Settlements[target]; !ok || owner != 0 {
+			t.Errorf("%dx%d: expected a click to settle %v", v.Width, v.Height, target)
+		}
+	}
+}
+
+func TestClickingAPathBuildsTheRoad(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	from := board.CrossCoord{X: 2, Y: 4}
+	game.Board.SetSettlement(from, 0)
+	game.phase = newPhaseRoadStart(game, PhaseIdle(game), true, PhaseIdle(game), 0)
+	to, _ := from.Right()
+
+	// the path to the right of a crossing is drawn on its line, after it
+	v := Viewport{Width: 160, Height: 50}
+	game.phase.(*phaseRoadStart).cursorCross = from
+	x, y := find(t, game.Render(v), "○")
+	game.Click(v, x+5, y, "left")
+	if owner, ok := game.Board.Roads[board.NewPathCoord(from, to)]; !ok || owner != 0 {
+		t.Errorf("Expected a click on the path to build the road from %v to %v", from, to)
+	}
+}
+
+func TestClickingAMenuOption(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.Players[0].AddResource(board.ResourceOre)
+	game.phase = PhaseIdle(game)
+	v := Viewport{Width: 160, Height: 50}
+
+	idle := game.phase.(*phaseIdle)
+	x, y := find(t, game.Render(v), idle.options[1].Localize(i18n.English))
+	game.Hover(v, x, y)
+	if idle.selected != 1 {
+		t.Errorf("Expected hovering to select %q, got %q", idle.options[1], idle.options[idle.selected])
+	}
+	game.Click(v, x, y, "left")
+	offer, ok := game.phase.(*phaseTradeOffer)
+	if !ok {
+		t.Fatalf("Expected the click to open the trade, got %T", game.phase)
+	}
+
+	x, y = find(t, game.Render(v), board.ResourceOre.String()+":  0 / 1")
+	game.Click(v, x, y, "left")
+	game.Click(v, x, y, "left")
+	if offer.offer[board.ResourceOre] != 1 {
+		t.Errorf("Expected clicks to offer the one ore there is, got %d", offer.offer[board.ResourceOre])
+	}
+	game.Click(v, x, y, "right")
+	if offer.offer[board.ResourceOre] != 0 {
+		t.Errorf("Expected a right click to take it back, got %d", offer.offer[board.ResourceOre])
+	}
+}
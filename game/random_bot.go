@@ -0,0 +1,19 @@
+package game
+
+import "math/rand/v2"
+
+// RandomBot plays any legal action, each as likely as the others. It is the
+// weakest opponent there is, a baseline for comparing bots.
+type RandomBot struct {
+	*SimpleBot // for the places that ask questions
+	rng        *rand.Rand
+}
+
+// NewRandomBot plays the actions picked by the seed
+func NewRandomBot(seed uint64) *RandomBot {
+	return &RandomBot{SimpleBot: NewSimpleBot(), rng: rand.New(rand.NewPCG(seed, seed))}
+}
+
+func (b *RandomBot) Act(view BotView, actions []Action) Action {
+	return actions[b.rng.IntN(len(actions))]
+}
@@ -2,6 +2,7 @@ package game
 
 import (
 	"el_poblador/board"
+	"el_poblador/i18n"
 	"testing"
 )
 
@@ -176,9 +177,9 @@ func TestRobberCannotBePlacedOnSameTile(t *testing.T) {
 	// Should still be in place robber phase with error message
 	if prPhase, ok := newPhase.(*phasePlaceRobber); !ok {
 		t.Fatalf("expected to stay in phasePlaceRobber, got %T", newPhase)
-	} else if prPhase.invalid == "" {
+	} else if prPhase.invalid == nil {
 		t.Fatal("expected invalid message when trying to place robber on same tile")
-	} else if prPhase.invalid != "Robber cannot be moved to the same tile it's already on" {
+	} else if prPhase.invalid.Localize(i18n.English) != "Robber cannot be moved to the same tile it's already on" {
 		t.Fatalf("expected specific error message, got: %s", prPhase.invalid)
 	}
 
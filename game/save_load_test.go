@@ -2,8 +2,10 @@ package game
 
 import (
 	"bytes"
+	"el_poblador/i18n"
 	"encoding/gob"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -62,7 +64,7 @@ func TestSaveLoadRoundtrip(t *testing.T) {
 		}
 	}
 
-	if len(loadedGame.ActionLog) == 0 {
+	if len(loadedGame.Log) == 0 {
 		t.Error("Expected action log to be preserved")
 	}
 
@@ -70,3 +72,21 @@ func TestSaveLoadRoundtrip(t *testing.T) {
 		t.Error("Phase should be restored to PhaseDiceRoll")
 	}
 }
+
+func TestSharedGamesCannotBeSaved(t *testing.T) {
+	game := &Game{}
+	game.Start([]string{"Alice", "Bob", "Charlie"})
+	game.DisableSaving()
+	game.phase = PhaseDiceRoll(game)
+
+	if menu := game.phase.(PhaseWithMenu).Menu(i18n.English); strings.Contains(menu, "Save") {
+		t.Errorf("Expected no Save & Quit in a shared game, got\n%s", menu)
+	}
+	for range 3 {
+		game.MoveCursor("down", nil)
+	}
+	game.ConfirmAction(nil)
+	if game.ShouldQuit() {
+		t.Errorf("Expected the game to go on")
+	}
+}
@@ -0,0 +1,182 @@
+package game
+
+import (
+	"el_poblador/board"
+	"slices"
+)
+
+// SimpleBot is a greedy computer opponent: it settles on the most productive
+// crossings, builds whatever it can afford, cities first, and trades its
+// surplus with the bank for what it is missing.
+type SimpleBot struct{}
+
+func NewSimpleBot() *SimpleBot {
+	return &SimpleBot{}
+}
+
+// what the bot is saving up for, in order of preference
+var (
+	cityCost       = map[board.ResourceType]int{board.ResourceWheat: 2, board.ResourceOre: 3}
+	settlementCost = map[board.ResourceType]int{board.ResourceWood: 1, board.ResourceBrick: 1, board.ResourceWheat: 1, board.ResourceSheep: 1}
+	roadCost       = map[board.ResourceType]int{board.ResourceWood: 1, board.ResourceBrick: 1}
+)
+
+// bestCross returns the most productive of the crossings
+func bestCross(view BotView, crosses []board.CrossCoord) board.CrossCoord {
+	var best board.CrossCoord
+	bestProduction := -1
+	for _, cross := range crosses {
+		if production := view.Production(cross); production > bestProduction {
+			best, bestProduction = cross, production
+		}
+	}
+	return best
+}
+
+func (b *SimpleBot) InitialSettlement(view BotView) board.CrossCoord {
+	return bestCross(view, view.SettlementSpots())
+}
+
+func (b *SimpleBot) InitialRoad(view BotView, settlement board.CrossCoord) board.CrossCoord {
+	return bestCross(view, view.InitialRoadSpots(settlement))
+}
+
+func (b *SimpleBot) KnightBeforeRoll(view BotView) bool {
+	return slices.Contains(view.PlayersAround(view.Robber()), view.Me)
+}
+
+func (b *SimpleBot) Turn(view BotView) Move {
+	switch {
+	case view.CanAfford(MoveBuildCity) && len(view.CitySpots()) > 0:
+		return Move{Kind: MoveBuildCity}
+	case view.CanAfford(MoveBuildSettlement) && len(view.SettlementSpots()) > 0:
+		return Move{Kind: MoveBuildSettlement}
+	case view.CanAfford(MoveBuildRoad) && len(view.SettlementSpots()) == 0 && len(view.RoadSpots()) > 0:
+		return Move{Kind: MoveBuildRoad}
+	case view.CanAfford(MoveBuyDevCard) && !view.CanAfford(MoveBuildSettlement):
+		return Move{Kind: MoveBuyDevCard}
+	}
+	for _, card := range view.DevCards() {
+		if card != DevCardKnight && card != DevCardVictoryPoint {
+			return Move{Kind: MovePlayDevCard, Card: card}
+		}
+	}
+	if give, get, ok := b.bankTrade(view); ok {
+		return Move{Kind: MoveBankTrade, Give: give, Get: get}
+	}
+	return Move{Kind: MoveEndTurn}
+}
+
+// missing returns what the bot lacks for its next build, most needed first
+func (b *SimpleBot) missing(view BotView) []board.ResourceType {
+	resources := view.Resources()
+	goal := settlementCost
+	if len(view.CitySpots()) > 0 && resources[board.ResourceOre] >= resources[board.ResourceWood] {
+		goal = cityCost
+	} else if len(view.SettlementSpots()) == 0 {
+		goal = roadCost
+	}
+	var missing []board.ResourceType
+	for _, resource := range board.RESOURCE_TYPES {
+		for i := resources[resource]; i < goal[resource]; i++ {
+			missing = append(missing, resource)
+		}
+	}
+	return missing
+}
+
+// bankTrade trades away a resource the bot has plenty of for one it misses
+func (b *SimpleBot) bankTrade(view BotView) (board.ResourceType, board.ResourceType, bool) {
+	missing := b.missing(view)
+	if len(missing) == 0 {
+		return 0, 0, false
+	}
+	resources := view.Resources()
+	for _, resource := range board.RESOURCE_TYPES {
+		if resources[resource] >= 4+cityCost[resource]+settlementCost[resource] && !slices.Contains(missing, resource) {
+			return resource, missing[0], true
+		}
+	}
+	return 0, 0, false
+}
+
+func (b *SimpleBot) Road(view BotView) board.PathCoord {
+	spots := view.RoadSpots()
+	if len(spots) == 0 {
+		return board.PathCoord{}
+	}
+	best := spots[0]
+	for _, spot := range spots {
+		if view.Production(spot.To) > view.Production(best.To) {
+			best = spot
+		}
+	}
+	return best
+}
+
+func (b *SimpleBot) Settlement(view BotView) board.CrossCoord {
+	return bestCross(view, view.SettlementSpots())
+}
+
+func (b *SimpleBot) City(view BotView) board.CrossCoord {
+	return bestCross(view, view.CitySpots())
+}
+
+// Robber blocks the busiest tile without the bot on it
+func (b *SimpleBot) Robber(view BotView) board.TileCoord {
+	var best board.TileCoord
+	bestScore := -1
+	tiles := view.Tiles()
+	// in board order rather than map order, so the same game plays the same
+	for _, coord := range allTileCoords() {
+		tile := tiles[coord]
+		players := view.PlayersAround(coord)
+		if coord == view.Robber() || slices.Contains(players, view.Me) {
+			continue
+		}
+		if score := len(players) * board.Pips(tile.DiceNumber); score > bestScore {
+			best, bestScore = coord, score
+		}
+	}
+	return best
+}
+
+// Steal takes from the biggest hand
+func (b *SimpleBot) Steal(view BotView, victims []int) int {
+	best := victims[0]
+	for _, victim := range victims {
+		if view.HandSize(victim) > view.HandSize(best) {
+			best = victim
+		}
+	}
+	return best
+}
+
+// Discard gives up the most plentiful resources
+func (b *SimpleBot) Discard(view BotView, amount int) map[board.ResourceType]int {
+	resources := view.Resources()
+	discard := make(map[board.ResourceType]int)
+	for i := 0; i < amount; i++ {
+		most := board.RESOURCE_TYPES[0]
+		for _, resource := range board.RESOURCE_TYPES {
+			if resources[resource] > resources[most] {
+				most = resource
+			}
+		}
+		resources[most]--
+		discard[most]++
+	}
+	return discard
+}
+
+func (b *SimpleBot) Monopoly(view BotView) board.ResourceType {
+	if missing := b.missing(view); len(missing) > 0 {
+		return missing[0]
+	}
+	return board.ResourceWheat
+}
+
+func (b *SimpleBot) YearOfPlenty(view BotView) (board.ResourceType, board.ResourceType) {
+	missing := append(b.missing(view), board.ResourceOre, board.ResourceWheat)
+	return missing[0], missing[1]
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"el_poblador/board"
+	"slices"
+)
+
+// targeter is a phase placing something on the board. targets lists the
+// crossings its cursor can be confirmed at, in board order, so they can be
+// highlighted and the cursor can jump from one to the next.
+type targeter interface {
+	targets() []board.CrossCoord
+}
+
+// highlights marks where the player can place what the phase waiting on them
+// is placing, if any
+func (g *Game) highlights(player int) board.Highlights {
+	t, ok := g.phase.(targeter)
+	if !ok || g.decisionFor(player) != nil || !g.phaseWaitsOn(player) {
+		return nil
+	}
+	// the end of a road is shown by the road leading to it
+	var roadFrom *board.CrossCoord
+	switch p := g.phase.(type) {
+	case *phaseInitialRoad:
+		roadFrom = &p.sourceCross
+	case *phaseRoadEnd:
+		roadFrom = &p.startCross
+	}
+	highlights := make(board.Highlights)
+	for _, cross := range t.targets() {
+		if roadFrom != nil {
+			highlights[board.NewPathCoord(*roadFrom, cross)] = true
+		} else {
+			highlights[cross] = true
+		}
+	}
+	return highlights
+}
+
+// nextTarget returns the target following the cursor in board order, starting
+// over after the last one
+func nextTarget(cursor board.CrossCoord, targets []board.CrossCoord) board.CrossCoord {
+	if len(targets) == 0 {
+		return cursor
+	}
+	for _, target := range targets {
+		if target.X > cursor.X || target.X == cursor.X && target.Y > cursor.Y {
+			return target
+		}
+	}
+	return targets[0]
+}
+
+func (p *phaseInitialSettlements) targets() []board.CrossCoord {
+	return p.game.settlementSpots(p.game.PlayerTurn, true)
+}
+
+func (p *phaseInitialRoad) targets() []board.CrossCoord {
+	return p.game.initialRoadSpots(p.sourceCross)
+}
+
+func (p *phaseSettlementPlacement) targets() []board.CrossCoord {
+	return p.game.settlementSpots(p.game.PlayerTurn, false)
+}
+
+func (p *phaseCityPlacement) targets() []board.CrossCoord {
+	return p.game.citySpots(p.game.PlayerTurn)
+}
+
+func (p *phaseRoadStart) targets() []board.CrossCoord {
+	player := p.game.PlayerTurn
+	var starts []board.CrossCoord
+	for _, at := range allCrossCoords() {
+		if !p.game.Board.HasRoadConnected(at, player) && !p.game.Board.HasSettlementAt(at, player) {
+			continue
+		}
+		if slices.ContainsFunc(at.Neighbors(), func(to board.CrossCoord) bool {
+			return p.game.canBuildRoad(board.PathCoord{From: at, To: to}, player)
+		}) {
+			starts = append(starts, at)
+		}
+	}
+	return starts
+}
+
+func (p *phaseRoadEnd) targets() []board.CrossCoord {
+	var ends []board.CrossCoord
+	for _, to := range allCrossCoords() {
+		if slices.Contains(p.startCross.Neighbors(), to) &&
+			p.game.canBuildRoad(board.PathCoord{From: p.startCross, To: to}, p.game.PlayerTurn) {
+			ends = append(ends, to)
+		}
+	}
+	return ends
+}
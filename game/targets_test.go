@@ -0,0 +1,77 @@
+package game
+
+import (
+	"el_poblador/board"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRoadTargetsAreHighlighted(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	from := board.CrossCoord{X: 2, Y: 4}
+	game.Board.SetSettlement(from, 0)
+	game.phase = newPhaseRoadStart(game, PhaseIdle(game), true, PhaseIdle(game), 0)
+
+	first := 0
+	if highlights := game.highlights(first); len(highlights) != 1 || !highlights[from] {
+		t.Errorf("Expected only the settlement to be highlighted as a start, got %v", highlights)
+	}
+	if highlights := game.highlights(1); highlights != nil {
+		t.Errorf("Expected nothing highlighted for p2, it isn't their turn, got %v", highlights)
+	}
+	// a start isn't marked like a settlement that can become a city, even
+	// without colors
+	game.MoveCursor("up", &first)
+	if view := ansi.Strip(game.Render(Viewport{Width: 160, Height: 50, Theme: "monochrome"})); !strings.Contains(view, "·A·") {
+		t.Errorf("Expected the settlement marked as a road start, got\n%s", view)
+	}
+	game.MoveCursor("next", &first)
+	if cursor := game.phase.BoardCursor(); cursor != from {
+		t.Errorf("Expected the cursor to jump to %v, got %v", from, cursor)
+	}
+
+	game.ConfirmAction(&first)
+	highlights := game.highlights(first)
+	if len(highlights) != len(from.Neighbors()) {
+		t.Errorf("Expected a road to every neighbor to be highlighted, got %v", highlights)
+	}
+	for _, to := range from.Neighbors() {
+		if !highlights[board.NewPathCoord(from, to)] {
+			t.Errorf("Expected the road to %v to be highlighted", to)
+		}
+	}
+	// the jump visits every end in turn
+	var visited []board.CrossCoord
+	for range from.Neighbors() {
+		game.MoveCursor("next", &first)
+		visited = append(visited, game.phase.BoardCursor().(board.CrossCoord))
+	}
+	for _, to := range from.Neighbors() {
+		if !slices.Contains(visited, to) {
+			t.Errorf("Expected jumping to reach %v, got %v", to, visited)
+		}
+	}
+}
+
+func TestCitySpotsAreHighlighted(t *testing.T) {
+	game := &Game{}
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	game.phase = PhaseCityPlacement(game, PhaseIdle(game))
+	game.Board.SetSettlement(board.CrossCoord{X: 2, Y: 4}, 0)
+	game.Board.SetSettlement(board.CrossCoord{X: 3, Y: 7}, 1)
+	if highlights := game.highlights(0); len(highlights) != 1 || !highlights[board.CrossCoord{X: 2, Y: 4}] {
+		t.Errorf("Expected p1's settlement to be highlighted, got %v", highlights)
+	}
+	// highlighting doesn't shift anything on the board
+	lines := game.Board.Print(nil, board.PrintOptions{})
+	highlighted := game.Board.Print(nil, board.PrintOptions{Highlights: game.highlights(0)})
+	for i := range lines {
+		if ansi.StringWidth(lines[i]) != ansi.StringWidth(highlighted[i]) {
+			t.Errorf("Expected line %d to keep its width, got %d and %d", i, ansi.StringWidth(lines[i]), ansi.StringWidth(highlighted[i]))
+		}
+	}
+}
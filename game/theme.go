@@ -0,0 +1,98 @@
+package game
+
+import (
+	"el_poblador/board"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// theme returns the theme the viewport asks for, the default if it names none
+func (v Viewport) theme() *board.Theme {
+	if theme, ok := board.ThemeNamed(v.Theme); ok {
+		return theme
+	}
+	return board.DefaultTheme
+}
+
+// wearTheme draws the players in the theme's colors until restore is called
+func (g *Game) wearTheme(theme *board.Theme) (restore func()) {
+	own := make([]lipgloss.AdaptiveColor, len(g.Players))
+	for i := range g.Players {
+		own[i] = g.Players[i].Color
+		g.Players[i].Color = theme.PlayerColor(own[i])
+	}
+	return func() {
+		for i, color := range own {
+			g.Players[i].Color = color
+		}
+	}
+}
+
+// ownerSuffix names the letter monochrome themes mark the player's pieces with
+func ownerSuffix(theme *board.Theme, player int) string {
+	if !theme.Monochrome {
+		return ""
+	}
+	return " [" + board.OwnerLetter(player) + "]"
+}
+
+// asciiGlyphs stands in for every glyph drawn beyond ASCII with characters as
+// wide, so nothing moves on screen
+var asciiGlyphs = strings.NewReplacer(
+	"▲", "^", "█", "#", "░", ".", "‾", "-", "○", "O", "·", ".", "•", "*",
+	"→", ">", "←", "<", "↑", "^", "↓", "v",
+	"▁", "_", "▂", ".", "▃", ",", "▄", "-", "▅", "=", "▆", "+", "▇", "*",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "─", "-", "│", "|",
+	"🎉", "**", "🏆", "#1",
+)
+
+// ASCII redraws a rendered screen with ASCII characters only, for terminals
+// that mangle the others
+func ASCII(s string) string {
+	return asciiGlyphs.Replace(s)
+}
+
+// stripColors removes the colors from a rendered screen and keeps the rest of
+// its styling, like bold and reverse video
+func stripColors(s string) string {
+	var out strings.Builder
+	for {
+		start := strings.Index(s, "\x1b[")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start:], 'm')
+		if end < 0 {
+			break
+		}
+		out.WriteString(s[:start])
+		params := strings.Split(s[start+2:start+end], ";")
+		var kept []string
+		for i := 0; i < len(params); i++ {
+			n, err := strconv.Atoi(params[i])
+			switch {
+			case err != nil && params[i] != "":
+				// not a plain style sequence: leave it alone
+				kept = append(kept, params[i])
+			case n == 38 || n == 48 || n == 58:
+				// extended colors: 5;n or 2;r;g;b
+				if i+1 < len(params) && params[i+1] == "5" {
+					i += 2
+				} else {
+					i += 4
+				}
+			case n >= 30 && n <= 49 || n >= 90 && n <= 107:
+			default:
+				kept = append(kept, params[i])
+			}
+		}
+		if len(kept) > 0 {
+			out.WriteString("\x1b[" + strings.Join(kept, ";") + "m")
+		}
+		s = s[start+end+1:]
+	}
+	out.WriteString(s)
+	return out.String()
+}
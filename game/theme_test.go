@@ -0,0 +1,51 @@
+package game
+
+import (
+	"el_poblador/board"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestStripColorsKeepsOtherStyles(t *testing.T) {
+	got := stripColors("\x1b[1;38;2;1;2;3;41mbold\x1b[0m \x1b[38;5;9;7mreverse\x1b[m")
+	if want := "\x1b[1mbold\x1b[0m \x1b[7mreverse\x1b[m"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestThemedViews(t *testing.T) {
+	game := &Game{}
+	game.Start([]string{"p1", "p2", "p3"})
+	// away from the cursor
+	game.Board.SetSettlement(board.CrossCoord{X: 3, Y: 4}, 0)
+	v := Viewport{Width: 160, Height: 50}
+	plain := strings.Split(ansi.Strip(game.Render(v)), "\n")
+
+	v.ASCII = true
+	ascii := strings.Split(game.Render(v), "\n")
+	for i, line := range ascii {
+		if strings.ContainsFunc(line, func(r rune) bool { return r > unicode.MaxASCII }) {
+			t.Errorf("Expected ASCII only, line %d is %q", i, ansi.Strip(line))
+		}
+		if ansi.StringWidth(line) != ansi.StringWidth(plain[i]) {
+			t.Errorf("Expected line %d to keep its width %d, got %d", i, ansi.StringWidth(plain[i]), ansi.StringWidth(line))
+		}
+	}
+
+	own := game.Players[0].Color
+	v = Viewport{Width: 160, Height: 50, Theme: "monochrome"}
+	view := game.Render(v)
+	if strings.Contains(view, "\x1b[38;") {
+		t.Errorf("Expected no colors in monochrome")
+	}
+	first := game.Players[0].Name
+	if !strings.Contains(view, first+" [A]") || !strings.Contains(ansi.Strip(view), "▲A▲") {
+		t.Errorf("Expected %s's letter by their name and on their settlement, got\n%s", first, ansi.Strip(view))
+	}
+	if game.Players[0].Color != own {
+		t.Errorf("Expected the players to get their own colors back after drawing")
+	}
+}
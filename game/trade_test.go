@@ -2,6 +2,7 @@ package game
 
 import (
 	"el_poblador/board"
+	"el_poblador/i18n"
 	"testing"
 )
 
@@ -311,7 +312,7 @@ func TestInvalidTradeNotYetImplemented(t *testing.T) {
 		t.Fatalf("Should be in idle phase, got: %T", game.phase)
 	}
 
-	if idlePhase.notification != "Trade type not yet implemented" {
+	if idlePhase.notification == nil || idlePhase.notification.Localize(i18n.English) != "Trade type not yet implemented" {
 		t.Fatalf("Expected 'not yet implemented', got: %s", idlePhase.notification)
 	}
 
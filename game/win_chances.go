@@ -0,0 +1,157 @@
+package game
+
+import (
+	"el_poblador/i18n"
+	"fmt"
+	"strings"
+)
+
+const (
+	// winRollouts is how many games the meter plays out for an estimate
+	winRollouts = 40
+	// winRolloutSteps is how far each of them goes before it is scored
+	winRolloutSteps = 80
+)
+
+// WinChance is an estimate of each player's chance to win, by seat, made
+// during the turn
+type WinChance struct {
+	Turn    int
+	Chances []float64
+}
+
+// EstimateWinChances plays the game on from here a number of times with Normal
+// bots in every seat and returns how often each player comes out ahead. The
+// playouts stop after a while and are scored like the MCTS bot scores them.
+// Nobody's hand is known, so the development cards and resources are dealt
+// again for each playout (see DeterminizedClone) and the meter gives away
+// nothing a spectator couldn't see. The same seed gives the same estimate.
+func (g *Game) EstimateWinChances(rollouts int, seed uint64) []float64 {
+	chances := make([]float64, len(g.Players))
+	if winner := g.CheckGameEnd(); winner != nil {
+		chances[g.getPlayerID(winner)] = 1
+		return chances
+	}
+	for i := 0; i < rollouts; i++ {
+		clone := g.DeterminizedClone(-1, seed+uint64(i))
+		for player := range clone.Players {
+			clone.SetBot(player, NewHeuristicBotSeeded(Normal, seed+uint64(i)))
+		}
+		clone.playBots(winRolloutSteps)
+		scores := playoutScores(clone)
+		total := 0.0
+		for _, score := range scores {
+			total += score
+		}
+		for player, score := range scores {
+			chances[player] += score / total / float64(rollouts)
+		}
+	}
+	return chances
+}
+
+// UpdateWinChances estimates the chances again if anything happened since the
+// last estimate, and reports whether it did. Interfaces call it now and then
+// to keep the meter live; games that never call it pay nothing for it.
+func (g *Game) UpdateWinChances() bool {
+	e := g.StartWinChances()
+	if e == nil {
+		return false
+	}
+	e.Run()
+	return g.FinishWinChances(e)
+}
+
+// WinChanceEstimate is an estimate worked out on a copy of the game, so the
+// game itself can go on while it runs
+type WinChanceEstimate struct {
+	game    *Game
+	logged  int
+	seed    uint64
+	chances []float64
+}
+
+// StartWinChances copies the game for an estimate if anything happened since
+// the last one, and returns nil otherwise. Run the estimate, then hand it to
+// FinishWinChances.
+func (g *Game) StartWinChances() *WinChanceEstimate {
+	if len(g.Players) == 0 || g.phase == nil || (g.logged == g.estimated && len(g.WinChances) > 0) {
+		return nil
+	}
+	return &WinChanceEstimate{game: g.Clone(), logged: g.logged, seed: uint64(len(g.WinChances))}
+}
+
+// Run plays the rollouts of the estimate. It doesn't touch the game it was
+// started from, and can run without holding it.
+func (e *WinChanceEstimate) Run() {
+	e.chances = e.game.EstimateWinChances(winRollouts, e.seed)
+}
+
+// FinishWinChances records the estimate, unless one made later got there
+// first, and reports whether it did
+func (g *Game) FinishWinChances(e *WinChanceEstimate) bool {
+	if e.chances == nil || (e.logged <= g.estimated && len(g.WinChances) > 0) {
+		return false
+	}
+	g.estimated = e.logged
+	g.WinChances = append(g.WinChances, WinChance{Turn: e.game.TurnsPlayed, Chances: e.chances})
+	return true
+}
+
+// winChancesText shows the latest estimate as a bar for each player
+func (g *Game) winChancesText(l i18n.Locale) string {
+	if len(g.WinChances) == 0 {
+		return ""
+	}
+	chances := g.WinChances[len(g.WinChances)-1].Chances
+	lines := []string{l.T("chances.title")}
+	for i, player := range g.Players {
+		if i >= len(chances) {
+			break
+		}
+		filled := int(chances[i]*10 + 0.5)
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", 10-filled)
+		lines = append(lines, fmt.Sprintf("%s %3.0f%% %s", player.Render(bar), chances[i]*100, player.Render(player.Name)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// winChancesGraph draws each player's chances over the game as a line of
+// width columns, and names the estimate that moved them the most
+func (g *Game) winChancesGraph(l i18n.Locale, width int) string {
+	history := g.WinChances
+	if len(history) < 2 {
+		return ""
+	}
+	columns := min(width, len(history))
+	lines := []string{l.T("chances.graph")}
+	for i, player := range g.Players {
+		var line []rune
+		for c := 0; c < columns; c++ {
+			chances := history[c*(len(history)-1)/max(columns-1, 1)].Chances
+			if i >= len(chances) {
+				line = append(line, ' ')
+				continue
+			}
+			line = append(line, sparks[min(int(chances[i]*float64(len(sparks))), len(sparks)-1)])
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", player.Render(string(line)), player.Render(player.Name)))
+	}
+
+	swing, turn, who := 0.0, 0, 0
+	for e := 1; e < len(history); e++ {
+		before, after := history[e-1].Chances, history[e].Chances
+		for i := range after {
+			if i < len(before) && after[i]-before[i] > swing {
+				swing, turn, who = after[i]-before[i], history[e].Turn, i
+			}
+		}
+	}
+	if swing > 0 && who < len(g.Players) {
+		player := g.Players[who]
+		lines = append(lines, l.T("chances.swing", player.Render(player.Name), swing*100, turn+1))
+	}
+	return strings.Join(lines, "\n")
+}
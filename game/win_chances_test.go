@@ -0,0 +1,72 @@
+package game
+
+import (
+	"el_poblador/board"
+	"el_poblador/i18n"
+	"github.com/charmbracelet/lipgloss"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWinChancesAddUpToOne(t *testing.T) {
+	game := &Game{}
+	game.Seed(3)
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	for player := range game.Players {
+		game.SetBot(player, NewHeuristicBot(Normal))
+	}
+	game.playBots(150)
+
+	chances := game.EstimateWinChances(10, 1)
+	total := 0.0
+	for _, chance := range chances {
+		if chance <= 0 || chance >= 1 {
+			t.Errorf("Expected every chance between 0 and 1 mid-game, got %v", chances)
+		}
+		total += chance
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Expected the chances to add up to 1, got %v", total)
+	}
+	if again := game.EstimateWinChances(10, 1); !slices.Equal(again, chances) {
+		t.Errorf("Expected the same seed to give the same estimate, got %v and %v", chances, again)
+	}
+}
+
+func TestWinChancesFollowTheGame(t *testing.T) {
+	game := &Game{}
+	game.Seed(5)
+	game.StartSeated([]Seat{{Name: "p1"}, {Name: "p2"}, {Name: "p3"}})
+	deck := len(game.DevCardDeck)
+
+	if !game.UpdateWinChances() {
+		t.Fatalf("Expected a first estimate")
+	}
+	if game.UpdateWinChances() {
+		t.Errorf("Expected no new estimate while nothing happened")
+	}
+	if len(game.DevCardDeck) != deck {
+		t.Errorf("Expected the estimate to leave the game alone")
+	}
+	if sidebar, _ := game.buildSidebar(i18n.English, 0, false, lipgloss.NewStyle(), board.DefaultTheme); !strings.Contains(sidebar, "Chances to win") {
+		t.Errorf("Expected the meter in the sidebar")
+	}
+
+	for player := range game.Players {
+		game.SetBot(player, NewHeuristicBot(Normal))
+	}
+	for !game.isOver() {
+		game.playBots(100)
+		game.UpdateWinChances()
+	}
+	last := game.WinChances[len(game.WinChances)-1]
+	winner := game.getPlayerID(game.CheckGameEnd())
+	if last.Chances[winner] != 1 {
+		t.Errorf("Expected the last estimate to be sure of the winner, got %v", last.Chances)
+	}
+	if graph := game.phase.(PhaseWithMenu).Menu(i18n.English); !strings.Contains(graph, "Biggest swing") {
+		t.Errorf("Expected the graph on the game over screen, got\n%s", graph)
+	}
+}
@@ -0,0 +1,331 @@
+package i18n
+
+// english is the catalog every other falls back on
+var english = Catalog{
+	// Resources and cards
+	"resource.ore":        "Ore",
+	"resource.wood":       "Wood",
+	"resource.sheep":      "Wool",
+	"resource.wheat":      "Wheat",
+	"resource.brick":      "Brick",
+	"resource.unknown":    "Unknown",
+	"card.knight":         "Knight",
+	"card.road_building":  "Road Building",
+	"card.monopoly":       "Monopoly",
+	"card.year_of_plenty": "Year of Plenty",
+	"card.victory_point":  "Victory Point",
+	"amount":              "%d %s",
+	"list.and":            "%s and %s",
+	"list.nothing":        "nothing",
+
+	// The board: tile labels are four letters wide, the robber's three
+	"board.wood":   "WOOD",
+	"board.brick":  "CLAY",
+	"board.ore":    "MNTN",
+	"board.wheat":  "WHEA",
+	"board.sheep":  "PAST",
+	"board.desert": "DESE",
+	"board.robber": "ROB",
+
+	// Sidebar
+	"sidebar.dice":         "Dice: %d (%d + %d)",
+	"sidebar.not_rolled":   "Dice: not rolled yet",
+	"sidebar.you":          "%s (you)",
+	"sidebar.hand":         " has %d resources, %d dev cards",
+	"sidebar.resources":    "Your resources:",
+	"sidebar.resource":     "%s: %d",
+	"sidebar.dev_cards":    "Dev Cards: %d",
+	"sidebar.points":       "Victory Points: %d",
+	"sidebar.shown_points": " %d victory points showing",
+	"help.turn":            "%s's turn. %s",
+
+	// Turn
+	"menu.roll":          "Roll",
+	"menu.play_knight":   "Play Knight",
+	"menu.save_quit":     "Save & Quit",
+	"help.save_failed":   "Save failed: %s",
+	"help.roll":          "Time to roll the dice",
+	"log.rolled":         "%s gained %s from dice roll (%d)",
+	"menu.build":         "Build",
+	"menu.trade":         "Trade",
+	"menu.play_card":     "Play Development Card",
+	"menu.end_turn":      "End Turn",
+	"help.anything_else": "%s Anything else?",
+	"help.idle":          "What do you want to do?",
+
+	// Building
+	"menu.road":             "Road",
+	"menu.settlement":       "Settlement",
+	"menu.city":             "City",
+	"menu.dev_card":         "Development Card",
+	"menu.cancel_esc":       "Cancel (or 'esc')",
+	"menu.cancel":           "Cancel",
+	"help.build":            "Choose what to build",
+	"help.place_settlement": "Select where to place your settlement",
+	"help.place_city":       "Select a settlement to upgrade to a city",
+
+	// Development cards
+	"help.play_card":             "Choose a development card to play",
+	"err.no_such_resource":       "There is no such resource",
+	"log.monopoly":               "%s collected %d %s from all players",
+	"notice.monopoly":            "Collected %d %s from other players!",
+	"log.monopoly_nothing":       "%s monopolized %s but collected nothing",
+	"notice.monopoly_nothing":    "No resources collected - nobody had any!",
+	"help.monopoly":              "Select a resource type to collect from all players",
+	"log.year_of_plenty":         "%s gained %s and %s from the bank",
+	"notice.year_of_plenty":      "Gained %s and %s from the bank!",
+	"help.year_of_plenty_first":  "Select first resource to gain from the bank",
+	"help.year_of_plenty_second": "Selected: %s. Select second resource to gain from the bank",
+
+	// Initial placement
+	"err.settlement_here":    "Can't build settlement here",
+	"log.initial_resources":  "%s gained %s from initial settlement",
+	"help.first_settlement":  "Place your first settlement on the board with 'enter'.",
+	"help.second_settlement": "Place your second settlement on the board with 'enter'.",
+	"err.initial_road":       "The road has to leave the new settlement",
+	"help.initial_road":      "Place a road connected to the settlement by selecting its direction.",
+
+	// Robber
+	"help.robber":      "Select a tile to place the robber on",
+	"err.no_such_tile": "There is no such tile",
+	"err.robber_stays": "Robber cannot be moved to the same tile it's already on",
+	"log.robber":       "%s moved the robber",
+	"help.steal":       "Select a player to steal from",
+	"err.steal":        "You can't steal from that player",
+	"log.steal":        "%s stole a card from %s",
+
+	// Roads
+	"notice.road":                 "Road built!",
+	"notice.free_roads":           "Two free roads built!",
+	"help.road_unconnected":       "You must have a road or settlement connected to this crossing",
+	"help.road_start":             "Select the starting point for your road",
+	"help.road_start_first_free":  "Select the starting point for your first free road",
+	"help.road_start_second_free": "Select the starting point for your second free road",
+	"help.road_end":               "Select the ending point for your road",
+	"help.road_end_first_free":    "Select the ending point for your first free road",
+	"help.road_end_second_free":   "Select the ending point for your second free road",
+
+	// Trade
+	"menu.offer":     "What do you want to offer?",
+	"menu.receive":   "What do you want to receive?",
+	"menu.amount_of": "%s:  %d / %d",
+	"menu.amount":    "%s:  %d",
+	"help.trade":     "Use ←/→ to adjust, ↑/↓ to move, Enter to confirm, Esc to cancel",
+
+	// End of the game
+	"end.game_over":  "🎉 GAME OVER! 🎉",
+	"end.wins":       "%s WINS!",
+	"end.scores":     "Final Scores:",
+	"end.score":      "%s %s: %d points",
+	"help.game_over": "Game complete! %s reached 10 victory points!",
+
+	// Decisions
+	"help.waiting":       "Waiting for %s",
+	"err.discard_that":   "You can't discard that",
+	"err.discard_amount": "You have to discard %d cards",
+	"log.discard":        "%s discarded %d cards",
+	"help.discard_more":  "Discard %d more cards, use ←/→ to adjust",
+	"help.discard":       "Enter to discard these cards",
+	"menu.discard":       "Discard %d cards",
+
+	// Actions
+	"err.not_your_turn":      "it's not your turn",
+	"err.not_now":            "you can't do that now",
+	"err.no_resources":       "Not enough resources",
+	"log.turn":               "Turn passed to %s",
+	"err.road_here":          "Can't build road here",
+	"log.free_road":          "%s built a free road",
+	"log.road":               "%s built a road",
+	"log.settlement":         "%s built a settlement",
+	"notice.settlement":      "Settlement built!",
+	"err.city_here":          "Can't upgrade to city here",
+	"log.city":               "%s upgraded to a city",
+	"notice.city":            "City built!",
+	"err.no_cards_left":      "No development cards left",
+	"log.bought_card":        "%s bought a development card",
+	"notice.bought_card":     "Bought a %s card!",
+	"err.victory_point_card": "Victory point cards count by themselves",
+	"err.no_card":            "You don't have a %s card",
+	"log.played":             "%s played %s",
+	"err.trade_type":         "Trade type not yet implemented",
+	"err.bank_trade":         "Not enough resources for bank trade!",
+	"log.bank_trade":         "%s traded 4 %s for 1 %s with the bank",
+	"notice.bank_trade":      "Traded 4 %s for 1 %s!",
+
+	// Hints
+	"hint.shown":           "Hint: %s.",
+	"hint.settle":          "settle here, %s",
+	"hint.settle_book":     "settle here, %s; spots like it won %.0f%% of %d simulated games",
+	"hint.city":            "upgrade here to double its %d pips",
+	"hint.road":            "build a road toward a free crossing with %d pips",
+	"hint.road_best":       "build a road toward the best free crossing in reach",
+	"hint.buy_card":        "buy a development card with the cards you can spare",
+	"hint.knight":          "play a knight to move the robber off your tiles",
+	"hint.road_building":   "play Road Building for two free roads",
+	"hint.play_card":       "play %s to get the %s you need for %s",
+	"hint.take":            "take %s, which you need for %s",
+	"hint.roll":            "roll, there is nothing worth playing first",
+	"hint.robber":          "move the robber to this %d-pip tile",
+	"hint.block":           "block this %d-pip tile of %s",
+	"hint.steal":           "steal from %s, who has %d visible points and %d cards",
+	"hint.discard":         "discard %s and keep what you need for %s",
+	"hint.trade":           "trade %s for %s, which you need for %s",
+	"hint.save":            "end your turn and save for %s, you need %s",
+	"hint.end_turn":        "end your turn",
+	"hint.cross":           "%d pips from %s",
+	"hint.cross_adding":    "%d pips from %s, adding %s to what you produce",
+	"hint.cross_different": "%d pips from %s, %d different resources",
+	"goal.city":            "a city",
+	"goal.settlement":      "a settlement",
+	"goal.road":            "a road",
+	"goal.dev_card":        "a development card",
+
+	// Clock
+	"log.out_of_time": "%s ran out of time",
+	"clock.turn":      "Turn clock: %s",
+	"clock.yours":     "Your time: %s",
+	"clock.out":       "%s is out of time!",
+
+	// Chances to win
+	"chances.title": "Chances to win:",
+	"chances.graph": "Chances to win over the game:",
+	"chances.swing": "Biggest swing: %s +%.0f%% on turn %d",
+
+	// Overlays
+	"overlay.pips":       "Pips: a dot for every way to roll the number, out of 36. o: next overlay",
+	"overlay.production": "Production: pips around each spot open to settle, blue poor to red rich. o: next overlay",
+	"overlay.one_road":   "%s 1 road",
+	"overlay.roads":      "%s %s roads",
+	"overlay.networks":   "Networks: • where roads reach, %s. Red: blocked by the robber. o: hide",
+	"overlay.no_roads":   "no roads yet",
+
+	// Reading the whole log
+	"log.filter":             "Player: %s  Event: %s",
+	"log.filter_all":         "all",
+	"log.event_production":   "production",
+	"log.event_trades":       "trades",
+	"log.event_robber":       "robber",
+	"log.event_builds":       "builds",
+	"log.event_cards":        "cards",
+	"log.event_turns":        "turns",
+	"log.search_placeholder": "'/' to search",
+	"log.no_entries":         "Nothing matches",
+	"log.help":               "Log: %s %s scroll, %s %s by page, %s player, %s event, %s search, %s back to the game",
+
+	// Chat
+	"chat.err_spectator": "only seated players can chat",
+	"chat.err_no_player": "there is no player called %s",
+	"chat.err_yourself":  "you can't whisper to yourself",
+	"chat.err_empty":     "nothing to say",
+	"chat.placeholder":   "'c' to chat, '/w name' to whisper",
+
+	// Command line: the commands themselves are English in every locale
+	"command.failed":           "%s: %s",
+	"command.empty":            "type a command, like settle 2,4",
+	"command.usage":            "usage: %s",
+	"command.usage_because":    "usage: %s (%s)",
+	"command.not_next":         "%s and %s aren't next to each other",
+	"command.unknown":          "unknown command %q, try %s",
+	"command.not_a_place":      "%q isn't a place on the board, write it as x,y",
+	"command.no_crossing":      "there is no crossing at %s",
+	"command.no_tile":          "there is no tile at %s",
+	"command.unknown_resource": "unknown resource %q",
+	"command.amounts":          "give an amount and a resource",
+	"command.not_an_amount":    "%q isn't an amount",
+	"command.ambiguous_player": "%q could be %s or %s",
+	"command.no_player":        "nobody is called %q",
+	"command.cursor":           "cursor at %s",
+
+	// Lobby
+	"lobby.err_no_table":         "no such table",
+	"lobby.err_not_member":       "you have not joined this table",
+	"lobby.err_not_host":         "only the host can do that",
+	"lobby.err_started":          "game already started",
+	"lobby.err_not_started":      "game has not started yet",
+	"lobby.err_seat_taken":       "seat is taken",
+	"lobby.err_color_taken":      "color is taken",
+	"lobby.err_not_seated":       "you must take a seat first",
+	"lobby.err_bad_token":        "name is taken, reconnect with its token",
+	"lobby.err_seats":            "tables have 3 or 4 seats, not %d",
+	"lobby.err_takeover":         "unknown takeover %q",
+	"lobby.err_no_seat":          "seat %d does not exist",
+	"lobby.err_color":            "unknown color %q",
+	"lobby.err_not_ready":        "%s is not ready",
+	"lobby.err_too_few":          "need at least 3 seated players, have %d",
+	"lobby.err_seat_not_in_play": "seat %d is not in play",
+	"lobby.err_still_connected":  "%s is still connected",
+	"lobby.err_has_seat":         "%s already has a seat",
+	"lobby.err_disconnected":     "disconnected from server, reconnecting",
+	"lobby.takeover_host":        "host reassigns the seat",
+	"lobby.takeover_bot":         "computer plays the seat",
+	"lobby.seats":                "Seats: ← %d →",
+	"lobby.wait":                 "Wait for disconnected players: ← %s →",
+	"lobby.forever":              "forever",
+	"lobby.then":                 "Then: ← %s →",
+	"lobby.turn_time":            "Time per turn: ← %s →",
+	"lobby.player_time":          "Time per player: ← %s →",
+	"lobby.unlimited":            "unlimited",
+	"lobby.new_table":            "New table",
+	"lobby.help_creating":        "↑/↓: option, ←/→: change, enter: create, esc: back",
+	"lobby.help_table":           "↑/↓: seat, ←/→: color, enter: sit, r: ready, s: start (host), esc: leave",
+	"lobby.help_list":            "↑/↓: select, enter: join, n: new table, q: quit",
+	"lobby.tables":               "Tables (playing as %s)",
+	"lobby.no_tables":            "No tables yet, press 'n' to create one",
+	"lobby.open":                 "open",
+	"lobby.in_progress":          "in progress",
+	"lobby.table":                "%s  %d/%d seated, %s",
+	"lobby.host":                 "%s (host: %s)",
+	"lobby.your_color":           "Your color: ← %s →",
+	"lobby.seat_open":            "Seat %d: open",
+	"lobby.not_ready":            "not ready",
+	"lobby.ready":                "ready",
+	"lobby.seat":                 "Seat %d: %s (%s), %s",
+	"lobby.joined":               "Joined: %s",
+	"lobby.status_bot":           "%s is played by the computer",
+	"lobby.status_abandoned":     "%s abandoned their seat",
+	"lobby.status_disconnected":  "%s is disconnected",
+	"lobby.give_bot":             "%s: give seat to the computer",
+	"lobby.give_bot_or":          "%s, %s: give seat to %s",
+
+	// Colors, as the lobby names them
+	"color.blue":   "blue",
+	"color.red":    "red",
+	"color.orange": "orange",
+	"color.purple": "purple",
+
+	// Keys: the help overlay lists an action's keys before its description
+	"keys.title":          "Keys (%s)",
+	"keys.close":          "Press any key to close",
+	"keys.confirm_quit":   "Quit without saving? y to quit, any other key to keep playing",
+	"keys.confirm_leave":  "Leave the table? y to leave, any other key to keep playing",
+	"keys.up":             "Move the cursor up",
+	"keys.down":           "Move the cursor down",
+	"keys.left":           "Move the cursor left",
+	"keys.right":          "Move the cursor right",
+	"keys.next":           "Jump to the next legal spot",
+	"keys.confirm":        "Confirm",
+	"keys.cancel":         "Cancel",
+	"keys.hint":           "Show a hint",
+	"keys.chat":           "Chat",
+	"keys.command":        "Type a command",
+	"keys.log":            "Read the whole log",
+	"keys.log_page_up":    "Scroll the log a page up",
+	"keys.log_page_down":  "Scroll the log a page down",
+	"keys.log_top":        "Jump to the newest log entry",
+	"keys.log_bottom":     "Jump to the oldest log entry",
+	"keys.log_player":     "Filter the log by the next player",
+	"keys.log_event":      "Filter the log by the next kind of event",
+	"keys.log_search":     "Search the log",
+	"keys.layout":         "Show other columns",
+	"keys.overlay":        "Next board overlay",
+	"keys.theme":          "Next theme",
+	"keys.help":           "Show the keys",
+	"keys.quit":           "Quit",
+	"keys.player1":        "Play as player 1",
+	"keys.player2":        "Play as player 2",
+	"keys.player3":        "Play as player 3",
+	"keys.player4":        "Play as player 4",
+	"keys.turn_holder":    "Play as whoever has to act",
+	"keys.give_bot":       "Give an abandoned seat to the computer",
+	"keys.give_spectator": "Give an abandoned seat to a spectator",
+}
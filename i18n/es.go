@@ -0,0 +1,331 @@
+package i18n
+
+// spanish is the Spanish catalog, written for Latin America
+var spanish = Catalog{
+	// Resources and cards
+	"resource.ore":        "Mineral",
+	"resource.wood":       "Madera",
+	"resource.sheep":      "Lana",
+	"resource.wheat":      "Trigo",
+	"resource.brick":      "Arcilla",
+	"resource.unknown":    "Desconocido",
+	"card.knight":         "Caballero",
+	"card.road_building":  "Construcción de carreteras",
+	"card.monopoly":       "Monopolio",
+	"card.year_of_plenty": "Año de abundancia",
+	"card.victory_point":  "Punto de victoria",
+	"amount":              "%d %s",
+	"list.and":            "%s y %s",
+	"list.nothing":        "nada",
+
+	// The board: tile labels are four letters wide, the robber's three
+	"board.wood":   "MADE",
+	"board.brick":  "ARCI",
+	"board.ore":    "MONT",
+	"board.wheat":  "TRIG",
+	"board.sheep":  "PAST",
+	"board.desert": "DESI",
+	"board.robber": "LAD",
+
+	// Sidebar
+	"sidebar.dice":         "Dados: %d (%d + %d)",
+	"sidebar.not_rolled":   "Dados: sin tirar",
+	"sidebar.you":          "%s (tú)",
+	"sidebar.hand":         " %d recursos, %d de desarrollo",
+	"sidebar.resources":    "Tus recursos:",
+	"sidebar.resource":     "%s: %d",
+	"sidebar.dev_cards":    "Cartas de desarrollo: %d",
+	"sidebar.points":       "Puntos de victoria: %d",
+	"sidebar.shown_points": " %d puntos de victoria a la vista",
+	"help.turn":            "Turno de %s. %s",
+
+	// Turn
+	"menu.roll":          "Tirar los dados",
+	"menu.play_knight":   "Jugar caballero",
+	"menu.save_quit":     "Guardar y salir",
+	"help.save_failed":   "No se pudo guardar: %s",
+	"help.roll":          "Hora de tirar los dados",
+	"log.rolled":         "%s obtuvo %s con la tirada (%d)",
+	"menu.build":         "Construir",
+	"menu.trade":         "Comerciar",
+	"menu.play_card":     "Jugar carta de desarrollo",
+	"menu.end_turn":      "Terminar turno",
+	"help.anything_else": "%s ¿Algo más?",
+	"help.idle":          "¿Qué quieres hacer?",
+
+	// Building
+	"menu.road":             "Carretera",
+	"menu.settlement":       "Poblado",
+	"menu.city":             "Ciudad",
+	"menu.dev_card":         "Carta de desarrollo",
+	"menu.cancel_esc":       "Cancelar (o 'esc')",
+	"menu.cancel":           "Cancelar",
+	"help.build":            "Elige qué construir",
+	"help.place_settlement": "Elige dónde poner tu poblado",
+	"help.place_city":       "Elige un poblado para convertirlo en ciudad",
+
+	// Development cards
+	"help.play_card":             "Elige una carta de desarrollo para jugar",
+	"err.no_such_resource":       "Ese recurso no existe",
+	"log.monopoly":               "%s se llevó %d de %s de todos los jugadores",
+	"notice.monopoly":            "¡Te llevaste %d de %s de los demás!",
+	"log.monopoly_nothing":       "%s monopolizó %s pero no se llevó nada",
+	"notice.monopoly_nothing":    "No te llevaste nada: ¡nadie tenía!",
+	"help.monopoly":              "Elige el recurso que te llevarás de todos los jugadores",
+	"log.year_of_plenty":         "%s obtuvo %s y %s de la banca",
+	"notice.year_of_plenty":      "¡Obtuviste %s y %s de la banca!",
+	"help.year_of_plenty_first":  "Elige el primer recurso que obtendrás de la banca",
+	"help.year_of_plenty_second": "Elegido: %s. Elige el segundo recurso que obtendrás de la banca",
+
+	// Initial placement
+	"err.settlement_here":    "No puedes construir un poblado aquí",
+	"log.initial_resources":  "%s obtuvo %s de su poblado inicial",
+	"help.first_settlement":  "Pon tu primer poblado en el tablero con 'enter'.",
+	"help.second_settlement": "Pon tu segundo poblado en el tablero con 'enter'.",
+	"err.initial_road":       "La carretera tiene que salir del nuevo poblado",
+	"help.initial_road":      "Pon una carretera junto al poblado eligiendo su dirección.",
+
+	// Robber
+	"help.robber":      "Elige la casilla donde poner al ladrón",
+	"err.no_such_tile": "Esa casilla no existe",
+	"err.robber_stays": "El ladrón no puede quedarse en la casilla donde ya está",
+	"log.robber":       "%s movió al ladrón",
+	"help.steal":       "Elige a quién robarle",
+	"err.steal":        "No puedes robarle a ese jugador",
+	"log.steal":        "%s le robó una carta a %s",
+
+	// Roads
+	"notice.road":                 "¡Carretera construida!",
+	"notice.free_roads":           "¡Construiste dos carreteras gratis!",
+	"help.road_unconnected":       "Necesitas una carretera o un poblado que llegue a este cruce",
+	"help.road_start":             "Elige dónde empieza tu carretera",
+	"help.road_start_first_free":  "Elige dónde empieza tu primera carretera gratis",
+	"help.road_start_second_free": "Elige dónde empieza tu segunda carretera gratis",
+	"help.road_end":               "Elige dónde termina tu carretera",
+	"help.road_end_first_free":    "Elige dónde termina tu primera carretera gratis",
+	"help.road_end_second_free":   "Elige dónde termina tu segunda carretera gratis",
+
+	// Trade
+	"menu.offer":     "¿Qué quieres ofrecer?",
+	"menu.receive":   "¿Qué quieres recibir?",
+	"menu.amount_of": "%s:  %d / %d",
+	"menu.amount":    "%s:  %d",
+	"help.trade":     "Usa ←/→ para ajustar, ↑/↓ para moverte, Enter para confirmar, Esc para cancelar",
+
+	// End of the game
+	"end.game_over":  "🎉 ¡FIN DE LA PARTIDA! 🎉",
+	"end.wins":       "¡GANA %s!",
+	"end.scores":     "Puntuación final:",
+	"end.score":      "%s %s: %d puntos",
+	"help.game_over": "¡Partida terminada! ¡%s llegó a 10 puntos de victoria!",
+
+	// Decisions
+	"help.waiting":       "Esperando a %s",
+	"err.discard_that":   "No puedes descartar eso",
+	"err.discard_amount": "Tienes que descartar %d cartas",
+	"log.discard":        "%s descartó %d cartas",
+	"help.discard_more":  "Descarta %d cartas más, usa ←/→ para ajustar",
+	"help.discard":       "Enter para descartar estas cartas",
+	"menu.discard":       "Descarta %d cartas",
+
+	// Actions
+	"err.not_your_turn":      "no es tu turno",
+	"err.not_now":            "no puedes hacer eso ahora",
+	"err.no_resources":       "No tienes suficientes recursos",
+	"log.turn":               "Turno de %s",
+	"err.road_here":          "No puedes construir una carretera aquí",
+	"log.free_road":          "%s construyó una carretera gratis",
+	"log.road":               "%s construyó una carretera",
+	"log.settlement":         "%s construyó un poblado",
+	"notice.settlement":      "¡Poblado construido!",
+	"err.city_here":          "No puedes mejorar a ciudad aquí",
+	"log.city":               "%s mejoró a ciudad",
+	"notice.city":            "¡Ciudad construida!",
+	"err.no_cards_left":      "No quedan cartas de desarrollo",
+	"log.bought_card":        "%s compró una carta de desarrollo",
+	"notice.bought_card":     "¡Compraste una carta de %s!",
+	"err.victory_point_card": "Las cartas de punto de victoria cuentan solas",
+	"err.no_card":            "No tienes una carta de %s",
+	"log.played":             "%s jugó %s",
+	"err.trade_type":         "Ese tipo de comercio aún no está implementado",
+	"err.bank_trade":         "¡No tienes suficientes recursos para comerciar con la banca!",
+	"log.bank_trade":         "%s cambió 4 de %s por 1 de %s con la banca",
+	"notice.bank_trade":      "¡Cambiaste 4 de %s por 1 de %s!",
+
+	// Hints
+	"hint.shown":           "Consejo: %s.",
+	"hint.settle":          "funda aquí, %s",
+	"hint.settle_book":     "funda aquí, %s; lugares como este ganaron el %.0f%% de %d partidas simuladas",
+	"hint.city":            "mejora aquí para duplicar sus %d pips",
+	"hint.road":            "construye una carretera hacia un cruce libre con %d pips",
+	"hint.road_best":       "construye una carretera hacia el mejor cruce libre a tu alcance",
+	"hint.buy_card":        "compra una carta de desarrollo con las cartas que te sobran",
+	"hint.knight":          "juega un caballero para sacar al ladrón de tus casillas",
+	"hint.road_building":   "juega Construcción de carreteras para dos carreteras gratis",
+	"hint.play_card":       "juega %s para conseguir %s, que necesitas para %s",
+	"hint.take":            "toma %s, que necesitas para %s",
+	"hint.roll":            "tira los dados, no hay nada que valga la pena jugar antes",
+	"hint.robber":          "mueve el ladrón a esta casilla de %d pips",
+	"hint.block":           "bloquea esta casilla de %d pips de %s",
+	"hint.steal":           "róbale a %s, que tiene %d puntos visibles y %d cartas",
+	"hint.discard":         "descarta %s y quédate con lo que necesitas para %s",
+	"hint.trade":           "cambia %s por %s, que necesitas para %s",
+	"hint.save":            "termina tu turno y ahorra para %s, necesitas %s",
+	"hint.end_turn":        "termina tu turno",
+	"hint.cross":           "%d pips de %s",
+	"hint.cross_adding":    "%d pips de %s, sumando %s a lo que produces",
+	"hint.cross_different": "%d pips de %s, %d recursos distintos",
+	"goal.city":            "una ciudad",
+	"goal.settlement":      "un poblado",
+	"goal.road":            "una carretera",
+	"goal.dev_card":        "una carta de desarrollo",
+
+	// Clock
+	"log.out_of_time": "%s se quedó sin tiempo",
+	"clock.turn":      "Reloj del turno: %s",
+	"clock.yours":     "Tu tiempo: %s",
+	"clock.out":       "¡%s se quedó sin tiempo!",
+
+	// Chances to win
+	"chances.title": "Probabilidad de ganar:",
+	"chances.graph": "Probabilidad de ganar durante la partida:",
+	"chances.swing": "Mayor vuelco: %s +%.0f%% en el turno %d",
+
+	// Overlays
+	"overlay.pips":       "Pips: un punto por cada forma de sacar el número, de 36. o: siguiente capa",
+	"overlay.production": "Producción: pips alrededor de cada lugar libre para fundar, de azul pobre a rojo rico. o: siguiente capa",
+	"overlay.one_road":   "%s 1 carretera",
+	"overlay.roads":      "%s %s carreteras",
+	"overlay.networks":   "Redes: • hasta donde llegan las carreteras, %s. Rojo: bloqueado por el ladrón. o: ocultar",
+	"overlay.no_roads":   "todavía sin carreteras",
+
+	// Reading the whole log
+	"log.filter":             "Jugador: %s  Evento: %s",
+	"log.filter_all":         "todos",
+	"log.event_production":   "producción",
+	"log.event_trades":       "cambios",
+	"log.event_robber":       "ladrón",
+	"log.event_builds":       "construcciones",
+	"log.event_cards":        "cartas",
+	"log.event_turns":        "turnos",
+	"log.search_placeholder": "'/' para buscar",
+	"log.no_entries":         "Nada coincide",
+	"log.help":               "Registro: %s %s desplazarse, %s %s por página, %s jugador, %s evento, %s buscar, %s volver al juego",
+
+	// Chat
+	"chat.err_spectator": "solo los jugadores sentados pueden chatear",
+	"chat.err_no_player": "no hay ningún jugador llamado %s",
+	"chat.err_yourself":  "no puedes susurrarte a ti mismo",
+	"chat.err_empty":     "no hay nada que decir",
+	"chat.placeholder":   "'c' para chatear, '/w nombre' para susurrar",
+
+	// Command line: the commands themselves are English in every locale
+	"command.failed":           "%s: %s",
+	"command.empty":            "escribe un comando, como settle 2,4",
+	"command.usage":            "uso: %s",
+	"command.usage_because":    "uso: %s (%s)",
+	"command.not_next":         "%s y %s no están uno al lado del otro",
+	"command.unknown":          "comando desconocido %q, prueba %s",
+	"command.not_a_place":      "%q no es un lugar del tablero, escríbelo como x,y",
+	"command.no_crossing":      "no hay ningún cruce en %s",
+	"command.no_tile":          "no hay ninguna casilla en %s",
+	"command.unknown_resource": "recurso desconocido %q",
+	"command.amounts":          "indica una cantidad y un recurso",
+	"command.not_an_amount":    "%q no es una cantidad",
+	"command.ambiguous_player": "%q podría ser %s o %s",
+	"command.no_player":        "nadie se llama %q",
+	"command.cursor":           "cursor en %s",
+
+	// Lobby
+	"lobby.err_no_table":         "no existe esa mesa",
+	"lobby.err_not_member":       "no te uniste a esta mesa",
+	"lobby.err_not_host":         "solo el anfitrión puede hacer eso",
+	"lobby.err_started":          "la partida ya empezó",
+	"lobby.err_not_started":      "la partida todavía no empezó",
+	"lobby.err_seat_taken":       "el asiento está ocupado",
+	"lobby.err_color_taken":      "el color está ocupado",
+	"lobby.err_not_seated":       "primero tienes que sentarte",
+	"lobby.err_bad_token":        "el nombre está ocupado, reconéctate con su token",
+	"lobby.err_seats":            "las mesas tienen 3 o 4 asientos, no %d",
+	"lobby.err_takeover":         "forma de reemplazo desconocida %q",
+	"lobby.err_no_seat":          "el asiento %d no existe",
+	"lobby.err_color":            "color desconocido %q",
+	"lobby.err_not_ready":        "%s no está listo",
+	"lobby.err_too_few":          "se necesitan al menos 3 jugadores sentados, hay %d",
+	"lobby.err_seat_not_in_play": "el asiento %d no está en juego",
+	"lobby.err_still_connected":  "%s sigue conectado",
+	"lobby.err_has_seat":         "%s ya tiene un asiento",
+	"lobby.err_disconnected":     "desconectado del servidor, reconectando",
+	"lobby.takeover_host":        "el anfitrión reasigna el asiento",
+	"lobby.takeover_bot":         "la computadora juega el asiento",
+	"lobby.seats":                "Asientos: ← %d →",
+	"lobby.wait":                 "Esperar a los desconectados: ← %s →",
+	"lobby.forever":              "para siempre",
+	"lobby.then":                 "Después: ← %s →",
+	"lobby.turn_time":            "Tiempo por turno: ← %s →",
+	"lobby.player_time":          "Tiempo por jugador: ← %s →",
+	"lobby.unlimited":            "sin límite",
+	"lobby.new_table":            "Nueva mesa",
+	"lobby.help_creating":        "↑/↓: opción, ←/→: cambiar, enter: crear, esc: volver",
+	"lobby.help_table":           "↑/↓: asiento, ←/→: color, enter: sentarse, r: listo, s: empezar (anfitrión), esc: salir",
+	"lobby.help_list":            "↑/↓: elegir, enter: unirse, n: nueva mesa, q: salir",
+	"lobby.tables":               "Mesas (juegas como %s)",
+	"lobby.no_tables":            "Todavía no hay mesas, pulsa 'n' para crear una",
+	"lobby.open":                 "abierta",
+	"lobby.in_progress":          "en juego",
+	"lobby.table":                "%s  %d/%d sentados, %s",
+	"lobby.host":                 "%s (anfitrión: %s)",
+	"lobby.your_color":           "Tu color: ← %s →",
+	"lobby.seat_open":            "Asiento %d: libre",
+	"lobby.not_ready":            "no está listo",
+	"lobby.ready":                "listo",
+	"lobby.seat":                 "Asiento %d: %s (%s), %s",
+	"lobby.joined":               "Se unieron: %s",
+	"lobby.status_bot":           "la computadora juega por %s",
+	"lobby.status_abandoned":     "%s abandonó su asiento",
+	"lobby.status_disconnected":  "%s está desconectado",
+	"lobby.give_bot":             "%s: darle el asiento a la computadora",
+	"lobby.give_bot_or":          "%s, %s: darle el asiento a %s",
+
+	// Colors, as the lobby names them
+	"color.blue":   "azul",
+	"color.red":    "rojo",
+	"color.orange": "naranja",
+	"color.purple": "morado",
+
+	// Keys: the help overlay lists an action's keys before its description
+	"keys.title":          "Teclas (%s)",
+	"keys.close":          "Presiona cualquier tecla para cerrar",
+	"keys.confirm_quit":   "¿Salir sin guardar? y para salir, cualquier otra tecla para seguir jugando",
+	"keys.confirm_leave":  "¿Dejar la mesa? y para irte, cualquier otra tecla para seguir jugando",
+	"keys.up":             "Mover el cursor hacia arriba",
+	"keys.down":           "Mover el cursor hacia abajo",
+	"keys.left":           "Mover el cursor a la izquierda",
+	"keys.right":          "Mover el cursor a la derecha",
+	"keys.next":           "Saltar al siguiente lugar válido",
+	"keys.confirm":        "Confirmar",
+	"keys.cancel":         "Cancelar",
+	"keys.hint":           "Mostrar una pista",
+	"keys.chat":           "Chatear",
+	"keys.command":        "Escribir un comando",
+	"keys.log":            "Leer todo el registro",
+	"keys.log_page_up":    "Subir una página en el registro",
+	"keys.log_page_down":  "Bajar una página en el registro",
+	"keys.log_top":        "Saltar a la entrada más reciente del registro",
+	"keys.log_bottom":     "Saltar a la entrada más antigua del registro",
+	"keys.log_player":     "Filtrar el registro por el siguiente jugador",
+	"keys.log_event":      "Filtrar el registro por el siguiente tipo de evento",
+	"keys.log_search":     "Buscar en el registro",
+	"keys.layout":         "Mostrar otras columnas",
+	"keys.overlay":        "Siguiente capa del tablero",
+	"keys.theme":          "Siguiente tema",
+	"keys.help":           "Mostrar las teclas",
+	"keys.quit":           "Salir",
+	"keys.player1":        "Jugar como el jugador 1",
+	"keys.player2":        "Jugar como el jugador 2",
+	"keys.player3":        "Jugar como el jugador 3",
+	"keys.player4":        "Jugar como el jugador 4",
+	"keys.turn_holder":    "Jugar como quien tenga que actuar",
+	"keys.give_bot":       "Darle un asiento abandonado a la computadora",
+	"keys.give_spectator": "Darle un asiento abandonado a un espectador",
+}
@@ -0,0 +1,176 @@
+// Package i18n writes the game's text in the players' languages. Messages are
+// looked up by key in the catalog of a locale and filled in like fmt.Sprintf,
+// so translations can reorder their arguments with %[n]s.
+package i18n
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Locale is a language the game can be played in
+type Locale string
+
+const (
+	English Locale = "en"
+	Spanish Locale = "es"
+)
+
+// Locales lists the locales with a catalog, English first
+var Locales = []Locale{English, Spanish}
+
+// Catalog maps message keys to the formats they are written with
+type Catalog map[string]string
+
+var catalogs = map[Locale]Catalog{English: english, Spanish: spanish}
+
+func init() {
+	// messages are saved with the game's log
+	gob.Register(Message{})
+	gob.Register(List{})
+	gob.Register(Raw(""))
+}
+
+// Parse reads a locale from a language tag or a POSIX locale name, like "es",
+// "es-AR" or "es_ES.UTF-8"
+func Parse(name string) (Locale, bool) {
+	language := strings.ToLower(name)
+	if i := strings.IndexAny(language, "_-.@"); i >= 0 {
+		language = language[:i]
+	}
+	for _, l := range Locales {
+		if string(l) == language {
+			return l, true
+		}
+	}
+	return English, false
+}
+
+// FromEnvironment picks the locale named by LC_ALL, LC_MESSAGES or LANG,
+// whichever is set first, English if it has no catalog
+func FromEnvironment() Locale {
+	for _, variable := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if name := os.Getenv(variable); name != "" {
+			l, _ := Parse(name)
+			return l
+		}
+	}
+	return English
+}
+
+// T writes the message in the locale. Arguments that are Localizers or errors
+// are written in the locale too. Keys missing from the locale's catalog fall
+// back to English, and keys no catalog knows are used as the format.
+func (l Locale) T(key string, args ...any) string {
+	format, ok := catalogs[l][key]
+	if !ok {
+		format, ok = english[key]
+	}
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	written := make([]any, len(args))
+	for i, arg := range args {
+		switch a := arg.(type) {
+		case Localizer:
+			written[i] = a.Localize(l)
+		case error:
+			written[i] = a.Error()
+		default:
+			written[i] = arg
+		}
+	}
+	return fmt.Sprintf(format, written...)
+}
+
+// Localizer is anything that can be written in a locale
+type Localizer interface {
+	Localize(l Locale) string
+}
+
+// Message is a message to be written later, in each reader's locale
+type Message struct {
+	Key  string
+	Args []any
+}
+
+// M makes a message of the key and the arguments to fill it in with
+func M(key string, args ...any) Message {
+	return Message{Key: key, Args: args}
+}
+
+func (m Message) Localize(l Locale) string {
+	return l.T(m.Key, m.Args...)
+}
+
+// Error is an error that can be written in every locale. Its Error method
+// writes it in English.
+type Error struct {
+	Message
+}
+
+// Errorf makes an Error of the key and the arguments to fill it in with. The
+// first error among the arguments is the one it wraps.
+func Errorf(key string, args ...any) error {
+	return &Error{M(key, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.Localize(English)
+}
+
+func (e *Error) Unwrap() error {
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// Text writes the error in the locale, or as it is if it can't be translated
+func Text(l Locale, err error) string {
+	return Explain(err).Localize(l)
+}
+
+// Explain turns an error into something to show: itself if it can be
+// translated, its text otherwise
+func Explain(err error) Localizer {
+	if e, ok := err.(Localizer); ok {
+		return e
+	}
+	return Raw(err.Error())
+}
+
+// Raw is text that reads the same in every locale, like a player's name
+type Raw string
+
+func (r Raw) Localize(Locale) string {
+	return string(r)
+}
+
+// List writes its items as one, like "wood, brick and ore"
+type List []any
+
+func (list List) Localize(l Locale) string {
+	if len(list) == 0 {
+		return l.T("list.nothing")
+	}
+	items := make([]string, len(list))
+	for i, item := range list {
+		if localizer, ok := item.(Localizer); ok {
+			items[i] = localizer.Localize(l)
+		} else {
+			items[i] = fmt.Sprint(item)
+		}
+	}
+	if len(items) == 1 {
+		return items[0]
+	}
+	return l.T("list.and", strings.Join(items[:len(items)-1], ", "), items[len(items)-1])
+}
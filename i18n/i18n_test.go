@@ -0,0 +1,73 @@
+package i18n
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+var verb = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+func TestCatalogsTranslateEveryMessage(t *testing.T) {
+	for _, l := range Locales {
+		catalog := catalogs[l]
+		for key, format := range english {
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("Expected %s to translate %q", l, key)
+				continue
+			}
+			if got, want := len(verb.FindAllString(translated, -1)), len(verb.FindAllString(format, -1)); got != want {
+				t.Errorf("Expected %s %q to fill in %d arguments like English, got %d: %q", l, key, want, got, translated)
+			}
+		}
+		for key := range catalog {
+			if _, ok := english[key]; !ok {
+				t.Errorf("Expected %s %q to be in the English catalog too", l, key)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for name, want := range map[string]Locale{
+		"es": Spanish, "es-AR": Spanish, "es_ES.UTF-8": Spanish, "EN_us": English, "C.UTF-8": English, "": English,
+	} {
+		if got, _ := Parse(name); got != want {
+			t.Errorf("Expected %q to parse as %s, got %s", name, want, got)
+		}
+	}
+	if _, ok := Parse("fr_FR"); ok {
+		t.Errorf("Expected no catalog for French")
+	}
+}
+
+func TestMessagesAreWrittenInTheReadersLocale(t *testing.T) {
+	message := M("list.and", Raw("Ana"), List{M("list.nothing")})
+	if got := message.Localize(English); got != "Ana and nothing" {
+		t.Errorf("Expected %q, got %q", "Ana and nothing", got)
+	}
+	if got := message.Localize(Spanish); got != "Ana y nada" {
+		t.Errorf("Expected %q, got %q", "Ana y nada", got)
+	}
+	if got := (List{"wood", "brick", "ore"}).Localize(English); got != "wood, brick and ore" {
+		t.Errorf("Expected the last two items joined with and, got %q", got)
+	}
+}
+
+func TestErrorsWrapTheirCause(t *testing.T) {
+	cause := Errorf("list.nothing")
+	err := Errorf("list.and", Raw("Ana"), cause)
+	if !errors.Is(err, cause) {
+		t.Errorf("Expected the error to wrap its cause")
+	}
+	if got := err.Error(); got != "Ana and nothing" {
+		t.Errorf("Expected the error in English, got %q", got)
+	}
+	if got := Text(Spanish, err); got != "Ana y nada" {
+		t.Errorf("Expected the error in Spanish, got %q", got)
+	}
+	if got := Text(Spanish, errors.New("plain")); got != "plain" {
+		t.Errorf("Expected an untranslated error as it is, got %q", got)
+	}
+}
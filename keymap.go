@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bufio"
+	"el_poblador/i18n"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Key bindings
+//
+// Keys in a game are bound to actions by a keymap, which starts from one of
+// the presets and can be changed by a file of lines like
+//
+//	# start from the vim keys, and quit with ctrl+q only
+//	preset = vim
+//	quit = ctrl+q
+//	confirm = enter, space
+//
+// Keys are written as bubbletea names them: "up", "enter", "esc", "tab",
+// "ctrl+c", "f1", letters and symbols as typed, "space" for the space bar. An
+// action given no keys is left unbound. Destructive actions, like quitting,
+// only happen once the user confirms them.
+
+// keyAction is something the user can do with a key in a game
+type keyAction string
+
+const (
+	keyUp            keyAction = "up"
+	keyDown          keyAction = "down"
+	keyLeft          keyAction = "left"
+	keyRight         keyAction = "right"
+	keyNext          keyAction = "next"
+	keyConfirm       keyAction = "confirm"
+	keyCancel        keyAction = "cancel"
+	keyHint          keyAction = "hint"
+	keyChat          keyAction = "chat"
+	keyCommand       keyAction = "command"
+	keyLog           keyAction = "log"
+	keyLogPageUp     keyAction = "log_page_up"
+	keyLogPageDown   keyAction = "log_page_down"
+	keyLogTop        keyAction = "log_top"
+	keyLogBottom     keyAction = "log_bottom"
+	keyLogPlayer     keyAction = "log_player"
+	keyLogEvent      keyAction = "log_event"
+	keyLogSearch     keyAction = "log_search"
+	keyLayout        keyAction = "layout"
+	keyOverlay       keyAction = "overlay"
+	keyTheme         keyAction = "theme"
+	keyHelp          keyAction = "help"
+	keyQuit          keyAction = "quit"
+	keyPlayer1       keyAction = "player1"
+	keyPlayer2       keyAction = "player2"
+	keyPlayer3       keyAction = "player3"
+	keyPlayer4       keyAction = "player4"
+	keyTurnHolder    keyAction = "turn_holder"
+	keyGiveBot       keyAction = "give_bot"
+	keyGiveSpectator keyAction = "give_spectator"
+)
+
+// keyActions lists every action, in the order the help shows them
+var keyActions = []keyAction{
+	keyUp, keyDown, keyLeft, keyRight, keyNext, keyConfirm, keyCancel, keyHint,
+	keyChat, keyCommand, keyLog, keyLogPageUp, keyLogPageDown, keyLogTop, keyLogBottom,
+	keyLogPlayer, keyLogEvent, keyLogSearch, keyLayout, keyOverlay, keyTheme, keyHelp, keyQuit,
+	keyPlayer1, keyPlayer2, keyPlayer3, keyPlayer4, keyTurnHolder,
+	keyGiveBot, keyGiveSpectator,
+}
+
+// localActions and tableActions are the actions of a game played on this
+// computer and of one played at a lobby's table
+var (
+	localActions = slices.DeleteFunc(slices.Clone(keyActions), func(a keyAction) bool {
+		return a == keyGiveBot || a == keyGiveSpectator
+	})
+	tableActions = slices.DeleteFunc(slices.Clone(keyActions), func(a keyAction) bool {
+		return slices.Contains(playerKeys, a) || a == keyTurnHolder
+	})
+)
+
+// playerKeys switch to the players' perspectives, in seat order
+var playerKeys = []keyAction{keyPlayer1, keyPlayer2, keyPlayer3, keyPlayer4}
+
+// destructive actions have to be confirmed
+var destructive = map[keyAction]bool{keyQuit: true}
+
+// keyPresets are the keymaps to start from
+var keyPresets = map[string]map[keyAction][]string{
+	"default": defaultKeys,
+	"vim": withKeys(defaultKeys, map[keyAction][]string{
+		keyUp: {"k", "up"}, keyDown: {"j", "down"}, keyLeft: {"h", "left"}, keyRight: {"l", "right"},
+		keyLog: {"L"}, keyGiveSpectator: {"H"},
+	}),
+	"wasd": withKeys(defaultKeys, map[keyAction][]string{
+		keyUp: {"w", "up"}, keyDown: {"s", "down"}, keyLeft: {"a", "left"}, keyRight: {"d", "right"},
+	}),
+}
+
+// keyPresetNames are the presets, in the order the usage lists them
+var keyPresetNames = []string{"default", "vim", "wasd"}
+
+var defaultKeys = map[keyAction][]string{
+	keyUp:            {"up"},
+	keyDown:          {"down"},
+	keyLeft:          {"left"},
+	keyRight:         {"right"},
+	keyNext:          {"n"},
+	keyConfirm:       {"enter"},
+	keyCancel:        {"esc"},
+	keyHint:          {"?"},
+	keyChat:          {"c"},
+	keyCommand:       {":"},
+	keyLog:           {"l"},
+	keyLogPageUp:     {"pgup"},
+	keyLogPageDown:   {"pgdown"},
+	keyLogTop:        {"home"},
+	keyLogBottom:     {"end"},
+	keyLogPlayer:     {"p"},
+	keyLogEvent:      {"e"},
+	keyLogSearch:     {"/"},
+	keyLayout:        {"tab"},
+	keyOverlay:       {"o"},
+	keyTheme:         {"t"},
+	keyHelp:          {"f1", "K"},
+	keyQuit:          {"q", "ctrl+c"},
+	keyPlayer1:       {"1"},
+	keyPlayer2:       {"2"},
+	keyPlayer3:       {"3"},
+	keyPlayer4:       {"4"},
+	keyTurnHolder:    {"0"},
+	keyGiveBot:       {"b"},
+	keyGiveSpectator: {"h"},
+}
+
+func withKeys(base, changes map[keyAction][]string) map[keyAction][]string {
+	keys := make(map[keyAction][]string, len(base))
+	for action, bound := range base {
+		keys[action] = bound
+	}
+	for action, bound := range changes {
+		keys[action] = bound
+	}
+	return keys
+}
+
+// keymap binds keys to actions
+type keymap struct {
+	// Name is the preset or file the keymap comes from
+	Name    string
+	keys    map[keyAction][]string
+	actions map[string]keyAction
+}
+
+// newKeymap makes a keymap of the bindings, which may bind each key once
+func newKeymap(name string, keys map[keyAction][]string) (*keymap, error) {
+	k := &keymap{Name: name, keys: keys, actions: make(map[string]keyAction)}
+	for _, action := range keyActions {
+		for _, key := range keys[action] {
+			if other, ok := k.actions[key]; ok {
+				return nil, fmt.Errorf("%q is bound to both %s and %s", key, other, action)
+			}
+			k.actions[key] = action
+		}
+	}
+	for _, action := range []keyAction{keyQuit, keyHelp} {
+		if len(keys[action]) == 0 {
+			return nil, fmt.Errorf("%s needs a key", action)
+		}
+	}
+	return k, nil
+}
+
+// keysConfigPath is where the keymap is read from if no other is given
+func keysConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "el_poblador", "keys")
+}
+
+// loadKeymap picks the keymap named by the flag, a preset or a file, or
+// reads the one in the config directory, the default preset if there is none
+func loadKeymap(flag string) (*keymap, error) {
+	if keys, ok := keyPresets[flag]; ok {
+		return newKeymap(flag, keys)
+	}
+	path := flag
+	if path == "" {
+		path = keysConfigPath()
+	}
+	file, err := os.Open(path)
+	if flag == "" && (path == "" || errors.Is(err, fs.ErrNotExist)) {
+		return newKeymap("default", defaultKeys)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	keys := defaultKeys
+	changes := make(map[keyAction][]string)
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected action = keys", path, n)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if name == "preset" {
+			preset, ok := keyPresets[value]
+			if !ok {
+				return nil, fmt.Errorf("%s:%d: unknown preset %q, pick one of %s", path, n, value, strings.Join(keyPresetNames, ", "))
+			}
+			keys = preset
+			continue
+		}
+		action := keyAction(name)
+		if !slices.Contains(keyActions, action) {
+			return nil, fmt.Errorf("%s:%d: unknown action %q", path, n, name)
+		}
+		var bound []string
+		for _, key := range strings.Split(value, ",") {
+			if key = strings.TrimSpace(key); key == "space" {
+				bound = append(bound, " ")
+			} else if key != "" {
+				bound = append(bound, key)
+			}
+		}
+		changes[action] = bound
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	k, err := newKeymap(path, withKeys(keys, changes))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return k, nil
+}
+
+// action returns what the key does, if anything
+func (k *keymap) action(msg tea.KeyMsg) (keyAction, bool) {
+	action, ok := k.actions[msg.String()]
+	return action, ok
+}
+
+// key names the first key bound to the action, for hints
+func (k *keymap) key(action keyAction) string {
+	if keys := k.keys[action]; len(keys) > 0 {
+		return keyName(keys[0])
+	}
+	return ""
+}
+
+func keyName(key string) string {
+	if key == " " {
+		return "space"
+	}
+	return key
+}
+
+// help lists the keys of the actions, for the help overlay
+func (k *keymap) help(l i18n.Locale, actions []keyAction) string {
+	lines := []string{l.T("keys.title", k.Name), ""}
+	for _, action := range actions {
+		var names []string
+		for _, key := range k.keys[action] {
+			names = append(names, keyName(key))
+		}
+		if len(names) == 0 {
+			names = []string{"-"}
+		}
+		lines = append(lines, fmt.Sprintf("%-12s %s", strings.Join(names, " "), l.T("keys."+string(action))))
+	}
+	lines = append(lines, "", l.T("keys.close"))
+	return lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
+
+// overlayBox draws the box in the middle of the view, which is width by
+// height cells
+func overlayBox(view, box string, width, height int) string {
+	lines := strings.Split(view, "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+	top := max((len(lines)-len(boxLines))/2, 0)
+	left := max((width-boxWidth)/2, 0)
+	for i, boxLine := range boxLines {
+		if top+i >= len(lines) {
+			break
+		}
+		line := lines[top+i]
+		if pad := left - ansi.StringWidth(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		lines[top+i] = ansi.Truncate(line, left, "") + boxLine + ansi.TruncateLeft(line, left+boxWidth, "")
+	}
+	return strings.Join(lines, "\n")
+}
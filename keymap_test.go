@@ -0,0 +1,83 @@
+package main
+
+import (
+	"el_poblador/board"
+	"el_poblador/game"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPresetsBindEveryKeyOnce(t *testing.T) {
+	for _, name := range keyPresetNames {
+		keys, err := loadKeymap(name)
+		if err != nil {
+			t.Fatalf("Expected the %s preset to load, got %v", name, err)
+		}
+		if action, _ := keys.action(tea.KeyMsg{Type: tea.KeyUp}); action != keyUp {
+			t.Errorf("Expected the arrows to move in the %s preset, got %q", name, action)
+		}
+	}
+	vim, _ := loadKeymap("vim")
+	if action, _ := vim.action(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")}); action != keyLeft {
+		t.Errorf("Expected h to move left in vim, got %q", action)
+	}
+}
+
+func TestKeymapFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys")
+	os.WriteFile(path, []byte("# mine\npreset = wasd\nquit = ctrl+q\nconfirm = enter, space\n"), 0o644)
+	keys, err := loadKeymap(path)
+	if err != nil {
+		t.Fatalf("loadKeymap failed: %v", err)
+	}
+	for key, want := range map[string]keyAction{"w": keyUp, "ctrl+q": keyQuit, " ": keyConfirm, "enter": keyConfirm} {
+		if got := keys.actions[key]; got != want {
+			t.Errorf("Expected %q to %s, got %q", key, want, got)
+		}
+	}
+	if _, bound := keys.actions["q"]; bound {
+		t.Errorf("Expected q to be unbound once quit is given other keys")
+	}
+
+	for content, want := range map[string]string{
+		"jump = j\n":  "unknown action",
+		"next = t\n":  "bound to both",
+		"help = \n":   "help needs a key",
+		"preset = x":  "unknown preset",
+		"just a line": "expected action = keys",
+	} {
+		os.WriteFile(path, []byte(content), 0o644)
+		if _, err := loadKeymap(path); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q to fail with %q, got %v", content, want, err)
+		}
+	}
+}
+
+func TestQuitAsksFirst(t *testing.T) {
+	keys, _ := loadKeymap("default")
+	g := &game.Game{}
+	g.Start([]string{"Ana", "Bob", "Cleo"})
+	var m tea.Model = model{game: g, theme: board.DefaultTheme, chat: &chatInput{}, command: &chatInput{}, log: &logPane{}, keys: keys, width: 160, height: 50}
+
+	q := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}
+	m, cmd := m.Update(q)
+	if cmd != nil {
+		t.Fatalf("Expected q to ask before quitting")
+	}
+	if !strings.Contains(m.View(), "Quit without saving?") {
+		t.Errorf("Expected the prompt in place of the help line, got\n%s", m.View())
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil || m.(model).confirming != "" {
+		t.Errorf("Expected any other key to keep playing")
+	}
+
+	m, _ = m.Update(q)
+	if _, cmd = m.Update(q); cmd == nil {
+		t.Errorf("Expected q again to quit")
+	}
+}
@@ -0,0 +1,290 @@
+package lobby
+
+import (
+	"bufio"
+	"el_poblador/i18n"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+var ErrDisconnected = i18n.Errorf("lobby.err_disconnected")
+
+// Client is a Service backed by a lobby Server on the network.
+//
+// When the connection drops the client keeps redialing in the background and
+// presents its reconnect token, so the player gets their seat back. Calls made
+// while disconnected fail with ErrDisconnected.
+type Client struct {
+	addr    string
+	player  string
+	locale  i18n.Locale
+	updates chan struct{}
+
+	mu      sync.Mutex
+	conn    net.Conn
+	live    net.Conn // conn once the server accepted our hello
+	encoder *json.Encoder
+	token   string
+	nextID  int
+	pending map[int]chan response
+	closed  bool
+	// updates is closed once the client is closed and its connection is gone
+	updatesClosed bool
+}
+
+// Dial connects to the server at addr and introduces the player, who reads
+// the server's errors in the locale.
+func Dial(addr, player string, locale i18n.Locale) (*Client, error) {
+	return DialWithToken(addr, player, "", locale)
+}
+
+// DialWithToken connects as a player that has connected before.
+func DialWithToken(addr, player, token string, locale i18n.Locale) (*Client, error) {
+	c := &Client{
+		addr:    addr,
+		player:  player,
+		locale:  locale,
+		updates: make(chan struct{}, 1),
+		token:   token,
+		nextID:  1,
+		pending: make(map[int]chan response),
+	}
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// connect dials the server and says hello, remembering the token it hands out
+func (c *Client) connect() error {
+	conn, err := net.Dial("tcp", c.addr)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.conn = conn
+	c.encoder = json.NewEncoder(conn)
+	token := c.token
+	c.mu.Unlock()
+	go c.read(conn)
+	resp, err := c.call(request{Op: opHello, Name: c.player, Token: token, Locale: string(c.locale)})
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	c.mu.Lock()
+	c.token = resp.Token
+	c.live = conn
+	c.mu.Unlock()
+	return nil
+}
+
+// Token is the reconnect token the server handed out for this player.
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+func (c *Client) read(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var resp response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			continue
+		}
+		if resp.Type == typeUpdate {
+			c.notify()
+			continue
+		}
+		c.mu.Lock()
+		ch, ok := c.pending[resp.ID]
+		delete(c.pending, resp.ID)
+		c.mu.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+
+	c.mu.Lock()
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+	wasLive := c.live == conn
+	if wasLive {
+		c.live = nil
+		c.encoder = nil
+	}
+	closed := c.closed
+	if wasLive && closed {
+		c.updatesClosed = true
+		close(c.updates)
+	}
+	c.mu.Unlock()
+	if !wasLive || closed {
+		// failed attempts to connect are reported by connect itself
+		return
+	}
+	c.notify()
+	go c.reconnect()
+}
+
+// reconnect redials with backoff until it succeeds or the client is closed
+func (c *Client) reconnect() {
+	backoff := 100 * time.Millisecond
+	for {
+		time.Sleep(backoff)
+		c.mu.Lock()
+		closed := c.closed
+		c.mu.Unlock()
+		if closed {
+			return
+		}
+		if err := c.connect(); err == nil {
+			c.notify()
+			return
+		}
+		backoff = min(backoff*2, 5*time.Second)
+	}
+}
+
+func (c *Client) notify() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.updatesClosed {
+		return
+	}
+	select {
+	case c.updates <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Client) call(req request) (response, error) {
+	ch := make(chan response, 1)
+	c.mu.Lock()
+	if c.closed || c.encoder == nil {
+		c.mu.Unlock()
+		return response{}, ErrDisconnected
+	}
+	req.ID = c.nextID
+	c.nextID++
+	c.pending[req.ID] = ch
+	err := c.encoder.Encode(req)
+	c.mu.Unlock()
+	if err != nil {
+		return response{}, fmt.Errorf("send failed: %w", err)
+	}
+	resp, ok := <-ch
+	if !ok {
+		return response{}, ErrDisconnected
+	}
+	if resp.Error != "" {
+		return resp, errors.New(resp.Error)
+	}
+	return resp, nil
+}
+
+func (c *Client) callTable(req request) (TableInfo, error) {
+	resp, err := c.call(req)
+	if err != nil {
+		return TableInfo{}, err
+	}
+	if resp.Table == nil {
+		return TableInfo{}, fmt.Errorf("server sent no table for %s", req.Op)
+	}
+	return *resp.Table, nil
+}
+
+func (c *Client) Player() string { return c.player }
+
+func (c *Client) List() ([]TableInfo, error) {
+	resp, err := c.call(request{Op: opList})
+	return resp.Tables, err
+}
+
+func (c *Client) Table(id int) (TableInfo, error) {
+	return c.callTable(request{Op: opTable, Table: id})
+}
+
+func (c *Client) Create(opts Options) (TableInfo, error) {
+	return c.callTable(request{Op: opCreate, Options: &opts})
+}
+
+func (c *Client) Join(id int) (TableInfo, error) {
+	return c.callTable(request{Op: opJoin, Table: id})
+}
+
+func (c *Client) Leave(id int) error {
+	_, err := c.call(request{Op: opLeave, Table: id})
+	return err
+}
+
+func (c *Client) ChooseSeat(id int, seat int, color string) (TableInfo, error) {
+	return c.callTable(request{Op: opSeat, Table: id, Seat: seat, Color: color})
+}
+
+func (c *Client) SetReady(id int, ready bool) (TableInfo, error) {
+	return c.callTable(request{Op: opReady, Table: id, Ready: ready})
+}
+
+func (c *Client) Start(id int) error {
+	_, err := c.call(request{Op: opStart, Table: id})
+	return err
+}
+
+func (c *Client) Reassign(id int, seat int, player string) error {
+	_, err := c.call(request{Op: opAssign, Table: id, Seat: seat, Player: player})
+	return err
+}
+
+func (c *Client) Press(id int, key string) error {
+	_, err := c.call(request{Op: opPress, Table: id, Key: key})
+	return err
+}
+
+func (c *Client) Point(id int, v View, x, y int, button string) error {
+	_, err := c.call(request{Op: opPoint, Table: id, View: &v, X: x, Y: y, Button: button})
+	return err
+}
+
+func (c *Client) Chat(id int, text string) error {
+	_, err := c.call(request{Op: opChat, Table: id, Text: text})
+	return err
+}
+
+func (c *Client) Command(id int, text string) error {
+	_, err := c.call(request{Op: opCommand, Table: id, Text: text})
+	return err
+}
+
+func (c *Client) Complete(id int, text string) (string, []string, error) {
+	resp, err := c.call(request{Op: opComplete, Table: id, Text: text})
+	return resp.Line, resp.Completions, err
+}
+
+func (c *Client) Render(id int, v View) (string, error) {
+	resp, err := c.call(request{Op: opRender, Table: id, View: &v})
+	return resp.Frame, err
+}
+
+func (c *Client) LogLength(id int, v View) (int, error) {
+	resp, err := c.call(request{Op: opLogLength, Table: id, View: &v})
+	return resp.LogLength, err
+}
+
+func (c *Client) Updates() <-chan struct{} { return c.updates }
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	conn := c.conn
+	c.mu.Unlock()
+	return conn.Close()
+}
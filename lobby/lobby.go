@@ -0,0 +1,920 @@
+package lobby
+
+import (
+	cryptorand "crypto/rand"
+	"el_poblador/board"
+	"el_poblador/game"
+	"el_poblador/i18n"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Lobby
+//
+// A lobby hosts any number of tables. A host creates a table with some
+// options, other players join it, pick a seat and a color, and mark themselves
+// ready. Once every seated player is ready the host starts the game, which is
+// then played on the table with the seating chosen here.
+//
+// The lobby itself is transport agnostic: the TUI talks to it directly when
+// hosting and through Client/Server when playing over the network. Every
+// mutation notifies subscribers so that screens can refresh.
+//
+// Players are identified by name. The first connection with a name receives a
+// reconnect token, and later connections must present it, so a dropped player
+// can come back to their seat. While the game waits on a disconnected player
+// the table's DecisionTimeout runs; once it expires the seat is abandoned and
+// either handed to the autopilot or left for the host to Reassign.
+
+var (
+	ErrNoSuchTable    = i18n.Errorf("lobby.err_no_table")
+	ErrNotMember      = i18n.Errorf("lobby.err_not_member")
+	ErrNotHost        = i18n.Errorf("lobby.err_not_host")
+	ErrAlreadyStarted = i18n.Errorf("lobby.err_started")
+	ErrNotStarted     = i18n.Errorf("lobby.err_not_started")
+	ErrSeatTaken      = i18n.Errorf("lobby.err_seat_taken")
+	ErrColorTaken     = i18n.Errorf("lobby.err_color_taken")
+	ErrNotSeated      = i18n.Errorf("lobby.err_not_seated")
+	ErrBadToken       = i18n.Errorf("lobby.err_bad_token")
+)
+
+// What happens to a seat whose player has been gone for too long
+const (
+	TakeoverHost = "host" // wait for the host to reassign the seat
+	TakeoverBot  = "bot"  // the computer plays the seat
+)
+
+// Options are chosen by the host when creating a table.
+type Options struct {
+	Name       string `json:"name"`
+	MaxPlayers int    `json:"max_players"`
+	// How long the game waits on a disconnected player before the seat
+	// is abandoned. Zero waits forever.
+	DecisionTimeout time.Duration `json:"decision_timeout"`
+	// TakeoverHost or TakeoverBot
+	Takeover string `json:"takeover"`
+	// optional turn and game time budgets
+	Clock game.TimeControl `json:"clock"`
+}
+
+// SeatInfo describes one seat at a table. An empty Player means the seat is open.
+type SeatInfo struct {
+	Player    string `json:"player"`
+	Color     string `json:"color"`
+	Ready     bool   `json:"ready"`
+	Connected bool   `json:"connected"`
+	Abandoned bool   `json:"abandoned"`
+	Bot       bool   `json:"bot"`
+}
+
+// TableInfo is a snapshot of a table, safe to hand out and send over the wire.
+type TableInfo struct {
+	ID      int        `json:"id"`
+	Host    string     `json:"host"`
+	Options Options    `json:"options"`
+	Members []string   `json:"members"`
+	Seats   []SeatInfo `json:"seats"`
+	Started bool       `json:"started"`
+	Over    bool       `json:"over"`
+}
+
+// SeatOf returns the seat index of the player, or -1 if they are not seated.
+func (t TableInfo) SeatOf(player string) int {
+	for i, seat := range t.Seats {
+		if seat.Player == player {
+			return i
+		}
+	}
+	return -1
+}
+
+type table struct {
+	id      int
+	host    string
+	options Options
+	members []string
+	seats   []SeatInfo
+
+	// guards game, which is nil until the table is started
+	mu   sync.Mutex
+	game *game.Game
+	// seat index to player index in game.Players, since empty seats are skipped
+	playerIndex map[int]int
+	over        bool
+	lastTick    time.Time
+	// a win chance estimate is running on a copy of the game
+	estimating bool
+	// seat index to when the game began waiting on the seat's player
+	waitingSince map[int]time.Time
+}
+
+type session struct {
+	token          string
+	connections    int
+	disconnectedAt time.Time
+}
+
+type Lobby struct {
+	mu          sync.Mutex
+	tables      map[int]*table
+	nextID      int
+	subscribers map[chan struct{}]bool
+	sessions    map[string]*session
+	// used for disconnection times, replaceable in tests
+	now func() time.Time
+}
+
+func New() *Lobby {
+	return &Lobby{
+		tables:      make(map[int]*table),
+		nextID:      1,
+		subscribers: make(map[chan struct{}]bool),
+		sessions:    make(map[string]*session),
+		now:         time.Now,
+	}
+}
+
+// Connect registers a connection for the player. A new name gets a fresh
+// reconnect token; a known name must present its token. Returns the token.
+func (l *Lobby) Connect(player, token string) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.sessions[player]
+	if !ok {
+		s = &session{token: newToken()}
+		l.sessions[player] = s
+	} else if s.token != token {
+		return "", ErrBadToken
+	}
+	s.connections++
+	// the player is back, take their seats back from the computer
+	for _, t := range l.tables {
+		if seat := t.seatOf(player); seat >= 0 {
+			t.setBot(seat, false)
+			t.seats[seat].Abandoned = false
+		}
+	}
+	l.notify()
+	return s.token, nil
+}
+
+// Disconnect records that one of the player's connections dropped.
+func (l *Lobby) Disconnect(player string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.sessions[player]
+	if !ok || s.connections == 0 {
+		return
+	}
+	s.connections--
+	if s.connections == 0 {
+		s.disconnectedAt = l.now()
+	}
+	l.notify()
+}
+
+func (l *Lobby) connected(player string) bool {
+	s, ok := l.sessions[player]
+	return ok && s.connections > 0
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := cryptorand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Subscribe returns a channel that receives a value whenever anything in the
+// lobby changes, and a function to stop receiving.
+func (l *Lobby) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	l.mu.Lock()
+	l.subscribers[ch] = true
+	l.mu.Unlock()
+	return ch, func() {
+		l.mu.Lock()
+		delete(l.subscribers, ch)
+		l.mu.Unlock()
+	}
+}
+
+// notify must be called with l.mu held
+func (l *Lobby) notify() {
+	for ch := range l.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default: // a notification is already pending
+		}
+	}
+}
+
+func (l *Lobby) Create(host string, opts Options) (TableInfo, error) {
+	if opts.MaxPlayers == 0 {
+		opts.MaxPlayers = 4
+	}
+	if opts.MaxPlayers < 3 || opts.MaxPlayers > 4 {
+		return TableInfo{}, i18n.Errorf("lobby.err_seats", opts.MaxPlayers)
+	}
+	if opts.Name == "" {
+		opts.Name = fmt.Sprintf("%s's game", host)
+	}
+	switch opts.Takeover {
+	case "":
+		opts.Takeover = TakeoverHost
+	case TakeoverHost, TakeoverBot:
+	default:
+		return TableInfo{}, i18n.Errorf("lobby.err_takeover", opts.Takeover)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t := &table{
+		id:      l.nextID,
+		host:    host,
+		options: opts,
+		members: []string{host},
+		seats:   make([]SeatInfo, opts.MaxPlayers),
+	}
+	l.nextID++
+	l.tables[t.id] = t
+	l.notify()
+	return l.info(t), nil
+}
+
+// List returns every table that has not finished, ordered by creation.
+func (l *Lobby) List() []TableInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var infos []TableInfo
+	for _, t := range l.tables {
+		info := l.info(t)
+		if !info.Over {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+func (l *Lobby) Table(id int) (TableInfo, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.tables[id]
+	if !ok {
+		return TableInfo{}, ErrNoSuchTable
+	}
+	return l.info(t), nil
+}
+
+// Join adds the player to the table without seating them. Joining a game in
+// progress makes the player a spectator, who the host can hand a seat to.
+func (l *Lobby) Join(id int, player string) (TableInfo, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.tables[id]
+	if !ok {
+		return TableInfo{}, ErrNoSuchTable
+	}
+	if !t.isMember(player) {
+		t.members = append(t.members, player)
+		l.notify()
+	}
+	return l.info(t), nil
+}
+
+// Leave removes the player from the table, freeing their seat.
+// A table whose host leaves before starting is closed.
+func (l *Lobby) Leave(id int, player string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.tables[id]
+	if !ok {
+		return ErrNoSuchTable
+	}
+	if t.started() {
+		return ErrAlreadyStarted
+	}
+	if player == t.host {
+		delete(l.tables, id)
+		l.notify()
+		return nil
+	}
+	for i, member := range t.members {
+		if member == player {
+			t.members = append(t.members[:i], t.members[i+1:]...)
+			break
+		}
+	}
+	if seat := t.seatOf(player); seat >= 0 {
+		t.seats[seat] = SeatInfo{}
+	}
+	l.notify()
+	return nil
+}
+
+// ChooseSeat moves the player to the given seat with the given color.
+// Changing seats clears the ready flag.
+func (l *Lobby) ChooseSeat(id int, player string, seat int, color string) (TableInfo, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.tables[id]
+	if !ok {
+		return TableInfo{}, ErrNoSuchTable
+	}
+	if !t.isMember(player) {
+		return TableInfo{}, ErrNotMember
+	}
+	if t.started() {
+		return TableInfo{}, ErrAlreadyStarted
+	}
+	if seat < 0 || seat >= len(t.seats) {
+		return TableInfo{}, i18n.Errorf("lobby.err_no_seat", seat+1)
+	}
+	if _, ok := colorByName(color); !ok {
+		return TableInfo{}, i18n.Errorf("lobby.err_color", color)
+	}
+	if occupant := t.seats[seat].Player; occupant != "" && occupant != player {
+		return TableInfo{}, ErrSeatTaken
+	}
+	for i, other := range t.seats {
+		if i != seat && other.Player != "" && other.Player != player && other.Color == color {
+			return TableInfo{}, ErrColorTaken
+		}
+	}
+	if current := t.seatOf(player); current >= 0 {
+		t.seats[current] = SeatInfo{}
+	}
+	t.seats[seat] = SeatInfo{Player: player, Color: color}
+	l.notify()
+	return l.info(t), nil
+}
+
+func (l *Lobby) SetReady(id int, player string, ready bool) (TableInfo, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.tables[id]
+	if !ok {
+		return TableInfo{}, ErrNoSuchTable
+	}
+	if t.started() {
+		return TableInfo{}, ErrAlreadyStarted
+	}
+	seat := t.seatOf(player)
+	if seat < 0 {
+		return TableInfo{}, ErrNotSeated
+	}
+	t.seats[seat].Ready = ready
+	l.notify()
+	return l.info(t), nil
+}
+
+// Start begins the game with the chosen seating. Only the host can start, and
+// only once 3-4 players are seated and all of them are ready.
+func (l *Lobby) Start(id int, player string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.tables[id]
+	if !ok {
+		return ErrNoSuchTable
+	}
+	if player != t.host {
+		return ErrNotHost
+	}
+	if t.started() {
+		return ErrAlreadyStarted
+	}
+	var seats []game.Seat
+	playerIndex := make(map[int]int)
+	for i, seat := range t.seats {
+		if seat.Player == "" {
+			continue
+		}
+		if !seat.Ready {
+			return i18n.Errorf("lobby.err_not_ready", seat.Player)
+		}
+		color, _ := colorByName(seat.Color)
+		playerIndex[i] = len(seats)
+		seats = append(seats, game.Seat{Name: seat.Player, Color: color.Color})
+	}
+	if len(seats) < 3 {
+		return i18n.Errorf("lobby.err_too_few", len(seats))
+	}
+	g := &game.Game{}
+	g.StartSeated(seats)
+	// no player gets to end the game for the whole table, or write files
+	// on the server
+	g.DisableSaving()
+	if t.options.Clock.Enabled() {
+		g.SetTimeControl(t.options.Clock)
+	}
+	t.mu.Lock()
+	t.game = g
+	t.playerIndex = playerIndex
+	t.lastTick = l.now()
+	t.noteWaiting(t.lastTick)
+	t.mu.Unlock()
+	l.notify()
+	return nil
+}
+
+// Press forwards a game key from a seated player to the table's game.
+// Spectators can't press keys, unknown keys are ignored.
+func (l *Lobby) Press(id int, player string, key string) error {
+	t, seat, err := l.playing(id, player)
+	if err != nil {
+		return err
+	}
+	if seat == nil {
+		return ErrNotSeated
+	}
+	t.mu.Lock()
+	if !t.over {
+		switch key {
+		case "up", "down", "left", "right":
+			t.game.MoveCursor(key, seat)
+		case "n":
+			t.game.MoveCursor("next", seat)
+		case "enter":
+			t.game.ConfirmAction(seat)
+			if t.game.ShouldQuit() {
+				t.over = true
+			}
+		case "esc":
+			t.game.CancelAction(seat)
+		case "?":
+			t.game.ShowHint(seat)
+		}
+	}
+	t.mu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.playBots(t)
+	l.notify()
+	return nil
+}
+
+// Reassign hands a seat of a started game to another member of the table, or
+// to the computer if player is empty. Only the host can reassign, and only
+// seats whose player is disconnected.
+func (l *Lobby) Reassign(id int, host string, seat int, player string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.tables[id]
+	if !ok {
+		return ErrNoSuchTable
+	}
+	if host != t.host {
+		return ErrNotHost
+	}
+	if !t.started() {
+		return ErrNotStarted
+	}
+	if seat < 0 || seat >= len(t.seats) || t.seats[seat].Player == "" {
+		return i18n.Errorf("lobby.err_seat_not_in_play", seat+1)
+	}
+	current := t.seats[seat]
+	if !current.Bot && l.connected(current.Player) {
+		return i18n.Errorf("lobby.err_still_connected", current.Player)
+	}
+	if player == "" {
+		t.setBot(seat, true)
+	} else {
+		if !t.isMember(player) {
+			return ErrNotMember
+		}
+		if t.seatOf(player) >= 0 {
+			return i18n.Errorf("lobby.err_has_seat", player)
+		}
+		t.setBot(seat, false)
+		t.seats[seat] = SeatInfo{Player: player, Color: current.Color, Ready: true}
+		t.mu.Lock()
+		t.game.Players[t.playerIndex[seat]].Name = player
+		t.mu.Unlock()
+	}
+	t.seats[seat].Abandoned = false
+	l.playBots(t)
+	l.notify()
+	return nil
+}
+
+// CheckTimeouts runs the game clocks, brings the win-chance meters up to date
+// and abandons the seats the games are waiting on whose players have been
+// disconnected for longer than their table's DecisionTimeout. Servers call it
+// periodically.
+func (l *Lobby) CheckTimeouts() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	changed := false
+	for _, t := range l.tables {
+		if t.options.Clock.Enabled() && t.started() {
+			t.mu.Lock()
+			if !t.over {
+				t.game.Tick(now.Sub(t.lastTick))
+			}
+			t.lastTick = now
+			t.mu.Unlock()
+			l.playBots(t)
+			changed = true
+		}
+		if t.started() {
+			l.estimateWinChances(t)
+		}
+		if t.options.DecisionTimeout == 0 || !t.started() {
+			continue
+		}
+		for _, seat := range t.waitingOn() {
+			info := &t.seats[seat]
+			s := l.sessions[info.Player]
+			if info.Bot || info.Abandoned || s == nil || s.connections > 0 {
+				continue
+			}
+			// the player has the whole timeout for a decision, even if they
+			// left before the game needed one from them
+			since := s.disconnectedAt
+			t.mu.Lock()
+			if waiting := t.waitingSince[seat]; waiting.After(since) {
+				since = waiting
+			}
+			t.mu.Unlock()
+			if now.Sub(since) < t.options.DecisionTimeout {
+				continue
+			}
+			info.Abandoned = true
+			if t.options.Takeover == TakeoverBot {
+				t.setBot(seat, true)
+				info.Abandoned = false
+			}
+			changed = true
+		}
+		l.playBots(t)
+	}
+	if changed {
+		l.notify()
+	}
+}
+
+// estimateWinChances brings the table's win chances up to date, on a copy of
+// the game so neither the lobby nor the table waits on the rollouts. Tables
+// estimate one at a time, and subscribers are told once the estimate is in.
+func (l *Lobby) estimateWinChances(t *table) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.estimating {
+		return
+	}
+	e := t.game.StartWinChances()
+	if e == nil {
+		return
+	}
+	t.estimating = true
+	go func() {
+		e.Run()
+		t.mu.Lock()
+		t.estimating = false
+		changed := t.game.FinishWinChances(e)
+		t.mu.Unlock()
+		if changed {
+			l.mu.Lock()
+			l.notify()
+			l.mu.Unlock()
+		}
+	}()
+}
+
+// waitingOn returns the seats whose players the game is waiting on
+func (t *table) waitingOn() []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.over {
+		return nil
+	}
+	var seats []int
+	for _, player := range t.game.WaitingOn() {
+		for seat, index := range t.playerIndex {
+			if index == player {
+				seats = append(seats, seat)
+			}
+		}
+	}
+	return seats
+}
+
+// setBot hands a seat to the computer, or gives it back to its player
+func (t *table) setBot(seat int, bot bool) {
+	t.seats[seat].Bot = bot
+	if !t.started() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if bot {
+		t.game.SetBot(t.playerIndex[seat], game.NewSimpleBot())
+	} else {
+		t.game.SetBot(t.playerIndex[seat], nil)
+	}
+}
+
+// playBots lets the computer play every bot seat the game is waiting on.
+// Must be called with l.mu held.
+func (l *Lobby) playBots(t *table) {
+	if !t.started() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.over {
+		t.game.PlayBots()
+	}
+	t.noteWaiting(l.now())
+}
+
+// noteWaiting records when the game began waiting on each seat it waits on
+// now, and forgets the others. Must be called with t.mu held, after every
+// change to the game.
+func (t *table) noteWaiting(now time.Time) {
+	waiting := make(map[int]time.Time)
+	if !t.over {
+		for _, player := range t.game.WaitingOn() {
+			for seat, index := range t.playerIndex {
+				if index != player {
+					continue
+				}
+				waiting[seat] = now
+				if since, ok := t.waitingSince[seat]; ok {
+					waiting[seat] = since
+				}
+			}
+		}
+	}
+	t.waitingSince = waiting
+}
+
+// View is what a player needs to render their screen.
+type View struct {
+	Width          int    `json:"width"`
+	Height         int    `json:"height"`
+	TwoColumnCycle int    `json:"two_column_cycle"`
+	OneColumnCycle int    `json:"one_column_cycle"`
+	ChatFocused    bool   `json:"chat_focused"`
+	ChatDraft      string `json:"chat_draft"`
+	// CommandFocused is set while the player types CommandDraft on the
+	// command line, CommandOptions are the completions offered last
+	CommandFocused bool     `json:"command_focused,omitempty"`
+	CommandDraft   string   `json:"command_draft,omitempty"`
+	CommandOptions []string `json:"command_options,omitempty"`
+	// Overlay is drawn over the board
+	Overlay board.Overlay `json:"overlay,omitempty"`
+	// Theme names the board.Theme to draw in, ASCII draws with ASCII
+	// characters only
+	Theme string `json:"theme,omitempty"`
+	ASCII bool   `json:"ascii,omitempty"`
+	// Locale is the language to write in
+	Locale i18n.Locale `json:"locale,omitempty"`
+	// LogFocused is set while the player reads the whole log, see
+	// game.Viewport
+	LogFocused   bool           `json:"log_focused,omitempty"`
+	LogFilter    game.LogFilter `json:"log_filter,omitempty"`
+	LogScroll    int            `json:"log_scroll,omitempty"`
+	LogSearching bool           `json:"log_searching,omitempty"`
+	LogHelp      string         `json:"log_help,omitempty"`
+}
+
+// Render draws the table's game from the player's perspective.
+// Spectators see what is on the table, and nobody's hand.
+func (l *Lobby) Render(id int, player string, v View) (string, error) {
+	t, seat, err := l.playing(id, player)
+	if err != nil {
+		return "", err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.game.Render(v.viewport(seat)), nil
+}
+
+// maxViewWidth and maxViewHeight bound the screens the lobby draws, which
+// clients ask for
+const (
+	maxViewWidth  = 500
+	maxViewHeight = 200
+)
+
+func (v View) viewport(seat *int) game.Viewport {
+	return game.Viewport{
+		Width:          min(max(v.Width, 0), maxViewWidth),
+		Height:         min(max(v.Height, 0), maxViewHeight),
+		Player:         seat,
+		Spectator:      seat == nil,
+		TwoColumnCycle: v.TwoColumnCycle,
+		OneColumnCycle: v.OneColumnCycle,
+		ChatFocused:    v.ChatFocused,
+		ChatDraft:      v.ChatDraft,
+		CommandFocused: v.CommandFocused,
+		CommandDraft:   v.CommandDraft,
+		CommandOptions: v.CommandOptions,
+		Overlay:        v.Overlay,
+		Theme:          v.Theme,
+		ASCII:          v.ASCII,
+		Locale:         v.Locale,
+		LogFocused:     v.LogFocused,
+		LogFilter:      v.LogFilter,
+		LogScroll:      v.LogScroll,
+		LogSearching:   v.LogSearching,
+		LogHelp:        ansi.Truncate(v.LogHelp, maxViewWidth, ""),
+	}
+}
+
+// LogLength counts the entries of the table's log the view's filter lets
+// through, for scrolling it.
+func (l *Lobby) LogLength(id int, player string, v View) (int, error) {
+	t, seat, err := l.playing(id, player)
+	if err != nil {
+		return 0, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.game.LogLength(v.viewport(seat)), nil
+}
+
+// Point forwards the mouse of a seated player, at a cell of the game as
+// rendered for the view: a hover with no button, or a click with "left" or
+// "right". Spectators can't point.
+func (l *Lobby) Point(id int, player string, v View, x, y int, button string) error {
+	t, seat, err := l.playing(id, player)
+	if err != nil {
+		return err
+	}
+	if seat == nil {
+		return ErrNotSeated
+	}
+	t.mu.Lock()
+	if !t.over {
+		if button == "" {
+			t.game.Hover(v.viewport(seat), x, y)
+		} else {
+			t.game.Click(v.viewport(seat), x, y, button)
+			if t.game.ShouldQuit() {
+				t.over = true
+			}
+		}
+	}
+	t.mu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.playBots(t)
+	l.notify()
+	return nil
+}
+
+// Chat posts a message from a seated player to the table's game chat.
+func (l *Lobby) Chat(id int, player string, text string) error {
+	t, seat, err := l.playing(id, player)
+	if err != nil {
+		return err
+	}
+	if seat == nil {
+		return ErrNotSeated
+	}
+	t.mu.Lock()
+	err = t.game.SendChat(*seat, text)
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.notify()
+	l.mu.Unlock()
+	return nil
+}
+
+// Command runs a command line typed by a seated player, see
+// game.Game.RunCommand.
+func (l *Lobby) Command(id int, player string, text string) error {
+	t, seat, err := l.playing(id, player)
+	if err != nil {
+		return err
+	}
+	if seat == nil {
+		return ErrNotSeated
+	}
+	t.mu.Lock()
+	if t.over {
+		err = game.ErrNotNow
+	} else {
+		err = t.game.RunCommand(seat, text)
+		if t.game.ShouldQuit() {
+			t.over = true
+		}
+	}
+	t.mu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.playBots(t)
+	l.notify()
+	return err
+}
+
+// Complete completes the last word of a seated player's command line.
+func (l *Lobby) Complete(id int, player string, text string) (string, []string, error) {
+	t, seat, err := l.playing(id, player)
+	if err != nil {
+		return "", nil, err
+	}
+	if seat == nil {
+		return "", nil, ErrNotSeated
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	line, options := t.game.CompleteCommand(seat, text)
+	return line, options, nil
+}
+
+// playing looks up a started table and the player's index in its game
+func (l *Lobby) playing(id int, player string) (*table, *int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.tables[id]
+	if !ok {
+		return nil, nil, ErrNoSuchTable
+	}
+	if !t.isMember(player) {
+		return nil, nil, ErrNotMember
+	}
+	if !t.started() {
+		return nil, nil, ErrNotStarted
+	}
+	seat := t.seatOf(player)
+	if seat < 0 {
+		return t, nil, nil
+	}
+	index := t.playerIndex[seat]
+	return t, &index, nil
+}
+
+func (t *table) started() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.game != nil
+}
+
+func (t *table) isMember(player string) bool {
+	for _, member := range t.members {
+		if member == player {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *table) seatOf(player string) int {
+	for i, seat := range t.seats {
+		if seat.Player == player {
+			return i
+		}
+	}
+	return -1
+}
+
+// info must be called with l.mu held
+func (l *Lobby) info(t *table) TableInfo {
+	t.mu.Lock()
+	started, over := t.game != nil, t.over
+	t.mu.Unlock()
+	seats := append([]SeatInfo(nil), t.seats...)
+	for i := range seats {
+		seats[i].Connected = seats[i].Player != "" && l.connected(seats[i].Player)
+	}
+	return TableInfo{
+		ID:      t.id,
+		Host:    t.host,
+		Options: t.options,
+		Members: append([]string(nil), t.members...),
+		Seats:   seats,
+		Started: started,
+		Over:    over,
+	}
+}
+
+func colorByName(name string) (game.PlayerColor, bool) {
+	for _, color := range game.PlayerColors {
+		if color.Name == name {
+			return color, true
+		}
+	}
+	return game.PlayerColor{}, false
+}
+
+// ColorNames lists the colors players can choose from.
+func ColorNames() []string {
+	names := make([]string, len(game.PlayerColors))
+	for i, color := range game.PlayerColors {
+		names[i] = color.Name
+	}
+	return names
+}
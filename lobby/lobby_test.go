@@ -0,0 +1,305 @@
+package lobby
+
+import (
+	"el_poblador/board"
+	"el_poblador/game"
+	"el_poblador/i18n"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func seatEveryone(t *testing.T, l *Lobby, id int, players []string) {
+	colors := ColorNames()
+	for i, player := range players {
+		if _, err := l.Join(id, player); err != nil {
+			t.Fatalf("%s failed to join: %v", player, err)
+		}
+		// seat players in reverse order of joining
+		seat := len(players) - 1 - i
+		if _, err := l.ChooseSeat(id, player, seat, colors[i]); err != nil {
+			t.Fatalf("%s failed to sit: %v", player, err)
+		}
+		if _, err := l.SetReady(id, player, true); err != nil {
+			t.Fatalf("%s failed to get ready: %v", player, err)
+		}
+	}
+}
+
+func TestStartUsesChosenSeating(t *testing.T) {
+	l := New()
+	info, err := l.Create("ana", Options{MaxPlayers: 3})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	seatEveryone(t, l, info.ID, []string{"ana", "ben", "cat"})
+
+	if err := l.Start(info.ID, "ben"); err != ErrNotHost {
+		t.Fatalf("Expected only the host to be able to start, got %v", err)
+	}
+	if err := l.Start(info.ID, "ana"); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	table := l.tables[info.ID]
+	expected := []string{"cat", "ben", "ana"}
+	for i, name := range expected {
+		if table.game.Players[i].Name != name {
+			t.Errorf("Expected %s in seat %d, got %s", name, i+1, table.game.Players[i].Name)
+		}
+	}
+
+	frame, err := l.Render(info.ID, "cat", View{Width: 130, Height: 40})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(frame, "cat (you)") {
+		t.Error("Expected the frame to be rendered from cat's perspective")
+	}
+}
+
+func TestSeatAndColorConflicts(t *testing.T) {
+	l := New()
+	info, _ := l.Create("ana", Options{})
+	l.Join(info.ID, "ben")
+
+	if _, err := l.ChooseSeat(info.ID, "ana", 0, "red"); err != nil {
+		t.Fatalf("ChooseSeat failed: %v", err)
+	}
+	if _, err := l.ChooseSeat(info.ID, "ben", 0, "blue"); err != ErrSeatTaken {
+		t.Errorf("Expected seat conflict, got %v", err)
+	}
+	if _, err := l.ChooseSeat(info.ID, "ben", 1, "red"); err != ErrColorTaken {
+		t.Errorf("Expected color conflict, got %v", err)
+	}
+	if _, err := l.ChooseSeat(info.ID, "dan", 1, "blue"); err != ErrNotMember {
+		t.Errorf("Expected non-members to be rejected, got %v", err)
+	}
+
+	// moving seats frees the old one and clears the ready flag
+	l.SetReady(info.ID, "ana", true)
+	table, err := l.ChooseSeat(info.ID, "ana", 2, "red")
+	if err != nil {
+		t.Fatalf("ChooseSeat failed: %v", err)
+	}
+	if table.Seats[0].Player != "" || table.Seats[2].Player != "ana" || table.Seats[2].Ready {
+		t.Errorf("Unexpected seats after moving: %+v", table.Seats)
+	}
+}
+
+func TestStartRequiresReadyPlayers(t *testing.T) {
+	l := New()
+	info, _ := l.Create("ana", Options{})
+	seatEveryone(t, l, info.ID, []string{"ana", "ben"})
+	if err := l.Start(info.ID, "ana"); err == nil {
+		t.Fatal("Should not start with only two players")
+	}
+
+	l.Join(info.ID, "cat")
+	l.ChooseSeat(info.ID, "cat", 2, "orange")
+	if err := l.Start(info.ID, "ana"); err == nil {
+		t.Fatal("Should not start while a player is not ready")
+	}
+}
+
+func TestSeveralTablesOverNetwork(t *testing.T) {
+	l := New()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer listener.Close()
+	go NewServer(l).Serve(listener)
+
+	addr := listener.Addr().String()
+	clients := make(map[string]*Client)
+	for _, name := range []string{"ana", "ben", "cat", "dan", "eve", "fay"} {
+		c, err := Dial(addr, name, i18n.English)
+		if err != nil {
+			t.Fatalf("%s failed to connect: %v", name, err)
+		}
+		defer c.Close()
+		clients[name] = c
+	}
+	if _, err := Dial(addr, "ana", i18n.English); err == nil {
+		t.Error("Expected a second connection with the same name to be rejected")
+	}
+
+	first, err := clients["ana"].Create(Options{Name: "first", MaxPlayers: 3})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	second, err := clients["dan"].Create(Options{Name: "second", MaxPlayers: 3})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	tables, err := clients["eve"].List()
+	if err != nil || len(tables) != 2 {
+		t.Fatalf("Expected 2 open tables, got %d (%v)", len(tables), err)
+	}
+
+	for id, players := range map[int][]string{first.ID: {"ana", "ben", "cat"}, second.ID: {"dan", "eve", "fay"}} {
+		for seat, name := range players {
+			c := clients[name]
+			if _, err := c.Join(id); err != nil {
+				t.Fatalf("%s failed to join: %v", name, err)
+			}
+			if _, err := c.ChooseSeat(id, seat, ColorNames()[seat]); err != nil {
+				t.Fatalf("%s failed to sit: %v", name, err)
+			}
+			if _, err := c.SetReady(id, true); err != nil {
+				t.Fatalf("%s failed to get ready: %v", name, err)
+			}
+		}
+		if err := clients[players[0]].Start(id); err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+	}
+
+	// the first player of each table places a settlement
+	for _, host := range []string{"ana", "dan"} {
+		id := first.ID
+		if host == "dan" {
+			id = second.ID
+		}
+		if err := clients[host].Press(id, "enter"); err != nil {
+			t.Fatalf("Press failed: %v", err)
+		}
+		frame, err := clients[host].Render(id, View{Width: 130, Height: 40})
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		if !strings.Contains(frame, "road") {
+			t.Errorf("Expected %s to be placing a road, got frame:\n%s", host, frame)
+		}
+	}
+}
+
+func TestServerWritesErrorsInTheClientsLocale(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer listener.Close()
+	go NewServer(New()).Serve(listener)
+
+	addr := listener.Addr().String()
+	for locale, want := range map[i18n.Locale]string{i18n.English: "no such table", i18n.Spanish: "no existe esa mesa"} {
+		c, err := Dial(addr, "ana-"+string(locale), locale)
+		if err != nil {
+			t.Fatalf("Dial failed: %v", err)
+		}
+		defer c.Close()
+		if _, err := c.Join(42); err == nil || err.Error() != want {
+			t.Errorf("Expected %q in %s, got %v", want, locale, err)
+		}
+	}
+}
+
+func TestClickPlacesASettlement(t *testing.T) {
+	l := New()
+	info := startedTable(t, l, Options{})
+	view := View{Width: 130, Height: 40}
+	frame, err := l.Render(info.ID, "ana", view)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	x, y := -1, -1
+	for i, line := range strings.Split(ansi.Strip(frame), "\n") {
+		if j := strings.Index(line, "○"); j >= 0 {
+			x, y = ansi.StringWidth(line[:j]), i
+		}
+	}
+	if x < 0 {
+		t.Fatalf("Expected the cursor on the board, got frame:\n%s", frame)
+	}
+
+	if err := l.Point(info.ID, "ben", view, x, y, "left"); err != nil {
+		t.Fatalf("Point failed: %v", err)
+	}
+	if frame, _ = l.Render(info.ID, "ana", view); strings.Contains(frame, "road") {
+		t.Errorf("Expected ben's click to be ignored, it isn't their turn")
+	}
+	if err := l.Point(info.ID, "ana", view, x, y, "left"); err != nil {
+		t.Fatalf("Point failed: %v", err)
+	}
+	if frame, _ = l.Render(info.ID, "ana", view); !strings.Contains(frame, "road") {
+		t.Errorf("Expected ana to be placing a road after clicking, got frame:\n%s", frame)
+	}
+}
+
+func TestSpectatorsCannotPlay(t *testing.T) {
+	l := New()
+	info := startedTable(t, l, Options{})
+	if _, err := l.Join(info.ID, "dan"); err != nil {
+		t.Fatalf("dan failed to join: %v", err)
+	}
+	view := View{Width: 130, Height: 40}
+
+	if err := l.Press(info.ID, "dan", "enter"); err != ErrNotSeated {
+		t.Errorf("Expected dan's key to be refused, got %v", err)
+	}
+	if err := l.Point(info.ID, "dan", view, 0, 0, "left"); err != ErrNotSeated {
+		t.Errorf("Expected dan's click to be refused, got %v", err)
+	}
+	if frame, _ := l.Render(info.ID, "ana", view); strings.Contains(frame, "road") {
+		t.Errorf("Expected ana's settlement to be left to her, got frame:\n%s", frame)
+	}
+}
+
+func TestSpectatorsSeeNoHands(t *testing.T) {
+	l := New()
+	info := startedTable(t, l, Options{})
+	l.Join(info.ID, "dan")
+	g := l.tables[info.ID].game
+	g.Players[0].Resources[board.ResourceWood] = 3
+	g.Players[0].HiddenDevCards = []game.DevCard{game.DevCardVictoryPoint}
+
+	frame, err := l.Render(info.ID, "dan", View{Width: 130, Height: 40})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	frame = ansi.Strip(frame)
+	for _, hidden := range []string{"Your resources", "Wood: 3", "(you)", "Victory Points: 1"} {
+		if strings.Contains(frame, hidden) {
+			t.Errorf("Expected %q hidden from spectators, got frame:\n%s", hidden, frame)
+		}
+	}
+	if !strings.Contains(frame, "0 victory points showing") {
+		t.Errorf("Expected the points on the table, got frame:\n%s", frame)
+	}
+}
+
+func TestHugeViewsAreBounded(t *testing.T) {
+	l := New()
+	info := startedTable(t, l, Options{})
+	frame, err := l.Render(info.ID, "ana", View{Width: 1e6, Height: 1e6})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if lines := strings.Split(frame, "\n"); len(lines) > maxViewHeight || ansi.StringWidth(lines[0]) > maxViewWidth {
+		t.Errorf("Expected at most %dx%d, got %d lines of %d", maxViewWidth, maxViewHeight, len(lines), ansi.StringWidth(lines[0]))
+	}
+}
+
+func TestCommandPlacesASettlement(t *testing.T) {
+	l := New()
+	info := startedTable(t, l, Options{})
+	view := View{Width: 130, Height: 40}
+
+	line, options, err := l.Complete(info.ID, "ana", "sett")
+	if err != nil || line != "settle " || len(options) != 1 {
+		t.Errorf("Expected settle to be completed, got %q %v (%v)", line, options, err)
+	}
+	if err := l.Command(info.ID, "ben", "settle 2,4"); err == nil {
+		t.Errorf("Expected ben's command to be refused, it isn't their turn")
+	}
+	if err := l.Command(info.ID, "ana", "settle 2,4"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if frame, _ := l.Render(info.ID, "ana", view); !strings.Contains(frame, "road") {
+		t.Errorf("Expected ana to be placing a road after the command, got frame:\n%s", frame)
+	}
+}
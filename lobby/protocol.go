@@ -0,0 +1,80 @@
+package lobby
+
+// Wire protocol
+//
+// Client and server exchange newline-delimited JSON. The first request on a
+// connection must be a "hello" carrying the player's name. Every request has an
+// ID and is answered by exactly one reply with the same ID. In addition the
+// server pushes "update" messages (ID 0) whenever the lobby changes, so the
+// client knows to refresh whatever it is showing.
+//
+// The hello reply carries the player's reconnect token. Sending it in a later
+// hello proves the connection belongs to the same player, who gets their seat
+// back. The hello may also name the locale errors are written in for the
+// connection, English by default.
+//
+//	→ {"id":1,"op":"hello","name":"alice","locale":"es"}
+//	← {"id":1,"type":"reply","token":"9f86d08..."}
+//	→ {"id":2,"op":"create","options":{"name":"lunch","max_players":4}}
+//	← {"id":2,"type":"reply","table":{...}}
+//	← {"type":"update"}
+
+const (
+	opHello  = "hello"
+	opList   = "list"
+	opTable  = "table"
+	opCreate = "create"
+	opJoin   = "join"
+	opLeave  = "leave"
+	opSeat   = "seat"
+	opReady  = "ready"
+	opStart  = "start"
+	opAssign = "reassign"
+	opPress  = "press"
+	opPoint  = "point"
+	opChat   = "chat"
+	opRender = "render"
+
+	opCommand  = "command"
+	opComplete = "complete"
+
+	opLogLength = "log_length"
+
+	typeReply  = "reply"
+	typeUpdate = "update"
+)
+
+type request struct {
+	ID      int      `json:"id"`
+	Op      string   `json:"op"`
+	Name    string   `json:"name,omitempty"`
+	Token   string   `json:"token,omitempty"`
+	Locale  string   `json:"locale,omitempty"`
+	Player  string   `json:"player,omitempty"`
+	Table   int      `json:"table,omitempty"`
+	Seat    int      `json:"seat,omitempty"`
+	Color   string   `json:"color,omitempty"`
+	Ready   bool     `json:"ready,omitempty"`
+	Key     string   `json:"key,omitempty"`
+	X       int      `json:"x,omitempty"`
+	Y       int      `json:"y,omitempty"`
+	Button  string   `json:"button,omitempty"`
+	Text    string   `json:"text,omitempty"`
+	Options *Options `json:"options,omitempty"`
+	View    *View    `json:"view,omitempty"`
+}
+
+type response struct {
+	ID     int         `json:"id,omitempty"`
+	Type   string      `json:"type"`
+	Error  string      `json:"error,omitempty"`
+	Token  string      `json:"token,omitempty"`
+	Tables []TableInfo `json:"tables,omitempty"`
+	Table  *TableInfo  `json:"table,omitempty"`
+	Frame  string      `json:"frame,omitempty"`
+	// Line and Completions answer a complete
+	Line        string   `json:"line,omitempty"`
+	Completions []string `json:"completions,omitempty"`
+	// LogLength answers a log_length
+	LogLength int `json:"log_length,omitempty"`
+}
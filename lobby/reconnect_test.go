@@ -0,0 +1,179 @@
+package lobby
+
+import (
+	"el_poblador/i18n"
+	"net"
+	"testing"
+	"time"
+)
+
+// startedTable creates a started 3 player table whose players are all connected
+func startedTable(t *testing.T, l *Lobby, opts Options) TableInfo {
+	players := []string{"ana", "ben", "cat"}
+	for _, player := range players {
+		if _, err := l.Connect(player, ""); err != nil {
+			t.Fatalf("%s failed to connect: %v", player, err)
+		}
+	}
+	info, err := l.Create("ana", opts)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	for seat, player := range players {
+		l.Join(info.ID, player)
+		l.ChooseSeat(info.ID, player, seat, ColorNames()[seat])
+		l.SetReady(info.ID, player, true)
+	}
+	if err := l.Start(info.ID, "ana"); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	return info
+}
+
+func TestTimeoutHandsSeatToComputer(t *testing.T) {
+	l := New()
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	info := startedTable(t, l, Options{DecisionTimeout: time.Minute, Takeover: TakeoverBot})
+	g := l.tables[info.ID].game
+
+	// ana has to place the first settlement but drops out
+	l.Disconnect("ana")
+	now = now.Add(30 * time.Second)
+	l.CheckTimeouts()
+	if g.PlayerTurn != 0 {
+		t.Fatal("Game should wait for ana until the timeout expires")
+	}
+
+	now = now.Add(31 * time.Second)
+	l.CheckTimeouts()
+	if g.PlayerTurn != 1 {
+		t.Fatalf("The computer should have finished ana's placement, turn is %d", g.PlayerTurn)
+	}
+	table, _ := l.Table(info.ID)
+	if !table.Seats[0].Bot {
+		t.Error("Seat should be marked as played by the computer")
+	}
+
+	// ana comes back with her token and gets the seat back
+	token := l.sessions["ana"].token
+	if _, err := l.Connect("ana", "wrong"); err != ErrBadToken {
+		t.Errorf("Expected a wrong token to be rejected, got %v", err)
+	}
+	if _, err := l.Connect("ana", token); err != nil {
+		t.Fatalf("Reconnect failed: %v", err)
+	}
+	table, _ = l.Table(info.ID)
+	if table.Seats[0].Bot || !table.Seats[0].Connected {
+		t.Errorf("ana should be back in control of her seat: %+v", table.Seats[0])
+	}
+}
+
+func TestTimeoutStartsWhenTheGameWaits(t *testing.T) {
+	l := New()
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	info := startedTable(t, l, Options{DecisionTimeout: time.Minute, Takeover: TakeoverBot})
+
+	// cat drops out long before her first settlement is due
+	l.Disconnect("cat")
+	now = now.Add(2 * time.Minute)
+	// ben's cursor starts on a crossing that is taken now, n moves it on
+	for _, press := range [][2]string{{"ana", "enter"}, {"ana", "enter"}, {"ben", "n"}, {"ben", "enter"}, {"ben", "enter"}} {
+		l.Press(info.ID, press[0], press[1])
+	}
+	l.CheckTimeouts()
+	table, _ := l.Table(info.ID)
+	if table.Seats[2].Bot {
+		t.Fatal("cat should get the whole timeout once the game waits on her")
+	}
+
+	now = now.Add(61 * time.Second)
+	l.CheckTimeouts()
+	table, _ = l.Table(info.ID)
+	if !table.Seats[2].Bot {
+		t.Errorf("cat's seat should go to the computer once the timeout expires: %+v", table.Seats[2])
+	}
+}
+
+func TestHostReassignsAbandonedSeat(t *testing.T) {
+	l := New()
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	info := startedTable(t, l, Options{DecisionTimeout: time.Minute})
+
+	// ben can't reassign anything, ana is still connected
+	if err := l.Reassign(info.ID, "ben", 0, ""); err != ErrNotHost {
+		t.Errorf("Expected only the host to reassign, got %v", err)
+	}
+	if err := l.Reassign(info.ID, "ana", 1, ""); err == nil {
+		t.Error("Should not reassign the seat of a connected player")
+	}
+
+	// ana places her settlement and road, then ben leaves for good
+	l.Press(info.ID, "ana", "enter")
+	l.Press(info.ID, "ana", "enter")
+	l.Disconnect("ben")
+	now = now.Add(2 * time.Minute)
+	l.CheckTimeouts()
+	table, _ := l.Table(info.ID)
+	if !table.Seats[1].Abandoned || table.Seats[1].Bot {
+		t.Fatalf("ben's seat should wait for the host: %+v", table.Seats[1])
+	}
+
+	l.Connect("dan", "")
+	if _, err := l.Join(info.ID, "dan"); err != nil {
+		t.Fatalf("Spectators should be able to join a started game: %v", err)
+	}
+	if err := l.Reassign(info.ID, "ana", 1, "dan"); err != nil {
+		t.Fatalf("Reassign failed: %v", err)
+	}
+	g := l.tables[info.ID].game
+	if g.Players[1].Name != "dan" {
+		t.Errorf("Expected dan to play the seat, got %s", g.Players[1].Name)
+	}
+	if err := l.Press(info.ID, "dan", "enter"); err != nil {
+		t.Fatalf("dan should be able to play: %v", err)
+	}
+}
+
+func TestClientReconnectsWithToken(t *testing.T) {
+	l := New()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer listener.Close()
+	go NewServer(l).Serve(listener)
+
+	c, err := Dial(listener.Addr().String(), "ana", i18n.English)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer c.Close()
+	info, err := c.Create(Options{})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	c.ChooseSeat(info.ID, 0, "red")
+
+	// simulate the network dropping
+	c.mu.Lock()
+	c.conn.Close()
+	c.mu.Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		table, err := c.Table(info.ID)
+		if err == nil && table.Seats[0].Connected {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Client did not reconnect: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if _, err := Dial(listener.Addr().String(), "ana", i18n.English); err == nil {
+		t.Error("Connecting as ana without the token should fail")
+	}
+}
@@ -0,0 +1,210 @@
+package lobby
+
+import (
+	"bufio"
+	"el_poblador/i18n"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// Server exposes a Lobby over the network. A single server hosts every table
+// of the lobby, so several games can run concurrently.
+type Server struct {
+	lobby *Lobby
+}
+
+func NewServer(l *Lobby) *Server {
+	return &Server{lobby: l}
+}
+
+// Serve accepts connections until the listener is closed.
+func (s *Server) Serve(listener net.Listener) error {
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.lobby.CheckTimeouts()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// ListenAndServe listens on the TCP address and serves the lobby on it.
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+type connection struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+func (c *connection) send(resp response) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.encoder.Encode(resp)
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	c := &connection{encoder: json.NewEncoder(conn)}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	// the first request identifies the player
+	if !scanner.Scan() {
+		return
+	}
+	var hello request
+	if err := json.Unmarshal(scanner.Bytes(), &hello); err != nil || hello.Op != opHello || hello.Name == "" {
+		c.send(response{ID: hello.ID, Type: typeReply, Error: "expected hello with a name"})
+		return
+	}
+	locale, _ := i18n.Parse(hello.Locale)
+	token, err := s.lobby.Connect(hello.Name, hello.Token)
+	if err != nil {
+		c.send(response{ID: hello.ID, Type: typeReply, Error: i18n.Text(locale, err)})
+		return
+	}
+	defer s.lobby.Disconnect(hello.Name)
+	player := hello.Name
+	c.send(response{ID: hello.ID, Type: typeReply, Token: token})
+
+	updates, unsubscribe := s.lobby.Subscribe()
+	defer unsubscribe()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-updates:
+				if c.send(response{Type: typeUpdate}) != nil {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			c.send(response{Type: typeReply, Error: fmt.Sprintf("malformed request: %v", err)})
+			continue
+		}
+		resp := s.dispatch(player, locale, req)
+		resp.ID = req.ID
+		resp.Type = typeReply
+		if c.send(resp) != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) dispatch(player string, locale i18n.Locale, req request) response {
+	var resp response
+	var info TableInfo
+	var err error
+	switch req.Op {
+	case opList:
+		resp.Tables = s.lobby.List()
+		return resp
+	case opTable:
+		info, err = s.lobby.Table(req.Table)
+	case opCreate:
+		var opts Options
+		if req.Options != nil {
+			opts = *req.Options
+		}
+		info, err = s.lobby.Create(player, opts)
+	case opJoin:
+		info, err = s.lobby.Join(req.Table, player)
+	case opLeave:
+		return errorResponse(locale, s.lobby.Leave(req.Table, player))
+	case opSeat:
+		info, err = s.lobby.ChooseSeat(req.Table, player, req.Seat, req.Color)
+	case opReady:
+		info, err = s.lobby.SetReady(req.Table, player, req.Ready)
+	case opStart:
+		return errorResponse(locale, s.lobby.Start(req.Table, player))
+	case opAssign:
+		return errorResponse(locale, s.lobby.Reassign(req.Table, player, req.Seat, req.Player))
+	case opPress:
+		return errorResponse(locale, s.lobby.Press(req.Table, player, req.Key))
+	case opPoint:
+		var v View
+		if req.View != nil {
+			v = *req.View
+		}
+		return errorResponse(locale, s.lobby.Point(req.Table, player, v, req.X, req.Y, req.Button))
+	case opChat:
+		return errorResponse(locale, s.lobby.Chat(req.Table, player, req.Text))
+	case opCommand:
+		return errorResponse(locale, s.lobby.Command(req.Table, player, req.Text))
+	case opComplete:
+		resp.Line, resp.Completions, err = s.lobby.Complete(req.Table, player, req.Text)
+		if err != nil {
+			return errorResponse(locale, err)
+		}
+		return resp
+	case opRender:
+		var v View
+		if req.View != nil {
+			v = *req.View
+		}
+		resp.Frame, err = s.lobby.Render(req.Table, player, v)
+		if err != nil {
+			return errorResponse(locale, err)
+		}
+		return resp
+	case opLogLength:
+		var v View
+		if req.View != nil {
+			v = *req.View
+		}
+		resp.LogLength, err = s.lobby.LogLength(req.Table, player, v)
+		if err != nil {
+			return errorResponse(locale, err)
+		}
+		return resp
+	default:
+		return response{Error: fmt.Sprintf("unknown op %q", req.Op)}
+	}
+	if err != nil {
+		return errorResponse(locale, err)
+	}
+	resp.Table = &info
+	return resp
+}
+
+func errorResponse(l i18n.Locale, err error) response {
+	if err != nil {
+		return response{Error: i18n.Text(l, err)}
+	}
+	return response{}
+}
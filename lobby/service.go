@@ -0,0 +1,98 @@
+package lobby
+
+// Service is the lobby as seen by one player. It is implemented both by a
+// local lobby (when hosting) and by a network Client, so screens don't need to
+// care where the lobby lives.
+type Service interface {
+	Player() string
+	List() ([]TableInfo, error)
+	Table(id int) (TableInfo, error)
+	Create(opts Options) (TableInfo, error)
+	Join(id int) (TableInfo, error)
+	Leave(id int) error
+	ChooseSeat(id int, seat int, color string) (TableInfo, error)
+	SetReady(id int, ready bool) (TableInfo, error)
+	Start(id int) error
+	Reassign(id int, seat int, player string) error
+	Press(id int, key string) error
+	// Point sends the mouse: a hover with no button, or a click
+	Point(id int, v View, x, y int, button string) error
+	Chat(id int, text string) error
+	// Command runs a typed command line, Complete completes one
+	Command(id int, text string) error
+	Complete(id int, text string) (string, []string, error)
+	Render(id int, v View) (string, error)
+	// LogLength counts the log entries the view's filter lets through
+	LogLength(id int, v View) (int, error)
+	// Updates receives a value whenever something in the lobby changed
+	Updates() <-chan struct{}
+	Close() error
+}
+
+type local struct {
+	lobby       *Lobby
+	player      string
+	updates     <-chan struct{}
+	unsubscribe func()
+}
+
+// Local returns a Service that talks to the lobby in-process as the given player.
+func Local(l *Lobby, player string) (Service, error) {
+	if _, err := l.Connect(player, ""); err != nil {
+		return nil, err
+	}
+	updates, unsubscribe := l.Subscribe()
+	return &local{lobby: l, player: player, updates: updates, unsubscribe: unsubscribe}, nil
+}
+
+func (s *local) Player() string                         { return s.player }
+func (s *local) List() ([]TableInfo, error)             { return s.lobby.List(), nil }
+func (s *local) Table(id int) (TableInfo, error)        { return s.lobby.Table(id) }
+func (s *local) Create(opts Options) (TableInfo, error) { return s.lobby.Create(s.player, opts) }
+func (s *local) Join(id int) (TableInfo, error)         { return s.lobby.Join(id, s.player) }
+func (s *local) Leave(id int) error                     { return s.lobby.Leave(id, s.player) }
+func (s *local) Start(id int) error                     { return s.lobby.Start(id, s.player) }
+func (s *local) Press(id int, key string) error         { return s.lobby.Press(id, s.player, key) }
+func (s *local) Updates() <-chan struct{}               { return s.updates }
+
+func (s *local) Point(id int, v View, x, y int, button string) error {
+	return s.lobby.Point(id, s.player, v, x, y, button)
+}
+
+func (s *local) ChooseSeat(id int, seat int, color string) (TableInfo, error) {
+	return s.lobby.ChooseSeat(id, s.player, seat, color)
+}
+
+func (s *local) SetReady(id int, ready bool) (TableInfo, error) {
+	return s.lobby.SetReady(id, s.player, ready)
+}
+
+func (s *local) Reassign(id int, seat int, player string) error {
+	return s.lobby.Reassign(id, s.player, seat, player)
+}
+
+func (s *local) Chat(id int, text string) error {
+	return s.lobby.Chat(id, s.player, text)
+}
+
+func (s *local) Command(id int, text string) error {
+	return s.lobby.Command(id, s.player, text)
+}
+
+func (s *local) Complete(id int, text string) (string, []string, error) {
+	return s.lobby.Complete(id, s.player, text)
+}
+
+func (s *local) Render(id int, v View) (string, error) {
+	return s.lobby.Render(id, s.player, v)
+}
+
+func (s *local) LogLength(id int, v View) (int, error) {
+	return s.lobby.LogLength(id, s.player, v)
+}
+
+func (s *local) Close() error {
+	s.unsubscribe()
+	s.lobby.Disconnect(s.player)
+	return nil
+}
@@ -0,0 +1,651 @@
+package main
+
+import (
+	"el_poblador/board"
+	"el_poblador/game"
+	"el_poblador/i18n"
+	"el_poblador/lobby"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lobbyModel is the screen where players create, list and join tables, pick
+// their seat and color and get ready. It works the same whether the lobby is
+// hosted in this process or reached over the network.
+type lobbyModel struct {
+	svc    lobby.Service
+	width  int
+	height int
+	err    string
+
+	// list of tables
+	tables   []lobby.TableInfo
+	selected int
+
+	// creating a table
+	creating    bool
+	createField int
+	maxPlayers  int
+	timeout     int // index into decisionTimeouts
+	takeover    string
+	turnLimit   int // index into turnLimits
+	gameLimit   int // index into gameLimits
+
+	// at a table
+	table      *lobby.TableInfo
+	seatCursor int
+	colorIndex int
+
+	// how games are drawn and played
+	theme  *board.Theme
+	ascii  bool
+	locale i18n.Locale
+	keys   *keymap
+}
+
+// choices for how long to wait on a disconnected player, zero waits forever
+var decisionTimeouts = []time.Duration{0, 30 * time.Second, time.Minute, 2 * time.Minute, 5 * time.Minute}
+
+// choices for the time budgets, zero is unlimited
+var turnLimits = []time.Duration{0, time.Minute, 2 * time.Minute, 3 * time.Minute}
+var gameLimits = []time.Duration{0, 10 * time.Minute, 15 * time.Minute, 20 * time.Minute}
+
+const createFields = 5
+
+type lobbyUpdateMsg struct{}
+
+type disconnectedMsg struct{}
+
+func newLobbyModel(svc lobby.Service, theme *board.Theme, ascii bool, locale i18n.Locale, keys *keymap) lobbyModel {
+	m := lobbyModel{svc: svc, maxPlayers: 4, takeover: lobby.TakeoverHost, theme: theme, ascii: ascii, locale: locale, keys: keys}
+	m.refresh()
+	return m
+}
+
+func waitForUpdate(svc lobby.Service) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-svc.Updates(); !ok {
+			return disconnectedMsg{}
+		}
+		return lobbyUpdateMsg{}
+	}
+}
+
+func (m lobbyModel) Init() tea.Cmd {
+	return waitForUpdate(m.svc)
+}
+
+func (m *lobbyModel) refresh() {
+	if m.table != nil {
+		info, err := m.svc.Table(m.table.ID)
+		if err != nil {
+			// the table was closed by its host
+			m.table = nil
+			m.err = i18n.Text(m.locale, err)
+		} else {
+			m.table = &info
+		}
+	}
+	tables, err := m.svc.List()
+	if err != nil {
+		m.err = i18n.Text(m.locale, err)
+		return
+	}
+	m.tables = tables
+	if m.selected >= len(m.tables) {
+		m.selected = max(len(m.tables)-1, 0)
+	}
+}
+
+func (m *lobbyModel) setResult(info lobby.TableInfo, err error) {
+	if err != nil {
+		m.err = i18n.Text(m.locale, err)
+		return
+	}
+	m.err = ""
+	m.table = &info
+}
+
+func (m lobbyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case disconnectedMsg:
+		m.svc.Close()
+		return m, tea.Quit
+	case lobbyUpdateMsg:
+		m.refresh()
+		if m.table != nil && m.table.Started {
+			return newTableGameModel(m.svc, m.table.ID, m.width, m.height, m.theme, m.ascii, m.locale, m.keys), waitForUpdate(m.svc)
+		}
+		return m, waitForUpdate(m.svc)
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.svc.Close()
+			return m, tea.Quit
+		}
+		switch {
+		case m.creating:
+			m.updateCreating(msg)
+		case m.table != nil:
+			m.updateTable(msg)
+		default:
+			if msg.String() == "q" {
+				m.svc.Close()
+				return m, tea.Quit
+			}
+			m.updateList(msg)
+		}
+		// an update is already being waited for, which the game screen inherits
+		if m.table != nil && m.table.Started {
+			return newTableGameModel(m.svc, m.table.ID, m.width, m.height, m.theme, m.ascii, m.locale, m.keys), nil
+		}
+	}
+	return m, nil
+}
+
+func (m *lobbyModel) updateList(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "up":
+		m.selected = max(m.selected-1, 0)
+	case "down":
+		m.selected = min(m.selected+1, max(len(m.tables)-1, 0))
+	case "n":
+		m.creating = true
+	case "enter":
+		if m.selected < len(m.tables) {
+			m.setResult(m.svc.Join(m.tables[m.selected].ID))
+			m.seatCursor = 0
+		}
+	}
+}
+
+func (m *lobbyModel) updateCreating(msg tea.KeyMsg) {
+	// cycle moves an index through n choices in the direction of the arrow
+	cycle := func(i, n int) int {
+		if msg.String() == "left" {
+			return (i + n - 1) % n
+		}
+		return (i + 1) % n
+	}
+	switch msg.String() {
+	case "up":
+		m.createField = (m.createField + createFields - 1) % createFields
+	case "down":
+		m.createField = (m.createField + 1) % createFields
+	case "left", "right":
+		switch m.createField {
+		case 0:
+			m.maxPlayers = 7 - m.maxPlayers // toggles between 3 and 4
+		case 1:
+			m.timeout = cycle(m.timeout, len(decisionTimeouts))
+		case 2:
+			if m.takeover == lobby.TakeoverHost {
+				m.takeover = lobby.TakeoverBot
+			} else {
+				m.takeover = lobby.TakeoverHost
+			}
+		case 3:
+			m.turnLimit = cycle(m.turnLimit, len(turnLimits))
+		case 4:
+			m.gameLimit = cycle(m.gameLimit, len(gameLimits))
+		}
+	case "enter":
+		m.creating = false
+		m.setResult(m.svc.Create(lobby.Options{
+			MaxPlayers:      m.maxPlayers,
+			DecisionTimeout: decisionTimeouts[m.timeout],
+			Takeover:        m.takeover,
+			Clock: game.TimeControl{
+				TurnLimit: turnLimits[m.turnLimit],
+				GameLimit: gameLimits[m.gameLimit],
+			},
+		}))
+	case "esc":
+		m.creating = false
+	}
+}
+
+// durationChoice shows a duration setting, where zero means none
+func durationChoice(d time.Duration, none string) string {
+	if d == 0 {
+		return none
+	}
+	return d.String()
+}
+
+func (m lobbyModel) viewCreating() string {
+	l := m.locale
+	takeover := l.T("lobby.takeover_host")
+	if m.takeover == lobby.TakeoverBot {
+		takeover = l.T("lobby.takeover_bot")
+	}
+	fields := []string{
+		l.T("lobby.seats", m.maxPlayers),
+		l.T("lobby.wait", durationChoice(decisionTimeouts[m.timeout], l.T("lobby.forever"))),
+		l.T("lobby.then", takeover),
+		l.T("lobby.turn_time", durationChoice(turnLimits[m.turnLimit], l.T("lobby.unlimited"))),
+		l.T("lobby.player_time", durationChoice(gameLimits[m.gameLimit], l.T("lobby.unlimited"))),
+	}
+	for i := range fields {
+		if i == m.createField {
+			fields[i] = "> " + fields[i]
+		} else {
+			fields[i] = "  " + fields[i]
+		}
+	}
+	return l.T("lobby.new_table") + "\n\n" + strings.Join(fields, "\n")
+}
+
+func (m *lobbyModel) updateTable(msg tea.KeyMsg) {
+	colors := lobby.ColorNames()
+	switch msg.String() {
+	case "up":
+		m.seatCursor = max(m.seatCursor-1, 0)
+	case "down":
+		m.seatCursor = min(m.seatCursor+1, len(m.table.Seats)-1)
+	case "left":
+		m.colorIndex = (m.colorIndex + len(colors) - 1) % len(colors)
+	case "right":
+		m.colorIndex = (m.colorIndex + 1) % len(colors)
+	case "enter":
+		m.setResult(m.svc.ChooseSeat(m.table.ID, m.seatCursor, colors[m.colorIndex]))
+	case "r":
+		ready := false
+		if seat := m.table.SeatOf(m.svc.Player()); seat >= 0 {
+			ready = !m.table.Seats[seat].Ready
+		}
+		m.setResult(m.svc.SetReady(m.table.ID, ready))
+	case "s":
+		if err := m.svc.Start(m.table.ID); err != nil {
+			m.err = i18n.Text(m.locale, err)
+			return
+		}
+		m.err = ""
+		m.refresh()
+	case "esc":
+		if err := m.svc.Leave(m.table.ID); err != nil {
+			m.err = i18n.Text(m.locale, err)
+			return
+		}
+		m.err = ""
+		m.table = nil
+		m.refresh()
+	}
+}
+
+func (m lobbyModel) View() string {
+	var content, help string
+	switch {
+	case m.creating:
+		content = m.viewCreating()
+		help = m.locale.T("lobby.help_creating")
+	case m.table != nil:
+		content = m.viewTable()
+		help = m.locale.T("lobby.help_table")
+	default:
+		content = m.viewList()
+		help = m.locale.T("lobby.help_list")
+	}
+	if m.err != "" {
+		help = m.err
+	}
+	box := lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(1, 2).Render(content)
+	help = lipgloss.PlaceHorizontal(m.width, lipgloss.Center, help)
+	main := lipgloss.Place(m.width, m.height-lipgloss.Height(help), lipgloss.Center, lipgloss.Center, box)
+	view := lipgloss.JoinVertical(lipgloss.Left, main, help)
+	if m.ascii {
+		view = game.ASCII(view)
+	}
+	return view
+}
+
+func (m lobbyModel) viewList() string {
+	l := m.locale
+	lines := []string{l.T("lobby.tables", m.svc.Player()), ""}
+	if len(m.tables) == 0 {
+		lines = append(lines, l.T("lobby.no_tables"))
+	}
+	for i, t := range m.tables {
+		seated := 0
+		for _, seat := range t.Seats {
+			if seat.Player != "" {
+				seated++
+			}
+		}
+		status := l.T("lobby.open")
+		if t.Started {
+			status = l.T("lobby.in_progress")
+		}
+		line := l.T("lobby.table", t.Options.Name, seated, len(t.Seats), status)
+		if i == m.selected {
+			line = "> " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m lobbyModel) viewTable() string {
+	l := m.locale
+	colors := lobby.ColorNames()
+	lines := []string{
+		l.T("lobby.host", m.table.Options.Name, m.table.Host),
+		"",
+		l.T("lobby.your_color", l.T("color."+colors[m.colorIndex])),
+		"",
+	}
+	for i, seat := range m.table.Seats {
+		var line string
+		if seat.Player == "" {
+			line = l.T("lobby.seat_open", i+1)
+		} else {
+			ready := l.T("lobby.not_ready")
+			if seat.Ready {
+				ready = l.T("lobby.ready")
+			}
+			line = l.T("lobby.seat", i+1, seat.Player, l.T("color."+seat.Color), ready)
+		}
+		if i == m.seatCursor {
+			line = "> " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", l.T("lobby.joined", strings.Join(m.table.Members, ", ")))
+	return strings.Join(lines, "\n")
+}
+
+// tableGameModel plays a game hosted by the lobby. Rendering happens on the
+// lobby's side so remote players see exactly what a local player would.
+type tableGameModel struct {
+	svc            lobby.Service
+	tableID        int
+	info           lobby.TableInfo
+	width          int
+	height         int
+	twoColumnCycle int
+	oneColumnCycle int
+	frame          string
+	err            string
+	chat           *chatInput
+	command        *chatInput
+	log            *logPane
+	overlay        board.Overlay
+	theme          *board.Theme
+	ascii          bool
+	locale         i18n.Locale
+	keys           *keymap
+	// confirming is the destructive action waiting to be confirmed
+	confirming keyAction
+	// showingKeys is set while the help lists the keys
+	showingKeys bool
+}
+
+// pressKeys are the keys the lobby's games understand, see lobby.Service.Press
+var pressKeys = map[keyAction]string{
+	keyUp: "up", keyDown: "down", keyLeft: "left", keyRight: "right",
+	keyNext: "n", keyConfirm: "enter", keyCancel: "esc", keyHint: "?",
+}
+
+func newTableGameModel(svc lobby.Service, tableID, width, height int, theme *board.Theme, ascii bool, locale i18n.Locale, keys *keymap) tableGameModel {
+	m := tableGameModel{svc: svc, tableID: tableID, width: width, height: height, theme: theme, ascii: ascii, locale: locale, keys: keys, chat: &chatInput{}, command: &chatInput{}, log: &logPane{}}
+	m.render()
+	return m
+}
+
+func (m tableGameModel) Init() tea.Cmd {
+	return waitForUpdate(m.svc)
+}
+
+func (m *tableGameModel) render() {
+	if info, err := m.svc.Table(m.tableID); err == nil {
+		m.info = info
+	}
+	frame, err := m.svc.Render(m.tableID, m.view())
+	if err != nil {
+		frame = i18n.Text(m.locale, err)
+	}
+	m.frame = frame
+}
+
+func (m tableGameModel) view() lobby.View {
+	return lobby.View{
+		Width:          m.width,
+		Height:         m.height - 1, // leave room for the status line
+		TwoColumnCycle: m.twoColumnCycle,
+		OneColumnCycle: m.oneColumnCycle,
+		ChatFocused:    m.chat.focused,
+		ChatDraft:      m.chat.draft,
+		CommandFocused: m.command.focused,
+		CommandDraft:   m.command.draft,
+		CommandOptions: m.command.options,
+		Overlay:        m.overlay,
+		Theme:          m.theme.Name,
+		ASCII:          m.ascii,
+		Locale:         m.locale,
+		LogFocused:     m.log.focused,
+		LogFilter:      m.log.shownFilter(),
+		LogScroll:      m.log.scroll,
+		LogSearching:   m.log.search.focused,
+		LogHelp:        m.log.help(m.locale, m.keys),
+	}
+}
+
+// players are the names of the seated players, to filter the log by
+func (m tableGameModel) players() []string {
+	var players []string
+	for _, seat := range m.info.Seats {
+		if seat.Player != "" {
+			players = append(players, seat.Player)
+		}
+	}
+	return players
+}
+
+// logLength counts the log entries the filter lets through, none if the
+// lobby can't tell
+func (m tableGameModel) logLength() int {
+	length, _ := m.svc.LogLength(m.tableID, m.view())
+	return length
+}
+
+// abandonedSeat returns the first seat waiting to be reassigned, or -1
+func (m tableGameModel) abandonedSeat() int {
+	for i, seat := range m.info.Seats {
+		if seat.Abandoned {
+			return i
+		}
+	}
+	return -1
+}
+
+// spectator returns a member of the table without a seat, or ""
+func (m tableGameModel) spectator() string {
+	for _, member := range m.info.Members {
+		if m.info.SeatOf(member) < 0 {
+			return member
+		}
+	}
+	return ""
+}
+
+func (m tableGameModel) status() string {
+	if m.confirming == keyQuit {
+		return lipgloss.NewStyle().Bold(true).Render(m.locale.T("keys.confirm_leave"))
+	}
+	if m.err != "" {
+		return m.err
+	}
+	var parts []string
+	for _, seat := range m.info.Seats {
+		switch {
+		case seat.Player == "":
+		case seat.Bot:
+			parts = append(parts, m.locale.T("lobby.status_bot", seat.Player))
+		case seat.Abandoned:
+			parts = append(parts, m.locale.T("lobby.status_abandoned", seat.Player))
+		case !seat.Connected:
+			parts = append(parts, m.locale.T("lobby.status_disconnected", seat.Player))
+		}
+	}
+	if m.info.Host == m.svc.Player() && m.abandonedSeat() >= 0 {
+		hint := m.locale.T("lobby.give_bot", m.keys.key(keyGiveBot))
+		if spectator := m.spectator(); spectator != "" {
+			hint = m.locale.T("lobby.give_bot_or", hint, m.keys.key(keyGiveSpectator), spectator)
+		}
+		parts = append(parts, hint)
+	}
+	return strings.Join(parts, ". ")
+}
+
+func (m tableGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.showingKeys {
+			m.showingKeys = false
+			break
+		}
+		if m.confirming != "" {
+			// the same key again also confirms
+			action, _ := m.keys.action(msg)
+			confirmed := msg.String() == "y" || action == m.confirming
+			if confirmed && m.confirming == keyQuit {
+				m.svc.Close()
+				return m, tea.Quit
+			}
+			m.confirming = ""
+			break
+		}
+		if m.log.focused && msg.String() != "ctrl+c" {
+			m.log.update(msg, m.keys, m.players(), m.logLength)
+			break
+		}
+		if m.chat.focused && msg.String() != "ctrl+c" {
+			if text, send := m.chat.update(msg); send {
+				m.err = ""
+				if err := m.svc.Chat(m.tableID, text); err != nil {
+					m.err = i18n.Text(m.locale, err)
+				}
+			}
+			break
+		}
+		if m.command.focused && msg.String() != "ctrl+c" {
+			if msg.Type == tea.KeyTab {
+				if line, options, err := m.svc.Complete(m.tableID, m.command.draft); err == nil {
+					m.command.draft, m.command.options = line, options
+				}
+				break
+			}
+			if text, run := m.command.update(msg); run {
+				m.err = ""
+				// the update notification triggers the re-render
+				if err := m.svc.Command(m.tableID, text); err != nil {
+					m.err = i18n.Text(m.locale, err)
+				}
+			}
+			break
+		}
+		action, _ := m.keys.action(msg)
+		if destructive[action] {
+			m.confirming = action
+			break
+		}
+		switch action {
+		case keyChat:
+			m.chat.focus()
+		case keyCommand:
+			m.err = ""
+			m.command.focus()
+		case keyLog:
+			m.log.focus()
+			// bring the log into view in the narrow layouts
+			m.twoColumnCycle, m.oneColumnCycle = 1, 2
+		case keyLayout:
+			m.twoColumnCycle = (m.twoColumnCycle + 1) % 2
+			m.oneColumnCycle = (m.oneColumnCycle + 1) % 3
+		case keyOverlay:
+			m.overlay = m.overlay.Next()
+			if m.overlay != board.OverlayNone {
+				// bring the board into view in the narrow layouts
+				m.twoColumnCycle, m.oneColumnCycle = 0, 1
+			}
+		case keyTheme:
+			m.theme = m.theme.Next()
+		case keyHelp:
+			m.showingKeys = true
+		case keyUp, keyDown, keyLeft, keyRight, keyNext, keyConfirm, keyCancel, keyHint:
+			// the update notification triggers the re-render
+			m.svc.Press(m.tableID, pressKeys[action])
+			return m, nil
+		case keyGiveBot, keyGiveSpectator:
+			seat := m.abandonedSeat()
+			if seat < 0 {
+				break
+			}
+			player := ""
+			if action == keyGiveSpectator {
+				if player = m.spectator(); player == "" {
+					break
+				}
+			}
+			m.err = ""
+			if err := m.svc.Reassign(m.tableID, seat, player); err != nil {
+				m.err = i18n.Text(m.locale, err)
+			}
+		}
+	case tea.MouseMsg:
+		if m.chat.focused || m.command.focused || m.log.focused {
+			break
+		}
+		button := ""
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			button = "left"
+		} else if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonRight {
+			button = "right"
+		} else if msg.Action != tea.MouseActionMotion {
+			break
+		}
+		// the update notification triggers the re-render
+		m.svc.Point(m.tableID, m.view(), msg.X, msg.Y, button)
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case disconnectedMsg:
+		m.svc.Close()
+		return m, tea.Quit
+	case lobbyUpdateMsg:
+		if info, err := m.svc.Table(m.tableID); err == nil && info.Over {
+			m.svc.Close()
+			return m, tea.Quit
+		}
+		m.render()
+		return m, waitForUpdate(m.svc)
+	}
+	m.render()
+	return m, nil
+}
+
+func (m tableGameModel) View() string {
+	status := lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.status())
+	if m.ascii {
+		status = game.ASCII(status)
+	}
+	frame := lipgloss.JoinVertical(lipgloss.Left, m.frame, status)
+	if m.showingKeys {
+		help := m.keys.help(m.locale, tableActions)
+		if m.ascii {
+			help = game.ASCII(help)
+		}
+		frame = overlayBox(frame, help, m.width, m.height)
+	}
+	return frame
+}
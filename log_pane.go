@@ -0,0 +1,90 @@
+package main
+
+import (
+	"el_poblador/game"
+	"el_poblador/i18n"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logPage is how far PgUp and PgDn scroll the log
+const logPage = 10
+
+// logPane is the user reading the whole action log: scrolling through it,
+// filtering it and searching it
+type logPane struct {
+	focused bool
+	scroll  int
+	filter  game.LogFilter
+	search  chatInput
+}
+
+func (p *logPane) focus() {
+	p.focused = true
+	p.scroll = 0
+}
+
+// update handles a key while the log is focused. players are the names to
+// filter by, length counts the entries the filter lets through.
+func (p *logPane) update(msg tea.KeyMsg, keys *keymap, players []string, length func() int) {
+	defer func() { p.scroll = max(min(p.scroll, length()-1), 0) }()
+	if p.search.focused {
+		// the log is searched as the user types, esc stops searching
+		text, _ := p.search.update(msg)
+		if !p.search.focused {
+			p.filter.Search = ""
+			if msg.Type == tea.KeyEnter {
+				p.filter.Search = text
+			}
+		}
+		p.scroll = 0
+		return
+	}
+	switch action, _ := keys.action(msg); action {
+	case keyUp:
+		p.scroll--
+	case keyDown:
+		p.scroll++
+	case keyCancel, keyLog:
+		p.focused = false
+	case keyLogPageUp:
+		p.scroll -= logPage
+	case keyLogPageDown:
+		p.scroll += logPage
+	case keyLogTop:
+		p.scroll = 0
+	case keyLogBottom:
+		p.scroll = length()
+	case keyLogPlayer:
+		// every player in turn, then all of them again
+		next := slices.Index(players, p.filter.Player) + 1
+		p.filter.Player = ""
+		if next < len(players) {
+			p.filter.Player = players[next]
+		}
+		p.scroll = 0
+	case keyLogEvent:
+		p.filter.Event = p.filter.Event.Next()
+		p.scroll = 0
+	case keyLogSearch:
+		p.search.focus()
+		p.search.draft = p.filter.Search
+	}
+}
+
+// help is the help line while the log is focused, naming the user's keys
+func (p *logPane) help(l i18n.Locale, keys *keymap) string {
+	return l.T("log.help", keys.key(keyUp), keys.key(keyDown), keys.key(keyLogPageUp), keys.key(keyLogPageDown),
+		keys.key(keyLogPlayer), keys.key(keyLogEvent), keys.key(keyLogSearch), keys.key(keyCancel))
+}
+
+// shownFilter is the filter the log is drawn with, searching for what the
+// user is typing while they search
+func (p *logPane) shownFilter() game.LogFilter {
+	filter := p.filter
+	if p.search.focused {
+		filter.Search = p.search.draft
+	}
+	return filter
+}
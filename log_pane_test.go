@@ -0,0 +1,87 @@
+package main
+
+import (
+	"el_poblador/game"
+	"el_poblador/i18n"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLogPaneKeys(t *testing.T) {
+	keys, _ := loadKeymap("vim")
+	players := []string{"Ana", "Bob"}
+	length := func() int { return 3 }
+	p := &logPane{}
+	p.focus()
+	press := func(key string) {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		p.update(msg, keys, players, length)
+	}
+
+	for range 5 {
+		press("j")
+	}
+	if p.scroll != 2 {
+		t.Errorf("Expected scrolling to stop at the oldest entry, got %d", p.scroll)
+	}
+	press("p")
+	press("p")
+	press("e")
+	if p.filter.Player != "Bob" || p.filter.Event != game.LogProduction || p.scroll != 0 {
+		t.Errorf("Expected Bob's production from the top, got %+v at %d", p.filter, p.scroll)
+	}
+	press("p")
+	if p.filter.Player != "" {
+		t.Errorf("Expected every player after the last, got %q", p.filter.Player)
+	}
+
+	press("/")
+	press("b")
+	press("r")
+	if got := p.shownFilter().Search; got != "br" {
+		t.Errorf("Expected the log searched as the user types, got %q", got)
+	}
+	press("enter")
+	if p.filter.Search != "br" || p.search.focused {
+		t.Errorf("Expected enter to keep the search, got %q", p.filter.Search)
+	}
+	press("/")
+	press("esc")
+	if p.filter.Search != "" || !p.focused {
+		t.Errorf("Expected esc to drop the search and keep reading, got %q", p.filter.Search)
+	}
+	press("esc")
+	if p.focused {
+		t.Errorf("Expected esc to go back to the game")
+	}
+}
+
+func TestLogPaneFollowsTheKeymap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys")
+	os.WriteFile(path, []byte("log_player = P\n"), 0o644)
+	keys, err := loadKeymap(path)
+	if err != nil {
+		t.Fatalf("loadKeymap failed: %v", err)
+	}
+	p := &logPane{}
+	p.focus()
+	for _, key := range []string{"p", "P"} {
+		p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}, keys, []string{"Ana", "Bob"}, func() int { return 3 })
+	}
+	if p.filter.Player != "Ana" {
+		t.Errorf("Expected only P to filter by player, got %q", p.filter.Player)
+	}
+	if help := p.help(i18n.English, keys); !strings.Contains(help, "P player") {
+		t.Errorf("Expected the help to name P, got %q", help)
+	}
+}
@@ -2,12 +2,24 @@ package main
 
 import (
 	"bytes"
+	"el_poblador/arena"
+	"el_poblador/board"
 	"el_poblador/game"
+	"el_poblador/i18n"
+	"el_poblador/lobby"
 	"encoding/gob"
+	"flag"
 	"fmt"
+	"math/rand/v2"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 type model struct {
@@ -17,48 +29,234 @@ type model struct {
 	userPlayer     *int
 	twoColumnCycle int // 0-1: for width 90-119
 	oneColumnCycle int // 0-2: for width <90
+	overlay        board.Overlay
+	theme          *board.Theme
+	ascii          bool
+	locale         i18n.Locale
+	chat           *chatInput
+	command        *chatInput
+	log            *logPane
+	keys           *keymap
+	// confirming is the destructive action waiting to be confirmed
+	confirming keyAction
+	// showingKeys is set while the help lists the keys
+	showingKeys bool
+	lastTick    time.Time
+	// thinking is set while a bot works out its move away from the game
+	thinking bool
+}
+
+type tickMsg time.Time
+
+// tick drives the game clock
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+// botMoveMsg brings back a bot's move, worked out off the UI loop so slow
+// bots don't freeze the screen. A nil move just starts the bots.
+type botMoveMsg struct{ move *game.BotMove }
+
+// playBots lets the bots the game waits on think, one move at a time
+func (m *model) playBots() tea.Cmd {
+	if m.thinking {
+		// the move being worked out asks for the next one
+		return nil
+	}
+	move := m.game.NextBotMove()
+	if move == nil {
+		return nil
+	}
+	m.thinking = true
+	return func() tea.Msg {
+		move.Think()
+		return botMoveMsg{move}
+	}
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	// the computer may go first
+	return tea.Batch(tick(), func() tea.Msg { return botMoveMsg{} })
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
-		case "tab":
+		if m.showingKeys {
+			m.showingKeys = false
+			return m, nil
+		}
+		if m.confirming != "" {
+			// the same key again also confirms
+			action, _ := m.keys.action(msg)
+			confirmed := msg.String() == "y" || action == m.confirming
+			if confirmed && m.confirming == keyQuit {
+				return m, tea.Quit
+			}
+			m.confirming = ""
+			return m, nil
+		}
+		if m.log.focused && msg.String() != "ctrl+c" {
+			var players []string
+			for _, player := range m.game.Players {
+				players = append(players, player.Name)
+			}
+			m.log.update(msg, m.keys, players, func() int { return m.game.LogLength(m.viewport()) })
+			return m, nil
+		}
+		if m.chat.focused && msg.String() != "ctrl+c" {
+			if text, send := m.chat.update(msg); send {
+				if err := m.game.SendChat(m.game.PerspectiveOf(m.userPlayer), text); err != nil {
+					m.chat.err = i18n.Text(m.locale, err)
+				}
+			}
+			return m, nil
+		}
+		if m.command.focused && msg.String() != "ctrl+c" {
+			if msg.Type == tea.KeyTab {
+				m.command.draft, m.command.options = m.game.CompleteCommand(m.userPlayer, m.command.draft)
+				return m, nil
+			}
+			if text, run := m.command.update(msg); run {
+				if err := m.game.RunCommand(m.userPlayer, text); err != nil {
+					m.command.err = i18n.Text(m.locale, err)
+				}
+				if m.game.ShouldQuit() {
+					return m, tea.Quit
+				}
+				cmd := m.playBots()
+				return m, cmd
+			}
+			return m, nil
+		}
+		action, _ := m.keys.action(msg)
+		if destructive[action] {
+			m.confirming = action
+			return m, nil
+		}
+		switch action {
+		case keyChat:
+			m.chat.focus()
+		case keyCommand:
+			m.command.focus()
+		case keyLog:
+			m.log.focus()
+			// bring the log into view in the narrow layouts
+			m.twoColumnCycle, m.oneColumnCycle = 1, 2
+		case keyLayout:
 			m.twoColumnCycle = (m.twoColumnCycle + 1) % 2
 			m.oneColumnCycle = (m.oneColumnCycle + 1) % 3
-		case "up", "down", "left", "right":
-			m.game.MoveCursor(msg.String(), m.userPlayer)
-		case "enter":
+		case keyOverlay:
+			m.overlay = m.overlay.Next()
+			if m.overlay != board.OverlayNone {
+				// bring the board into view in the narrow layouts
+				m.twoColumnCycle, m.oneColumnCycle = 0, 1
+			}
+		case keyTheme:
+			m.theme = m.theme.Next()
+		case keyHelp:
+			m.showingKeys = true
+		case keyUp, keyDown, keyLeft, keyRight:
+			m.game.MoveCursor(string(action), m.userPlayer)
+		case keyNext:
+			m.game.MoveCursor("next", m.userPlayer)
+		case keyConfirm:
 			m.game.ConfirmAction(m.userPlayer)
 			if m.game.ShouldQuit() {
 				return m, tea.Quit
 			}
-		case "esc":
+		case keyCancel:
 			m.game.CancelAction(m.userPlayer)
+		case keyHint:
+			m.game.ShowHint(m.userPlayer)
 		// switch to specific player's perspective
-		case "1", "2", "3", "4":
-			player := int(msg.String()[0] - '1')
+		case keyPlayer1, keyPlayer2, keyPlayer3, keyPlayer4:
+			player := slices.Index(playerKeys, action)
 			m.userPlayer = &player
 		// switch back to turn holder's perspective
-		case "0":
+		case keyTurnHolder:
 			m.userPlayer = nil
 		}
+		cmd := m.playBots()
+		return m, cmd
+	case tea.MouseMsg:
+		if m.chat.focused || m.command.focused || m.log.focused {
+			break
+		}
+		switch {
+		case msg.Action == tea.MouseActionMotion:
+			m.game.Hover(m.viewport(), msg.X, msg.Y)
+		case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+			m.game.Click(m.viewport(), msg.X, msg.Y, "left")
+		case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonRight:
+			m.game.Click(m.viewport(), msg.X, msg.Y, "right")
+		}
+		if m.game.ShouldQuit() {
+			return m, tea.Quit
+		}
+		cmd := m.playBots()
+		return m, cmd
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-
+	case tickMsg:
+		m.game.Tick(time.Time(msg).Sub(m.lastTick))
+		m.game.UpdateWinChances()
+		m.lastTick = time.Time(msg)
+		cmd := m.playBots()
+		return m, tea.Batch(tick(), cmd)
+	case botMoveMsg:
+		if msg.move != nil {
+			m.thinking = false
+			m.game.PlayBotMove(msg.move)
+		}
+		cmd := m.playBots()
+		return m, cmd
 	}
 	return m, nil
 }
 
 func (m model) View() string {
-	return m.game.Print(m.width, m.height, m.userPlayer, m.twoColumnCycle, m.oneColumnCycle)
+	frame := m.game.Render(m.viewport())
+	if m.showingKeys {
+		help := m.keys.help(m.locale, localActions)
+		if m.ascii {
+			help = game.ASCII(help)
+		}
+		frame = overlayBox(frame, help, m.width, m.height)
+	}
+	return frame
+}
+
+func (m model) viewport() game.Viewport {
+	var prompt string
+	if m.confirming == keyQuit {
+		prompt = m.locale.T("keys.confirm_quit")
+	}
+	return game.Viewport{
+		Width:          m.width,
+		Height:         m.height,
+		Player:         m.userPlayer,
+		TwoColumnCycle: m.twoColumnCycle,
+		OneColumnCycle: m.oneColumnCycle,
+		ChatFocused:    m.chat.focused,
+		ChatDraft:      m.chat.draft,
+		ChatError:      m.chat.err,
+		CommandFocused: m.command.focused,
+		CommandDraft:   m.command.draft,
+		CommandOptions: m.command.options,
+		CommandError:   m.command.err,
+		Prompt:         prompt,
+		LogFocused:     m.log.focused,
+		LogFilter:      m.log.shownFilter(),
+		LogScroll:      m.log.scroll,
+		LogSearching:   m.log.search.focused,
+		LogHelp:        m.log.help(m.locale, m.keys),
+		Overlay:        m.overlay,
+		Theme:          m.theme.Name,
+		ASCII:          m.ascii,
+		Locale:         m.locale,
+	}
 }
 
 func loadGameState(filename string) (*game.Game, error) {
@@ -81,12 +279,108 @@ func loadGameState(filename string) (*game.Game, error) {
 
 func printUsage() {
 	fmt.Println("Usage:")
-	fmt.Println("  el_poblador new <player1> <player2> <player3> [player4]")
+	fmt.Println("  el_poblador [--theme default] [--ascii] [--locale es] [--keys vim] <command> ...")
+	fmt.Println("  el_poblador new [--turn-time 2m] [--game-time 15m] [--warn-only] <player1> <player2> <player3> [player4]")
 	fmt.Println("  el_poblador load <filename.gob>")
+	fmt.Println("  el_poblador arena [--games 100] [--seed 1] [--max-turns 500] <player1> <player2> <player3> [player4]")
+	fmt.Println("  el_poblador serve <address>")
+	fmt.Println("  el_poblador host <address> <name>")
+	fmt.Println("  el_poblador connect <address> <name>")
 	fmt.Println()
 	fmt.Println("Commands:")
-	fmt.Println("  new   Start a new game with 3-4 players")
-	fmt.Println("  load  Load a saved game from file")
+	fmt.Println("  new      Start a new game with 3-4 players, 'bot:<name>' players are played by the computer")
+	fmt.Println("           ('bot:easy:<name>' and 'bot:hard:<name>' set how well, 'bot:mcts:<name>' searches)")
+	fmt.Println("           and 'engine:<program>' players by a program speaking the engine protocol")
+	fmt.Println("  load     Load a saved game from file")
+	fmt.Println("  arena    Play games between computer players and compare them: random, greedy, easy,")
+	fmt.Println("           normal, hard, mcts or engine:<program>")
+	fmt.Println("  serve    Run a lobby server for network games")
+	fmt.Println("  host     Run a lobby server and join it")
+	fmt.Println("  connect  Join a lobby server")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --theme  Colors to draw in: " + strings.Join(themeNames(), ", "))
+	fmt.Println("  --ascii  Draw with ASCII characters only")
+	fmt.Println("  --locale Language to play in: " + strings.Join(localeNames(), ", ") + ", from $LANG by default")
+	fmt.Println("  --keys   Key bindings: " + strings.Join(keyPresetNames, ", ") + " or a file, " + keysConfigPath() + " by default")
+}
+
+func themeNames() []string {
+	var names []string
+	for _, theme := range board.Themes {
+		names = append(names, theme.Name)
+	}
+	return names
+}
+
+func localeNames() []string {
+	var names []string
+	for _, locale := range i18n.Locales {
+		names = append(names, string(locale))
+	}
+	return names
+}
+
+// engineName names an engine's seat after its program, numbering it if
+// another player already goes by that name
+func engineName(program string, taken map[string]bool) string {
+	name := filepath.Base(program)
+	for n := 2; taken[name]; n++ {
+		name = fmt.Sprintf("%s %d", filepath.Base(program), n)
+	}
+	return name
+}
+
+// seatPlayers starts a game of the players in random seats, each bot going
+// with the name at the same index, so players may share a name
+func seatPlayers(names []string, bots []game.Bot) *game.Game {
+	seats := make([]game.Seat, len(names))
+	for i, name := range names {
+		seats[i] = game.Seat{Name: name, Color: game.PlayerColors[i].Color}
+	}
+	order := rand.Perm(len(seats))
+	shuffled := make([]game.Seat, len(seats))
+	for to, from := range order {
+		shuffled[to] = seats[from]
+	}
+	g := &game.Game{}
+	g.StartSeated(shuffled)
+	for to, from := range order {
+		if bots[from] != nil {
+			g.SetBot(to, bots[from])
+		}
+	}
+	return g
+}
+
+// runArena plays the games between the players and prints how they did
+func runArena(specs []string, games int, seed uint64, maxTurns int) error {
+	config := arena.Config{Games: games, Seed: seed, MaxTurns: maxTurns}
+	for _, spec := range specs {
+		entrant, err := arena.ParseEntrant(spec)
+		if err != nil {
+			return err
+		}
+		config.Entrants = append(config.Entrants, entrant)
+	}
+	config.Progress = func(played int) {
+		fmt.Fprintf(os.Stderr, "\rgame %d/%d", played, games)
+	}
+	result, err := arena.Run(config)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return err
+	}
+	result.Report(os.Stdout)
+	return nil
+}
+
+// serveLobby runs a lobby server until it fails.
+// Frames are rendered on the server, so assume a capable terminal on the other side.
+func serveLobby(l *lobby.Lobby, addr string) error {
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	lipgloss.SetHasDarkBackground(true)
+	return lobby.NewServer(l).ListenAndServe(addr)
 }
 
 func main() {
@@ -97,6 +391,30 @@ func main() {
 		args = args[1:]
 	}
 
+	options := flag.NewFlagSet("el_poblador", flag.ExitOnError)
+	options.Usage = printUsage
+	themeName := options.String("theme", board.DefaultTheme.Name, "colors to draw in")
+	ascii := options.Bool("ascii", false, "draw with ASCII characters only")
+	localeName := options.String("locale", string(i18n.FromEnvironment()), "language to play in")
+	keysName := options.String("keys", "", "key bindings, a preset or a file")
+	options.Parse(args)
+	args = options.Args()
+	theme, ok := board.ThemeNamed(*themeName)
+	if !ok {
+		fmt.Printf("Error: unknown theme '%s', pick one of %s\n", *themeName, strings.Join(themeNames(), ", "))
+		os.Exit(1)
+	}
+	locale, ok := i18n.Parse(*localeName)
+	if !ok {
+		fmt.Printf("Error: unknown locale '%s', pick one of %s\n", *localeName, strings.Join(localeNames(), ", "))
+		os.Exit(1)
+	}
+	keys, err := loadKeymap(*keysName)
+	if err != nil {
+		fmt.Printf("Error: bad key bindings: %v\n", err)
+		os.Exit(1)
+	}
+
 	if len(args) < 1 {
 		printUsage()
 		os.Exit(1)
@@ -107,14 +425,126 @@ func main() {
 
 	switch command {
 	case "new":
-		if len(args) < 4 || len(args) > 5 {
+		flags := flag.NewFlagSet("new", flag.ExitOnError)
+		turnLimit := flags.Duration("turn-time", 0, "time limit for each turn, e.g. 2m")
+		gameLimit := flags.Duration("game-time", 0, "time each player has for the whole game, e.g. 15m")
+		warnOnly := flags.Bool("warn-only", false, "only flag players who run out of time instead of ending their turn")
+		botTime := flags.Duration("bot-time", time.Second, "how long 'bot:mcts:<name>' players think about each decision")
+		flags.Parse(args[1:])
+		names := flags.Args()
+		if len(names) < 3 || len(names) > 4 {
 			fmt.Println("Error: 'new' command requires 3-4 player names")
 			fmt.Println()
 			printUsage()
 			os.Exit(1)
 		}
-		g = &game.Game{}
-		g.Start(args[1:])
+		bots := make([]game.Bot, len(names))
+		taken := make(map[string]bool)
+		for i, name := range names {
+			if strings.HasPrefix(name, "engine:") {
+				continue
+			}
+			if botName, ok := strings.CutPrefix(name, "bot:"); ok {
+				var bot game.Bot = game.NewHeuristicBot(game.Normal)
+				if prefix, rest, found := strings.Cut(botName, ":"); found {
+					if level, ok := game.ParseDifficulty(prefix); ok {
+						bot, botName = game.NewHeuristicBot(level), rest
+					} else if prefix == "mcts" {
+						bot, botName = game.NewMCTSBot(*botTime), rest
+					}
+				}
+				names[i] = botName
+				bots[i] = bot
+			}
+			taken[names[i]] = true
+		}
+		// engines are named after the others, so they don't take their names
+		for i, name := range names {
+			program, ok := strings.CutPrefix(name, "engine:")
+			if !ok {
+				continue
+			}
+			engine, err := game.NewEngineBot(program)
+			if err != nil {
+				fmt.Printf("Failed to start engine: %v\n", err)
+				os.Exit(1)
+			}
+			defer engine.Close()
+			names[i] = engineName(program, taken)
+			taken[names[i]] = true
+			bots[i] = engine
+		}
+		g = seatPlayers(names, bots)
+		if *turnLimit > 0 || *gameLimit > 0 {
+			control := game.TimeControl{TurnLimit: *turnLimit, GameLimit: *gameLimit}
+			if *warnOnly {
+				control.OnTimeout = game.TimeoutWarn
+			}
+			g.SetTimeControl(control)
+		}
+
+	case "arena":
+		flags := flag.NewFlagSet("arena", flag.ExitOnError)
+		games := flags.Int("games", 100, "how many games to play")
+		seed := flags.Uint64("seed", 1, "seed of the first board")
+		maxTurns := flags.Int("max-turns", 500, "turns after which a game is stopped without a winner")
+		flags.Parse(args[1:])
+		if err := runArena(flags.Args(), *games, *seed, *maxTurns); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+
+	case "serve":
+		if len(args) != 2 {
+			fmt.Println("Error: 'serve' command requires an address")
+			fmt.Println()
+			printUsage()
+			os.Exit(1)
+		}
+		fmt.Printf("Lobby listening on %s\n", args[1])
+		if err := serveLobby(lobby.New(), args[1]); err != nil {
+			fmt.Printf("Server failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+
+	case "host", "connect":
+		if len(args) != 3 {
+			fmt.Printf("Error: '%s' command requires an address and your name\n", command)
+			fmt.Println()
+			printUsage()
+			os.Exit(1)
+		}
+		var svc lobby.Service
+		if command == "host" {
+			l := lobby.New()
+			go func() {
+				if err := lobby.NewServer(l).ListenAndServe(args[1]); err != nil {
+					fmt.Printf("Server failed: %v\n", err)
+					os.Exit(1)
+				}
+			}()
+			local, err := lobby.Local(l, args[2])
+			if err != nil {
+				fmt.Printf("Failed to join own lobby: %v\n", err)
+				os.Exit(1)
+			}
+			svc = local
+		} else {
+			client, err := lobby.Dial(args[1], args[2], locale)
+			if err != nil {
+				fmt.Printf("Failed to connect: %v\n", err)
+				os.Exit(1)
+			}
+			svc = client
+		}
+		p := tea.NewProgram(newLobbyModel(svc, theme, *ascii, locale, keys), tea.WithAltScreen(), tea.WithMouseCellMotion())
+		if _, err := p.Run(); err != nil {
+			fmt.Println("Error running program:", err)
+			os.Exit(1)
+		}
+		return
 
 	case "load":
 		if len(args) != 2 {
@@ -137,7 +567,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	p := tea.NewProgram(model{game: g}, tea.WithAltScreen())
+	p := tea.NewProgram(model{game: g, theme: theme, ascii: *ascii, locale: locale, chat: &chatInput{}, command: &chatInput{}, log: &logPane{}, keys: keys, lastTick: time.Now()}, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"el_poblador/game"
+	"testing"
+)
+
+func TestSeatPlayersKeepsBotsWithTheirSeats(t *testing.T) {
+	bot := game.NewSimpleBot()
+	for range 20 {
+		g := seatPlayers([]string{"Alice", "Alice", "Bob"}, []game.Bot{bot, nil, nil})
+		bots := 0
+		for _, player := range g.Players {
+			if player.Bot {
+				bots++
+				if player.Name != "Alice" {
+					t.Fatalf("Expected Alice's seat played by the bot, got %s's", player.Name)
+				}
+			}
+		}
+		if bots != 1 {
+			t.Fatalf("Expected one bot however the seats are shuffled, got %d", bots)
+		}
+	}
+}
+
+func TestEngineNamesAreNotTaken(t *testing.T) {
+	taken := map[string]bool{"engine": true, "engine 2": true}
+	if name := engineName("/usr/bin/engine", taken); name != "engine 3" {
+		t.Errorf("Expected engine 3, got %q", name)
+	}
+}